@@ -0,0 +1,94 @@
+// Command hash-passwords replaces passwords stored in plaintext, from before
+// hashing was introduced, with hashes from the configured hasher. It is safe to
+// run repeatedly and alongside the server; once it reports nothing left to do
+// PASSWORD_ALLOW_PLAINTEXT can be turned off.
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"log"
+
+	userStore "github.com/colmmurphy91/muzz/internal/adapter/mysql/user"
+	"github.com/colmmurphy91/muzz/internal/pkg"
+	"github.com/colmmurphy91/muzz/internal/pkg/envvar"
+	"github.com/colmmurphy91/muzz/internal/pkg/password"
+)
+
+func main() {
+	var (
+		env       string
+		batchSize int
+	)
+
+	flag.StringVar(&env, "env", "env.example", "Environment Variables filename")
+	flag.IntVar(&batchSize, "batch-size", 500, "Number of users to hash per query")
+	flag.Parse()
+
+	if err := run(env, batchSize); err != nil {
+		log.Fatalf("could not hash passwords: %s", err)
+	}
+}
+
+func run(env string, batchSize int) error {
+	logger, err := pkg.New("muzz-hash-passwords")
+	if err != nil {
+		return fmt.Errorf("zap.NewProduction %w", err)
+	}
+
+	if err := envvar.Load(env); err != nil {
+		return fmt.Errorf("envar.Load %w", err)
+	}
+
+	conf := envvar.New()
+
+	db, err := pkg.NewDBConnection(conf)
+	if err != nil {
+		return fmt.Errorf("failed to create db connection: %w", err)
+	}
+	defer db.Close()
+
+	passwordHasher, err := password.New(conf)
+	if err != nil {
+		return fmt.Errorf("failed to create password hasher: %w", err)
+	}
+
+	var (
+		ctx    = context.Background()
+		store  = userStore.NewStore(logger, db)
+		lastID = 0
+		hashed = 0
+	)
+
+	for {
+		users, err := store.FindUnhashedPasswords(ctx, lastID, batchSize)
+		if err != nil {
+			return fmt.Errorf("failed to find users: %w", err)
+		}
+
+		if len(users) == 0 {
+			break
+		}
+
+		for _, user := range users {
+			hash, err := passwordHasher.Hash(user.Password)
+			if err != nil {
+				return fmt.Errorf("failed to hash password for user %d: %w", user.ID, err)
+			}
+
+			if err := store.UpdatePassword(ctx, user.ID, hash); err != nil {
+				return fmt.Errorf("failed to update password for user %d: %w", user.ID, err)
+			}
+
+			lastID = user.ID
+			hashed++
+		}
+
+		logger.Infof("hashed %d passwords so far", hashed)
+	}
+
+	logger.Infof("done, hashed %d passwords", hashed)
+
+	return nil
+}
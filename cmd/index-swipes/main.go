@@ -0,0 +1,115 @@
+// Command index-swipes rebuilds the swiped index, which discovery uses to
+// exclude people a user has already swiped on, and who each user super liked,
+// which discovery uses to put them first, from the swipes table. Run it once after upgrading, or whenever
+// the index is lost.
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"log"
+
+	elasticsearch "github.com/colmmurphy91/muzz/internal/adapter/elasticsearch"
+	swipeStore "github.com/colmmurphy91/muzz/internal/adapter/mysql/swipe"
+	"github.com/colmmurphy91/muzz/internal/entity"
+	"github.com/colmmurphy91/muzz/internal/pkg"
+	"github.com/colmmurphy91/muzz/internal/pkg/envvar"
+)
+
+func main() {
+	var (
+		env       string
+		batchSize int
+	)
+
+	flag.StringVar(&env, "env", "env.example", "Environment Variables filename")
+	flag.IntVar(&batchSize, "batch-size", 500, "Number of users to index per query")
+	flag.Parse()
+
+	if err := run(env, batchSize); err != nil {
+		log.Fatalf("could not index swipes: %s", err)
+	}
+}
+
+func run(env string, batchSize int) error {
+	logger, err := pkg.New("muzz-index-swipes")
+	if err != nil {
+		return fmt.Errorf("zap.NewProduction %w", err)
+	}
+
+	if err := envvar.Load(env); err != nil {
+		return fmt.Errorf("envar.Load %w", err)
+	}
+
+	conf := envvar.New()
+
+	db, err := pkg.NewDBConnection(conf)
+	if err != nil {
+		return fmt.Errorf("failed to create db connection: %w", err)
+	}
+	defer db.Close()
+
+	es, err := pkg.NewElasticSearch(conf)
+	if err != nil {
+		return fmt.Errorf("failed to create es connection: %w", err)
+	}
+
+	if err := pkg.CreateIndices(es); err != nil {
+		return fmt.Errorf("failed to create indices: %w", err)
+	}
+
+	var (
+		ctx     = context.Background()
+		store   = swipeStore.NewStore(logger, db)
+		swiped  = elasticsearch.NewSwiped(es)
+		lastID  = 0
+		indexed = 0
+	)
+
+	for {
+		userIDs, err := store.GetSwiperIDs(ctx, lastID, batchSize)
+		if err != nil {
+			return fmt.Errorf("failed to find swipers: %w", err)
+		}
+
+		if len(userIDs) == 0 {
+			break
+		}
+
+		for _, userID := range userIDs {
+			swipes, err := store.GetUserSwipes(ctx, userID)
+			if err != nil {
+				return fmt.Errorf("failed to get swipes for user %d: %w", userID, err)
+			}
+
+			targetIDs := make([]int, 0, len(swipes))
+			superLiked := []int{}
+
+			for _, swipe := range swipes {
+				targetIDs = append(targetIDs, swipe.TargetID)
+
+				if swipe.Preference == entity.PreferenceSuper {
+					superLiked = append(superLiked, swipe.TargetID)
+				}
+			}
+
+			if err := swiped.ReplaceSwiped(ctx, userID, targetIDs); err != nil {
+				return fmt.Errorf("failed to index swipes for user %d: %w", userID, err)
+			}
+
+			if err := swiped.ReplaceSuperLiked(ctx, userID, superLiked); err != nil {
+				return fmt.Errorf("failed to index super likes by user %d: %w", userID, err)
+			}
+
+			lastID = userID
+			indexed++
+		}
+
+		logger.Infof("indexed swipes for %d users so far", indexed)
+	}
+
+	logger.Infof("done, indexed swipes for %d users", indexed)
+
+	return nil
+}
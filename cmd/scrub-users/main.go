@@ -0,0 +1,67 @@
+// Command scrub-users brings users documents indexed by older versions in line
+// with what is written now, snapping any exact location left on them to the
+// grid and removing the emails they used to carry. Run it once after upgrading.
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"log"
+
+	elasticsearch "github.com/colmmurphy91/muzz/internal/adapter/elasticsearch"
+	"github.com/colmmurphy91/muzz/internal/pkg"
+	"github.com/colmmurphy91/muzz/internal/pkg/envvar"
+)
+
+func main() {
+	var env string
+
+	flag.StringVar(&env, "env", "env.example", "Environment Variables filename")
+	flag.Parse()
+
+	if err := run(env); err != nil {
+		log.Fatalf("could not scrub users: %s", err)
+	}
+}
+
+func run(env string) error {
+	logger, err := pkg.New("muzz-scrub-users")
+	if err != nil {
+		return fmt.Errorf("zap.NewProduction %w", err)
+	}
+
+	if err := envvar.Load(env); err != nil {
+		return fmt.Errorf("envar.Load %w", err)
+	}
+
+	conf := envvar.New()
+
+	es, err := pkg.NewElasticSearch(conf)
+	if err != nil {
+		return fmt.Errorf("failed to create es connection: %w", err)
+	}
+
+	var (
+		ctx   = context.Background()
+		users = elasticsearch.NewUser(es)
+	)
+
+	snapped, err := users.SnapLocations(ctx)
+	if err != nil {
+		return err
+	}
+
+	logger.Infof("snapped %d locations to the grid", snapped)
+
+	// Nothing searches on emails, so they are taken off rather than left lying
+	// around.
+	scrubbed, err := users.RemoveField(ctx, "email")
+	if err != nil {
+		return err
+	}
+
+	logger.Infof("done, removed %d emails", scrubbed)
+
+	return nil
+}
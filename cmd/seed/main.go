@@ -0,0 +1,118 @@
+// Command seed fills a local environment with fake users. It talks to the
+// stores directly and must never be pointed at production data.
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"log"
+	"regexp"
+	"strings"
+	"time"
+
+	faker "github.com/bxcodec/faker/v3"
+
+	elasticsearch "github.com/colmmurphy91/muzz/internal/adapter/elasticsearch"
+	"github.com/colmmurphy91/muzz/internal/adapter/mysql/uow"
+	userStore "github.com/colmmurphy91/muzz/internal/adapter/mysql/user"
+	"github.com/colmmurphy91/muzz/internal/entity"
+	"github.com/colmmurphy91/muzz/internal/pkg"
+	"github.com/colmmurphy91/muzz/internal/pkg/envvar"
+	"github.com/colmmurphy91/muzz/internal/pkg/password"
+	userM "github.com/colmmurphy91/muzz/internal/usecase/user"
+)
+
+const seedPassword = "Password1"
+
+var genders = []string{"male", "female"}
+
+func main() {
+	var (
+		env   string
+		count int
+	)
+
+	flag.StringVar(&env, "env", "env.example", "Environment Variables filename")
+	flag.IntVar(&count, "count", 10, "Number of fake users to create")
+	flag.Parse()
+
+	if err := run(env, count); err != nil {
+		log.Fatalf("could not seed users: %s", err)
+	}
+}
+
+func run(env string, count int) error {
+	logger, err := pkg.New("muzz-seed")
+	if err != nil {
+		return fmt.Errorf("zap.NewProduction %w", err)
+	}
+
+	if err := envvar.Load(env); err != nil {
+		return fmt.Errorf("envar.Load %w", err)
+	}
+
+	conf := envvar.New()
+
+	db, err := pkg.NewDBConnection(conf)
+	if err != nil {
+		return fmt.Errorf("failed to create db connection: %w", err)
+	}
+	defer db.Close()
+
+	es, err := pkg.NewElasticSearch(conf)
+	if err != nil {
+		return fmt.Errorf("failed to create es connection: %w", err)
+	}
+
+	passwordHasher, err := password.New(conf)
+	if err != nil {
+		return fmt.Errorf("failed to create password hasher: %w", err)
+	}
+
+	manager := userM.NewManager(userStore.NewStore(logger, db), elasticsearch.NewUser(es), passwordHasher, uow.NewUnitOfWork(logger, db))
+
+	for i := 0; i < count; i++ {
+		user, err := manager.CreateUser(context.Background(), generateFakeRegistration())
+		if err != nil {
+			return fmt.Errorf("failed to create user: %w", err)
+		}
+
+		logger.Infof("created user %d: %s / %s", user.ID, user.Email, seedPassword)
+	}
+
+	return nil
+}
+
+func generateFakeRegistration() entity.Registration {
+	p, _ := faker.RandomInt(entity.MinimumAge, 70, 1)
+	g, _ := faker.RandomInt(0, len(genders)-1, 1)
+
+	name := faker.Name()
+	sanitizedName := sanitizeName(name)
+	email := fmt.Sprintf("%s@muzz.com", sanitizedName)
+
+	return entity.Registration{
+		Email:       email,
+		Password:    seedPassword,
+		Name:        name,
+		Gender:      genders[g[0]],
+		DateOfBirth: time.Now().AddDate(-p[0], 0, -1),
+		Location: entity.Location{
+			Lat: faker.Latitude(),
+			Lon: faker.Longitude(),
+		},
+	}
+}
+
+func sanitizeName(name string) string {
+	// Convert the name to lowercase
+	name = strings.ToLower(name)
+	// Replace spaces with hyphens
+	name = strings.ReplaceAll(name, " ", "-")
+	// Remove invalid characters
+	re := regexp.MustCompile(`[^a-z0-9-_]`)
+	name = re.ReplaceAllString(name, "")
+
+	return name
+}
@@ -2,14 +2,22 @@ package main
 
 import (
 	"context"
+	"crypto/cipher"
+	"crypto/rand"
+	"encoding/base64"
+	"encoding/json"
 	"flag"
 	"fmt"
 	"github.com/colmmurphy91/muzz/internal/pkg"
 	"github.com/colmmurphy91/muzz/internal/pkg/envvar"
+	"github.com/colmmurphy91/muzz/internal/pkg/idempotency"
+	"github.com/colmmurphy91/muzz/internal/pkg/password"
+	"github.com/colmmurphy91/muzz/internal/pkg/signing"
 	"log"
 	"net/http"
 	"os"
 	"os/signal"
+	"strconv"
 	"syscall"
 	"time"
 
@@ -19,27 +27,70 @@ import (
 	"go.uber.org/zap"
 
 	elasticsearch "github.com/colmmurphy91/muzz/internal/adapter/elasticsearch"
+	memoryHub "github.com/colmmurphy91/muzz/internal/adapter/memory/hub"
+	memoryIdempotency "github.com/colmmurphy91/muzz/internal/adapter/memory/idempotency"
+	memoryQuota "github.com/colmmurphy91/muzz/internal/adapter/memory/quota"
+	auditStore "github.com/colmmurphy91/muzz/internal/adapter/mysql/audit"
+	blockStore "github.com/colmmurphy91/muzz/internal/adapter/mysql/block"
+	idempotencyStore "github.com/colmmurphy91/muzz/internal/adapter/mysql/idempotency"
 	matchStore "github.com/colmmurphy91/muzz/internal/adapter/mysql/match"
+	messageStore "github.com/colmmurphy91/muzz/internal/adapter/mysql/message"
+	notificationStore "github.com/colmmurphy91/muzz/internal/adapter/mysql/notification"
+	preferenceStore "github.com/colmmurphy91/muzz/internal/adapter/mysql/preference"
+	quotaStore "github.com/colmmurphy91/muzz/internal/adapter/mysql/quota"
+	reportStore "github.com/colmmurphy91/muzz/internal/adapter/mysql/report"
 	swipeStore "github.com/colmmurphy91/muzz/internal/adapter/mysql/swipe"
+	tokenStore "github.com/colmmurphy91/muzz/internal/adapter/mysql/token"
+	"github.com/colmmurphy91/muzz/internal/adapter/mysql/uow"
 	userStore "github.com/colmmurphy91/muzz/internal/adapter/mysql/user"
+	adminHttp "github.com/colmmurphy91/muzz/internal/api/admin"
+	blockHttp "github.com/colmmurphy91/muzz/internal/api/block"
 	"github.com/colmmurphy91/muzz/internal/api/discover"
+	discoverModel "github.com/colmmurphy91/muzz/internal/api/discover/model"
+	"github.com/colmmurphy91/muzz/internal/api/jwks"
+	likeHttp "github.com/colmmurphy91/muzz/internal/api/like"
 	authhttp "github.com/colmmurphy91/muzz/internal/api/login"
+	matchHttp "github.com/colmmurphy91/muzz/internal/api/match"
+	messageHttp "github.com/colmmurphy91/muzz/internal/api/message"
+	notificationHttp "github.com/colmmurphy91/muzz/internal/api/notification"
+	preferenceHttp "github.com/colmmurphy91/muzz/internal/api/preference"
+	reportHttp "github.com/colmmurphy91/muzz/internal/api/report"
 	swipeHttp "github.com/colmmurphy91/muzz/internal/api/swipe"
 	"github.com/colmmurphy91/muzz/internal/api/user"
+	"github.com/colmmurphy91/muzz/internal/entity"
+	adminService "github.com/colmmurphy91/muzz/internal/usecase/admin"
 	"github.com/colmmurphy91/muzz/internal/usecase/auth"
+	blockService "github.com/colmmurphy91/muzz/internal/usecase/block"
 	discoverService "github.com/colmmurphy91/muzz/internal/usecase/discover"
+	likeService "github.com/colmmurphy91/muzz/internal/usecase/like"
+	matchService "github.com/colmmurphy91/muzz/internal/usecase/match"
+	messageService "github.com/colmmurphy91/muzz/internal/usecase/message"
+	notificationService "github.com/colmmurphy91/muzz/internal/usecase/notification"
+	preferenceService "github.com/colmmurphy91/muzz/internal/usecase/preference"
+	reportService "github.com/colmmurphy91/muzz/internal/usecase/report"
 	swipeService "github.com/colmmurphy91/muzz/internal/usecase/swipe"
 	userM "github.com/colmmurphy91/muzz/internal/usecase/user"
 )
 
 type serverConfig struct {
-	Address     string
-	DB          *sqlx.DB
-	ES          *esv7.Client
-	MiddleWares []func(next http.Handler) http.Handler
-	Logger      *zap.SugaredLogger
-	PrivateKey  string
-	PublicKey   string
+	Address        string
+	DB             *sqlx.DB
+	ES             *esv7.Client
+	MiddleWares    []func(next http.Handler) http.Handler
+	Logger         *zap.SugaredLogger
+	SigningKeys    *signing.KeySet
+	PasswordHasher *password.Upgrader
+	TokenTTL       tokenTTL
+	Ranking        []entity.RankingStrategy
+	Cursors        cipher.AEAD
+	Swipe          swipeService.Config
+	LikeLimiter    string
+	Idempotency    idempotencyConfig
+}
+
+type tokenTTL struct {
+	Access  time.Duration
+	Refresh time.Duration
 }
 
 func main() {
@@ -100,17 +151,69 @@ func run(env, _ string) (<-chan error, error) {
 		return nil, fmt.Errorf("failed to create es connection: %w", err)
 	}
 
+	if err := pkg.CreateIndices(es); err != nil {
+		return nil, fmt.Errorf("failed to create es indices: %w", err)
+	}
+
+	passwordHasher, err := password.New(conf)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create password hasher: %w", err)
+	}
+
+	signingKeys, err := signing.New(conf)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load signing keys: %w", err)
+	}
+
+	ttl, err := loadTokenTTL(conf)
+	if err != nil {
+		return nil, err
+	}
+
+	ranking, err := loadRankingStrategies(conf)
+	if err != nil {
+		return nil, err
+	}
+
+	cursors, err := loadDiscoverCursorCipher(conf)
+	if err != nil {
+		return nil, err
+	}
+
+	swipeConfig, err := loadSwipeConfig(conf)
+	if err != nil {
+		return nil, err
+	}
+
+	idempotencyConf, err := loadIdempotencyConfig(conf)
+	if err != nil {
+		return nil, err
+	}
+
+	likeLimiter := conf.Get("SWIPE_LIKE_LIMITER")
+	if likeLimiter != "" && likeLimiter != storeMySQL && likeLimiter != storeMemory {
+		return nil, fmt.Errorf("invalid SWIPE_LIKE_LIMITER: %q", likeLimiter)
+	}
+
 	errC := make(chan error, 1)
 
 	port := conf.Get("PORT")
 	addr := fmt.Sprintf(":%s", port)
 
 	srv := newServer(serverConfig{
-		Address:     addr,
-		DB:          db,
-		ES:          es,
-		MiddleWares: []func(next http.Handler) http.Handler{LoggingMiddleware(logger)},
-		Logger:      logger,
+		Address:        addr,
+		DB:             db,
+		ES:             es,
+		MiddleWares:    []func(next http.Handler) http.Handler{LoggingMiddleware(logger)},
+		Logger:         logger,
+		SigningKeys:    signingKeys,
+		PasswordHasher: passwordHasher,
+		TokenTTL:       ttl,
+		Ranking:        ranking,
+		Cursors:        cursors,
+		Swipe:          swipeConfig,
+		LikeLimiter:    likeLimiter,
+		Idempotency:    idempotencyConf,
 	})
 
 	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM, syscall.SIGQUIT)
@@ -154,6 +257,165 @@ func run(env, _ string) (<-chan error, error) {
 	return errC, nil
 }
 
+func loadTokenTTL(conf *envvar.Configuration) (tokenTTL, error) {
+	var ttl tokenTTL
+
+	for key, target := range map[string]*time.Duration{
+		"ACCESS_TOKEN_TTL":  &ttl.Access,
+		"REFRESH_TOKEN_TTL": &ttl.Refresh,
+	} {
+		value := conf.Get(key)
+		if value == "" {
+			continue
+		}
+
+		duration, err := time.ParseDuration(value)
+		if err != nil {
+			return tokenTTL{}, fmt.Errorf("invalid %s: %w", key, err)
+		}
+
+		*target = duration
+	}
+
+	return ttl, nil
+}
+
+// loadRankingStrategies reads the discover ranking strategies users are split
+// between, e.g. [{"name":"control","weights":{"distance":1}}].
+func loadRankingStrategies(conf *envvar.Configuration) ([]entity.RankingStrategy, error) {
+	value := conf.Get("DISCOVER_RANKING_STRATEGIES")
+	if value == "" {
+		return nil, nil
+	}
+
+	var strategies []entity.RankingStrategy
+
+	if err := json.Unmarshal([]byte(value), &strategies); err != nil {
+		return nil, fmt.Errorf("invalid DISCOVER_RANKING_STRATEGIES: %w", err)
+	}
+
+	for _, strategy := range strategies {
+		if err := strategy.Validate(); err != nil {
+			return nil, fmt.Errorf("invalid DISCOVER_RANKING_STRATEGIES: %w", err)
+		}
+	}
+
+	return strategies, nil
+}
+
+// loadDiscoverCursorCipher reads the base64 AES key discover cursors are sealed
+// with. Left empty, a random key is used, so cursors do not survive a restart or
+// work across instances.
+func loadDiscoverCursorCipher(conf *envvar.Configuration) (cipher.AEAD, error) {
+	key := make([]byte, 32)
+
+	if value := conf.Get("DISCOVER_CURSOR_KEY"); value != "" {
+		decoded, err := base64.StdEncoding.DecodeString(value)
+		if err != nil {
+			return nil, fmt.Errorf("invalid DISCOVER_CURSOR_KEY: %w", err)
+		}
+
+		key = decoded
+	} else if _, err := rand.Read(key); err != nil {
+		return nil, fmt.Errorf("failed to generate discover cursor key: %w", err)
+	}
+
+	cursors, err := discoverModel.NewCursorCipher(key)
+	if err != nil {
+		return nil, fmt.Errorf("invalid DISCOVER_CURSOR_KEY: %w", err)
+	}
+
+	return cursors, nil
+}
+
+func loadSwipeConfig(conf *envvar.Configuration) (swipeService.Config, error) {
+	var config swipeService.Config
+
+	if value := conf.Get("SWIPE_REWINDS_PER_DAY"); value != "" {
+		rewinds, err := strconv.Atoi(value)
+		if err != nil {
+			return swipeService.Config{}, fmt.Errorf("invalid SWIPE_REWINDS_PER_DAY: %w", err)
+		}
+
+		config.RewindsPerDay = rewinds
+	}
+
+	if value := conf.Get("SWIPE_LIKES_PER_WINDOW"); value != "" {
+		likes, err := strconv.Atoi(value)
+		if err != nil {
+			return swipeService.Config{}, fmt.Errorf("invalid SWIPE_LIKES_PER_WINDOW: %w", err)
+		}
+
+		config.LikesPerWindow = likes
+	}
+
+	if value := conf.Get("SWIPE_LIKE_WINDOW"); value != "" {
+		window, err := time.ParseDuration(value)
+		if err != nil {
+			return swipeService.Config{}, fmt.Errorf("invalid SWIPE_LIKE_WINDOW: %w", err)
+		}
+
+		config.LikeWindow = window
+	}
+
+	if value := conf.Get("SWIPE_SUPER_LIKES_PER_DAY"); value != "" {
+		superLikes, err := strconv.Atoi(value)
+		if err != nil {
+			return swipeService.Config{}, fmt.Errorf("invalid SWIPE_SUPER_LIKES_PER_DAY: %w", err)
+		}
+
+		config.SuperLikesPerDay = superLikes
+	}
+
+	return config, nil
+}
+
+// Where like quotas and idempotent responses are kept: in MySQL, shared by every
+// instance, or in memory, per instance and lost on restart.
+const (
+	storeMySQL  = "mysql"
+	storeMemory = "memory"
+)
+
+// idempotencyConfig is where responses to requests with an Idempotency-Key are
+// kept, "mysql" or "memory", and for how long.
+type idempotencyConfig struct {
+	Store string
+	TTL   time.Duration
+}
+
+func loadIdempotencyConfig(conf *envvar.Configuration) (idempotencyConfig, error) {
+	config := idempotencyConfig{Store: conf.Get("IDEMPOTENCY_STORE")}
+
+	if config.Store != "" && config.Store != storeMySQL && config.Store != storeMemory {
+		return idempotencyConfig{}, fmt.Errorf("invalid IDEMPOTENCY_STORE: %q", config.Store)
+	}
+
+	if value := conf.Get("IDEMPOTENCY_TTL"); value != "" {
+		ttl, err := time.ParseDuration(value)
+		if err != nil {
+			return idempotencyConfig{}, fmt.Errorf("invalid IDEMPOTENCY_TTL: %w", err)
+		}
+
+		config.TTL = ttl
+	}
+
+	return config, nil
+}
+
+// newIdempotency returns the middleware replaying requests sent with an
+// Idempotency-Key, keeping responses in MySQL unless configured to keep them in
+// memory.
+func newIdempotency(conf serverConfig) func(next http.Handler) http.Handler {
+	var store idempotency.Store = idempotencyStore.NewStore(conf.Logger, conf.DB)
+
+	if conf.Idempotency.Store == storeMemory {
+		store = memoryIdempotency.NewStore()
+	}
+
+	return idempotency.Middleware(store, conf.Idempotency.TTL)
+}
+
 func newServer(conf serverConfig) *http.Server {
 	r := chi.NewRouter()
 
@@ -165,30 +427,101 @@ func newServer(conf serverConfig) *http.Server {
 		store       = userStore.NewStore(conf.Logger, conf.DB)
 		swipeStorer = swipeStore.NewStore(conf.Logger, conf.DB)
 		matchStorer = matchStore.NewStore(conf.Logger, conf.DB)
+		notifStorer = notificationStore.NewStore(conf.Logger, conf.DB)
+		msgStorer   = messageStore.NewStore(conf.Logger, conf.DB)
+		blockStorer = blockStore.NewStore(conf.Logger, conf.DB)
+		tokenStorer = tokenStore.NewStore(conf.Logger, conf.DB)
+		prefStorer  = preferenceStore.NewStore(conf.Logger, conf.DB)
 		index       = elasticsearch.NewUser(conf.ES)
+		swipedIndex = elasticsearch.NewSwiped(conf.ES)
+		unitOfWork  = uow.NewUnitOfWork(conf.Logger, conf.DB)
 	)
 
-	swipeS := swipeService.NewService(swipeStorer, matchStorer)
+	// Notifications are only pushed to devices connected to this instance.
+	notificationS := notificationService.NewService(notifStorer, memoryHub.NewHub())
+
+	// Like quotas are kept in MySQL unless configured to be kept in memory.
+	swipeS := swipeService.NewService(
+		conf.Logger, conf.Swipe, swipeStorer, swipedIndex, matchStorer, blockStorer, notificationS,
+		quotaStore.NewStore(conf.Logger, conf.DB), unitOfWork,
+	)
 
-	discoverS := discoverService.NewService(index, swipeStorer)
+	if conf.LikeLimiter == storeMemory {
+		swipeS = swipeService.NewService(
+			conf.Logger, conf.Swipe, swipeStorer, swipedIndex, matchStorer, blockStorer, notificationS,
+			memoryQuota.NewLimiter(), unitOfWork,
+		)
+	}
 
-	userManager := userM.NewManager(store, index)
-	authService := auth.NewAuthService("my-secret-key", store)
+	ranker := discoverService.NewRanker(swipeStorer, conf.Ranking...)
+	discoverS := discoverService.NewService(conf.Logger, index, store, prefStorer, blockStorer, index, ranker)
 
-	pkg.InitAuth("my-secret-key")
+	preferenceS := preferenceService.NewService(prefStorer, index)
+
+	likeS := likeService.NewService(swipeStorer, store, blockStorer)
+
+	matchS := matchService.NewService(matchStorer, store, swipedIndex)
+
+	messageS := messageService.NewService(conf.Logger, msgStorer, matchStorer, blockStorer, notificationS, unitOfWork)
+
+	blockS := blockService.NewService(blockStorer, store, matchStorer, unitOfWork)
+
+	reportS := reportService.NewService(reportStore.NewStore(conf.Logger, conf.DB), store, unitOfWork)
+
+	adminS := adminService.NewService(
+		store, reportS, matchStorer, prefStorer, index, tokenStorer, auditStore.NewStore(conf.Logger, conf.DB), unitOfWork,
+	)
 
-	authhttp.NewHandler(conf.Logger, authService).Register(r)
+	userManager := userM.NewManager(store, index, conf.PasswordHasher, unitOfWork)
+	authService := auth.NewAuthService(conf.Logger, auth.Config{
+		AccessTokenTTL:  conf.TokenTTL.Access,
+		RefreshTokenTTL: conf.TokenTTL.Refresh,
+	}, conf.SigningKeys, store, store, conf.PasswordHasher, tokenStorer, unitOfWork)
 
-	user.NewHandler(conf.Logger, userManager).Register(r)
+	pkg.InitAuth(conf.SigningKeys.Keyfunc, tokenStorer)
+
+	jwks.NewHandler(conf.Logger, conf.SigningKeys).Register(r)
+
+	authHandler := authhttp.NewHandler(conf.Logger, authService)
+	authHandler.Register(r)
+
+	idempotent := newIdempotency(conf)
+
+	userHandler := user.NewHandler(conf.Logger, userManager)
+
+	r.Group(func(r chi.Router) {
+		r.Use(idempotent)
+		userHandler.Register(r)
+	})
 
 	r.Group(func(r chi.Router) {
 		r.Use(pkg.AuthMiddleware)
-		discover.NewHandler(conf.Logger, discoverS).Register(r)
+		authHandler.RegisterAuthenticated(r)
+		userHandler.RegisterAuthenticated(r)
 	})
 
 	r.Group(func(r chi.Router) {
 		r.Use(pkg.AuthMiddleware)
+		discover.NewHandler(conf.Logger, discoverS, conf.Cursors).Register(r)
+		preferenceHttp.NewHandler(conf.Logger, preferenceS).Register(r)
+	})
+
+	r.Group(func(r chi.Router) {
+		r.Use(pkg.AuthMiddleware)
+		r.Use(idempotent)
 		swipeHttp.NewHandler(conf.Logger, swipeS).Register(r)
+		likeHttp.NewHandler(conf.Logger, likeS).Register(r)
+		matchHttp.NewHandler(conf.Logger, matchS).Register(r)
+		messageHttp.NewHandler(conf.Logger, messageS).Register(r)
+		blockHttp.NewHandler(conf.Logger, blockS).Register(r)
+		reportHttp.NewHandler(conf.Logger, reportS).Register(r)
+		notificationHttp.NewHandler(conf.Logger, notificationS).Register(r)
+	})
+
+	r.Route("/admin", func(r chi.Router) {
+		r.Use(pkg.AuthMiddleware)
+		r.Use(pkg.RequireRole(string(entity.RoleModerator), string(entity.RoleAdmin)))
+		adminHttp.NewHandler(conf.Logger, adminS).Register(r)
 	})
 
 	return &http.Server{
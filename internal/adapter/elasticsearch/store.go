@@ -6,6 +6,9 @@ import (
 	"encoding/json"
 	"fmt"
 	"io"
+	"math"
+	"net/http"
+	"time"
 
 	esv7 "github.com/elastic/go-elasticsearch/v7"
 	esv7api "github.com/elastic/go-elasticsearch/v7/esapi"
@@ -14,34 +17,52 @@ import (
 )
 
 type User struct {
-	client *esv7.Client
-	index  string
+	client      *esv7.Client
+	index       string
+	swipedIndex string
 }
 
 func NewUser(client *esv7.Client) *User {
 	return &User{
-		client: client,
-		index:  "users",
+		client:      client,
+		index:       "users",
+		swipedIndex: swipedIndex,
 	}
 }
 
 type indexedUser struct {
-	ID       int             `json:"id"`
-	Email    string          `json:"email"`
-	Name     string          `json:"name"`
-	Gender   string          `json:"gender"`
-	Age      int             `json:"age"`
-	Location entity.Location `json:"location"`
+	ID           int             `json:"id"`
+	Name         string          `json:"name"`
+	Bio          string          `json:"bio"`
+	Gender       string          `json:"gender"`
+	Age          int             `json:"age"`
+	Location     entity.Location `json:"location"`
+	Completeness float64         `json:"completeness"`
+	LastActiveAt *time.Time      `json:"last_active_at,omitempty"`
+}
+
+// indexedPreferences are the discovery preferences stored alongside a user, so
+// searches can exclude people who would not want to see the searcher. A null
+// field does not restrict anything.
+type indexedPreferences struct {
+	PrefMinAge        *int64   `json:"pref_min_age"`
+	PrefMaxAge        *int64   `json:"pref_max_age"`
+	PrefGenders       []string `json:"pref_genders"`
+	PrefMaxDistanceKm *float64 `json:"pref_max_distance_km"`
+	ShowMe            bool     `json:"show_me"`
 }
 
 func (u *User) Index(ctx context.Context, user entity.User) error {
+	now := time.Now().UTC()
 	body := indexedUser{
-		ID:       user.ID,
-		Email:    user.Email,
-		Name:     user.Name,
-		Gender:   user.Gender,
-		Age:      user.Age,
-		Location: user.Location,
+		ID:           user.ID,
+		Name:         user.Name,
+		Bio:          user.Bio,
+		Gender:       user.Gender,
+		Age:          user.Age,
+		Location:     user.Location,
+		Completeness: user.Completeness(),
+		LastActiveAt: &now,
 	}
 
 	var buf bytes.Buffer
@@ -72,17 +93,502 @@ func (u *User) Index(ctx context.Context, user entity.User) error {
 	return nil
 }
 
+// indexedDocument is everything MySQL knows about a user that their document
+// holds, which leaves out only the super likes they have given.
+type indexedDocument struct {
+	indexedUser
+	indexedPreferences
+	ShadowBanned bool `json:"shadow_banned"`
+}
+
+// Reindex writes a user's whole document in one request, with their profile,
+// preferences and shadow ban, so it is never seen half done. Their super likes
+// are kept, and a user without a document gets one.
+func (u *User) Reindex(ctx context.Context, user entity.User, prefs entity.Preferences, shadowBanned bool) error {
+	now := time.Now().UTC()
+	doc := indexedDocument{
+		indexedUser: indexedUser{
+			ID:           user.ID,
+			Name:         user.Name,
+			Bio:          user.Bio,
+			Gender:       user.Gender,
+			Age:          user.Age,
+			Location:     user.Location,
+			Completeness: user.Completeness(),
+			LastActiveAt: &now,
+		},
+		indexedPreferences: newIndexedPreferences(prefs),
+		ShadowBanned:       shadowBanned,
+	}
+
+	body := map[string]interface{}{"doc": doc, "doc_as_upsert": true}
+
+	if err := u.write(ctx, user.ID, body, "true"); err != nil {
+		return fmt.Errorf("failed to reindex: %w", err)
+	}
+
+	return nil
+}
+
+// indexedProfile is the part of a user's document they can edit.
+type indexedProfile struct {
+	Name         string  `json:"name"`
+	Bio          string  `json:"bio"`
+	Gender       string  `json:"gender"`
+	Age          int     `json:"age"`
+	Completeness float64 `json:"completeness"`
+}
+
+// UpdateProfile stores a user's edited profile on their document, leaving their
+// location, preferences, activity and moderation flags as they are.
+func (u *User) UpdateProfile(ctx context.Context, user entity.User) error {
+	doc := indexedProfile{
+		Name:         user.Name,
+		Bio:          user.Bio,
+		Gender:       user.Gender,
+		Age:          user.Age,
+		Completeness: user.Completeness(),
+	}
+
+	if err := u.update(ctx, user.ID, doc, "true"); err != nil {
+		return fmt.Errorf("failed to update profile: %w", err)
+	}
+
+	return nil
+}
+
+// UpdateLocation moves a user's document to loc. It is not refreshed straight
+// away, as locations change far more often than anything else on it.
+func (u *User) UpdateLocation(ctx context.Context, userID int, loc entity.Location) error {
+	doc := map[string]interface{}{"location": loc}
+
+	if err := u.update(ctx, userID, doc, "false"); err != nil {
+		return fmt.Errorf("failed to update location: %w", err)
+	}
+
+	return nil
+}
+
+// UpdatePreferences stores a user's discovery preferences on their document.
+func (u *User) UpdatePreferences(ctx context.Context, prefs entity.Preferences) error {
+	if err := u.update(ctx, prefs.UserID, newIndexedPreferences(prefs), "true"); err != nil {
+		return fmt.Errorf("failed to update preferences: %w", err)
+	}
+
+	return nil
+}
+
+func newIndexedPreferences(prefs entity.Preferences) indexedPreferences {
+	prefsDoc := indexedPreferences{
+		PrefMinAge:        prefs.MinAge.Ptr(),
+		PrefMaxAge:        prefs.MaxAge.Ptr(),
+		PrefGenders:       prefs.Genders,
+		PrefMaxDistanceKm: prefs.MaxDistanceKm.Ptr(),
+		ShowMe:            prefs.ShowMe,
+	}
+
+	if len(prefsDoc.PrefGenders) == 0 {
+		prefsDoc.PrefGenders = nil
+	}
+
+	return prefsDoc
+}
+
+// TouchActivity records when a user was last active, which favours them in
+// other people's discover results.
+func (u *User) TouchActivity(ctx context.Context, userID int, at time.Time) error {
+	doc := map[string]interface{}{"last_active_at": at.UTC()}
+
+	if err := u.update(ctx, userID, doc, "false"); err != nil {
+		return fmt.Errorf("failed to update activity: %w", err)
+	}
+
+	return nil
+}
+
+// SetShadowBanned hides a user from everyone else's searches, or shows them
+// again.
+func (u *User) SetShadowBanned(ctx context.Context, userID int, shadowBanned bool) error {
+	doc := map[string]interface{}{"shadow_banned": shadowBanned}
+
+	if err := u.update(ctx, userID, doc, "true"); err != nil {
+		return fmt.Errorf("failed to update shadow ban: %w", err)
+	}
+
+	return nil
+}
+
+// Delete removes a user's document, so they are no longer discovered. A user
+// without one is left as is.
+func (u *User) Delete(ctx context.Context, userID int) error {
+	req := esv7api.DeleteRequest{
+		Index:      u.index,
+		DocumentID: fmt.Sprint(userID),
+		Refresh:    "true",
+	}
+
+	resp, err := req.Do(ctx, u.client)
+	if err != nil {
+		return fmt.Errorf("failed to delete: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.IsError() && resp.StatusCode != http.StatusNotFound {
+		return fmt.Errorf("failed to delete: %s", resp.String())
+	}
+
+	io.Copy(io.Discard, resp.Body) //nolint: errcheck
+
+	return nil
+}
+
+// snapLocationScript snaps a document's location to the grid the way
+// entity.Location.Fuzzed does, rounding halves away from zero, and leaves
+// documents already on it untouched.
+const snapLocationScript = `
+	double lat = ((Number) ctx._source.location.lat).doubleValue();
+	double lon = ((Number) ctx._source.location.lon).doubleValue();
+	double snappedLat = Math.signum(lat) * Math.round(Math.abs(lat) * 100) / 100.0;
+	double snappedLon = Math.signum(lon) * Math.round(Math.abs(lon) * 100) / 100.0;
+
+	if (snappedLat == lat && snappedLon == lon) {
+		ctx.op = 'noop';
+	} else {
+		ctx._source.location = ['lat': snappedLat, 'lon': snappedLon];
+	}
+`
+
+// SnapLocations snaps every location in the index to the grid, for documents
+// indexed before locations were fuzzed on the way in. It returns how many it
+// changed.
+func (u *User) SnapLocations(ctx context.Context) (int, error) {
+	body := map[string]interface{}{
+		"query": map[string]interface{}{
+			"exists": map[string]interface{}{"field": "location"},
+		},
+		"script": map[string]interface{}{"source": snapLocationScript},
+	}
+
+	updated, err := u.updateByQuery(ctx, body)
+	if err != nil {
+		return 0, fmt.Errorf("failed to snap locations: %w", err)
+	}
+
+	return updated, nil
+}
+
+// RemoveField deletes field from every document that has it, returning how
+// many it changed. The field stays in the mapping, as Elasticsearch cannot
+// remove one from an existing index.
+func (u *User) RemoveField(ctx context.Context, field string) (int, error) {
+	body := map[string]interface{}{
+		"query": map[string]interface{}{
+			"exists": map[string]interface{}{"field": field},
+		},
+		"script": map[string]interface{}{
+			"source": "ctx._source.remove(params.field)",
+			"params": map[string]interface{}{"field": field},
+		},
+	}
+
+	updated, err := u.updateByQuery(ctx, body)
+	if err != nil {
+		return 0, fmt.Errorf("failed to remove %s: %w", field, err)
+	}
+
+	return updated, nil
+}
+
+// updateByQuery runs body over the index and returns how many documents it
+// changed. Documents written to meanwhile are skipped and fail it, so it can be
+// run again.
+func (u *User) updateByQuery(ctx context.Context, body interface{}) (int, error) {
+	var buf bytes.Buffer
+
+	if err := json.NewEncoder(&buf).Encode(body); err != nil {
+		return 0, fmt.Errorf("failed to encode body: %w", err)
+	}
+
+	refresh := true
+	req := esv7api.UpdateByQueryRequest{
+		Index:     []string{u.index},
+		Body:      &buf,
+		Conflicts: "proceed",
+		Refresh:   &refresh,
+	}
+
+	resp, err := req.Do(ctx, u.client)
+	if err != nil {
+		return 0, fmt.Errorf("failed to update by query: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.IsError() {
+		return 0, fmt.Errorf("failed to update by query: %s", resp.String())
+	}
+
+	var r struct {
+		Updated          int `json:"updated"`
+		VersionConflicts int `json:"version_conflicts"`
+	}
+
+	if err := json.NewDecoder(resp.Body).Decode(&r); err != nil {
+		return 0, fmt.Errorf("failed to decode response: %w", err)
+	}
+
+	if r.VersionConflicts > 0 {
+		return r.Updated, fmt.Errorf("%d documents changed meanwhile, run again", r.VersionConflicts)
+	}
+
+	return r.Updated, nil
+}
+
+// update merges doc into a user's document.
+func (u *User) update(ctx context.Context, userID int, doc interface{}, refresh string) error {
+	return u.write(ctx, userID, map[string]interface{}{"doc": doc}, refresh)
+}
+
+// write sends body as an update to a user's document.
+func (u *User) write(ctx context.Context, userID int, body interface{}, refresh string) error {
+	var buf bytes.Buffer
+
+	if err := json.NewEncoder(&buf).Encode(body); err != nil {
+		return fmt.Errorf("failed to encode body: %w", err)
+	}
+
+	req := esv7api.UpdateRequest{
+		Index:      u.index,
+		DocumentID: fmt.Sprint(userID),
+		Body:       &buf,
+		Refresh:    refresh,
+	}
+
+	resp, err := req.Do(ctx, u.client)
+	if err != nil {
+		return fmt.Errorf("failed to update: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.IsError() {
+		return fmt.Errorf("failed to update: %s", resp.String())
+	}
+
+	io.Copy(io.Discard, resp.Body) //nolint: errcheck
+
+	return nil
+}
+
+// mutualFilters only keep people whose own preferences include the searcher.
+func mutualFilters(params entity.SearchParams) []map[string]interface{} {
+	filters := []map[string]interface{}{
+		{
+			"bool": map[string]interface{}{
+				"must_not": map[string]interface{}{
+					"term": map[string]interface{}{"show_me": false},
+				},
+			},
+		},
+		{
+			"script": map[string]interface{}{
+				"script": map[string]interface{}{
+					"source": "!doc.containsKey('pref_max_distance_km') || doc['pref_max_distance_km'].size() == 0 || " +
+						"doc['location'].arcDistance(params.lat, params.lon) <= doc['pref_max_distance_km'].value * 1000",
+					"params": map[string]interface{}{
+						"lat": params.Lat,
+						"lon": params.Lon,
+					},
+				},
+			},
+		},
+	}
+
+	if params.SearcherAge.Valid {
+		filters = append(filters,
+			missingOr("pref_min_age", map[string]interface{}{
+				"range": map[string]interface{}{"pref_min_age": map[string]interface{}{"lte": params.SearcherAge.Int64}},
+			}),
+			missingOr("pref_max_age", map[string]interface{}{
+				"range": map[string]interface{}{"pref_max_age": map[string]interface{}{"gte": params.SearcherAge.Int64}},
+			}),
+		)
+	}
+
+	if params.SearcherGender.Valid {
+		filters = append(filters, missingOr("pref_genders", map[string]interface{}{
+			"term": map[string]interface{}{"pref_genders": params.SearcherGender.String},
+		}))
+	}
+
+	return filters
+}
+
+// missingOr matches documents without field, or that match query.
+func missingOr(field string, query map[string]interface{}) map[string]interface{} {
+	return map[string]interface{}{
+		"bool": map[string]interface{}{
+			"should": []map[string]interface{}{
+				{"bool": map[string]interface{}{"must_not": map[string]interface{}{"exists": map[string]interface{}{"field": field}}}},
+				query,
+			},
+			"minimum_should_match": 1,
+		},
+	}
+}
+
+// OpenPointInTime opens a point in time of the users index for a discover
+// session to search, so every page sees the same documents.
+func (u *User) OpenPointInTime(ctx context.Context) (string, error) {
+	req := esv7api.OpenPointInTimeRequest{
+		Index:     []string{u.index},
+		KeepAlive: pitKeepAlive,
+	}
+
+	resp, err := req.Do(ctx, u.client)
+	if err != nil {
+		return "", fmt.Errorf("failed to open point in time: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.IsError() {
+		return "", fmt.Errorf("failed to open point in time: %s", resp.String())
+	}
+
+	var r struct {
+		ID string `json:"id"`
+	}
+
+	if err := json.NewDecoder(resp.Body).Decode(&r); err != nil {
+		return "", fmt.Errorf("failed to decode response: %w", err)
+	}
+
+	return r.ID, nil
+}
+
+// ClosePointInTime frees a point in time once a discover session has reached
+// its last page. One that has already expired is left as is.
+func (u *User) ClosePointInTime(ctx context.Context, pit string) error {
+	var buf bytes.Buffer
+
+	if err := json.NewEncoder(&buf).Encode(map[string]interface{}{"id": pit}); err != nil {
+		return fmt.Errorf("failed to encode body: %w", err)
+	}
+
+	req := esv7api.ClosePointInTimeRequest{Body: &buf}
+
+	resp, err := req.Do(ctx, u.client)
+	if err != nil {
+		return fmt.Errorf("failed to close point in time: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.IsError() && resp.StatusCode != http.StatusNotFound {
+		return fmt.Errorf("failed to close point in time: %s", resp.String())
+	}
+
+	io.Copy(io.Discard, resp.Body) //nolint: errcheck
+
+	return nil
+}
+
+// SearchOthers searches the point in time in params, or the index itself when
+// there is none.
+func (u *User) SearchOthers(ctx context.Context, params entity.SearchParams) (entity.SearchResult, error) {
+	var buf bytes.Buffer
+	if err := json.NewEncoder(&buf).Encode(u.searchQuery(params)); err != nil {
+		return entity.SearchResult{}, fmt.Errorf("failed to encode query: %w", err)
+	}
+
+	opts := []func(*esv7api.SearchRequest){
+		u.client.Search.WithContext(ctx),
+		u.client.Search.WithBody(&buf),
+	}
+
+	// A point in time already names the index searched.
+	if params.PIT == "" {
+		opts = append(opts, u.client.Search.WithIndex(u.index))
+	}
+
+	resp, err := u.client.Search(opts...)
+	if err != nil {
+		return entity.SearchResult{}, fmt.Errorf("failed to search: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.IsError() {
+		if params.PIT != "" && resp.StatusCode == http.StatusNotFound {
+			return entity.SearchResult{}, fmt.Errorf("point in time is gone: %w", entity.ErrCursorExpired)
+		}
+
+		return entity.SearchResult{}, fmt.Errorf("search query failed: %s", resp.String())
+	}
+
+	var r struct {
+		PITID string `json:"pit_id"`
+		Hits  struct {
+			Hits []struct {
+				Source indexedUser `json:"_source"`
+				Sort   []float64   `json:"sort"`
+			} `json:"hits"`
+		} `json:"hits"`
+	}
+
+	if err := json.NewDecoder(resp.Body).Decode(&r); err != nil {
+		return entity.SearchResult{}, fmt.Errorf("failed to decode response: %w", err)
+	}
+
+	users := make([]entity.User, len(r.Hits.Hits))
+	for i, hit := range r.Hits.Hits {
+		users[i] = entity.User{
+			ID:       hit.Source.ID,
+			Name:     hit.Source.Name,
+			Bio:      hit.Source.Bio,
+			Gender:   hit.Source.Gender,
+			Age:      hit.Source.Age,
+			Location: hit.Source.Location,
+			Sort:     hit.Sort,
+		}
+
+		if len(hit.Sort) > 1 {
+			users[i].Score = hit.Sort[0]
+			users[i].DistanceFromMe = hit.Sort[1]
+		}
+	}
+
+	return entity.SearchResult{Users: users, PIT: r.PITID}, nil
+}
+
+// searchQuery builds the search body. Its size does not depend on how many
+// people the searcher has swiped on: those ids are looked up by Elasticsearch
+// from the searcher's document in the swiped index.
+//
 // nolint:cyclop,forcetypeassert
-func (u *User) SearchOthers(ctx context.Context, params entity.SearchParams) ([]entity.User, error) {
+func (u *User) searchQuery(params entity.SearchParams) map[string]interface{} {
 	boolQuery := map[string]interface{}{
 		"must_not": []map[string]interface{}{},
 		"filter":   []map[string]interface{}{},
 	}
 
-	for _, userID := range params.ExcludeUserIDs {
+	// Shadow banned users are never shown to anyone else.
+	boolQuery["must_not"] = append(boolQuery["must_not"].([]map[string]interface{}), map[string]interface{}{
+		"term": map[string]interface{}{"shadow_banned": true},
+	})
+
+	if len(params.ExcludeUserIDs) > 0 {
 		boolQuery["must_not"] = append(boolQuery["must_not"].([]map[string]interface{}), map[string]interface{}{
-			"term": map[string]interface{}{
-				"id": userID,
+			"terms": map[string]interface{}{
+				"id": params.ExcludeUserIDs,
+			},
+		})
+	}
+
+	if params.ExcludeSwipedBy > 0 {
+		boolQuery["must_not"] = append(boolQuery["must_not"].([]map[string]interface{}), map[string]interface{}{
+			"terms": map[string]interface{}{
+				"id": map[string]interface{}{
+					"index": u.swipedIndex,
+					"id":    fmt.Sprint(params.ExcludeSwipedBy),
+					"path":  "target_ids",
+				},
 			},
 		})
 	}
@@ -110,56 +616,193 @@ func (u *User) SearchOthers(ctx context.Context, params entity.SearchParams) ([]
 				"gender": params.Gender.String,
 			},
 		})
+	} else if len(params.Genders) > 0 {
+		boolQuery["filter"] = append(boolQuery["filter"].([]map[string]interface{}), map[string]interface{}{
+			"terms": map[string]interface{}{
+				"gender": params.Genders,
+			},
+		})
+	}
+
+	if params.MaxDistanceKm.Valid {
+		boolQuery["filter"] = append(boolQuery["filter"].([]map[string]interface{}), map[string]interface{}{
+			"geo_distance": map[string]interface{}{
+				"distance": fmt.Sprintf("%fkm", params.MaxDistanceKm.Float64),
+				"location": map[string]interface{}{
+					"lat": params.Lat,
+					"lon": params.Lon,
+				},
+			},
+		})
+	}
+
+	boolQuery["filter"] = append(boolQuery["filter"].([]map[string]interface{}), mutualFilters(params)...)
+
+	functions := rankingFunctions(params)
+
+	// Super likes are kept on the super liker's own document rather than looked
+	// up, so a point in time freezes them with everything else.
+	if params.BoostSuperLikersOf > 0 {
+		functions = append(functions, map[string]interface{}{
+			"filter": map[string]interface{}{
+				"term": map[string]interface{}{"super_liked": params.BoostSuperLikersOf},
+			},
+			"weight": superLikeBoost,
+		})
+	}
+
+	var search map[string]interface{}
+
+	if len(functions) > 0 {
+		search = map[string]interface{}{
+			"function_score": map[string]interface{}{
+				"query":      map[string]interface{}{"bool": boolQuery},
+				"functions":  functions,
+				"score_mode": "sum",
+				"boost_mode": "replace",
+			},
+		}
+	} else {
+		search = map[string]interface{}{"bool": boolQuery}
 	}
 
 	query := map[string]interface{}{
-		"query": map[string]interface{}{
-			"bool": boolQuery,
+		"query": search,
+		// Highest score first, then nearest, with ties broken by id so pages are
+		// deterministic. The sort values start with the score and the distance
+		// from the searcher in km; searching a point in time adds its own
+		// tiebreaker after them.
+		"sort": []map[string]interface{}{
+			{"_score": "desc"},
+			{
+				"_geo_distance": map[string]interface{}{
+					"location": map[string]interface{}{
+						"lat": params.Lat,
+						"lon": params.Lon,
+					},
+					"order":         "asc",
+					"unit":          "km",
+					"distance_type": "arc",
+				},
+			},
+			{"id": "asc"},
 		},
 	}
 
-	var buf bytes.Buffer
-	if err := json.NewEncoder(&buf).Encode(query); err != nil {
-		return nil, fmt.Errorf("failed to encode query: %w", err)
+	if params.Limit > 0 {
+		query["size"] = params.Limit
 	}
 
-	resp, err := u.client.Search(
-		u.client.Search.WithContext(ctx),
-		u.client.Search.WithIndex(u.index),
-		u.client.Search.WithBody(&buf),
-	)
-	if err != nil {
-		return nil, fmt.Errorf("failed to search: %w", err)
+	if params.PIT != "" {
+		query["pit"] = map[string]interface{}{"id": params.PIT, "keep_alive": pitKeepAlive}
 	}
-	defer resp.Body.Close()
 
-	if resp.IsError() {
-		return nil, fmt.Errorf("search query failed: %s", resp.String())
+	if params.After != nil {
+		query["search_after"] = params.After.Sort
 	}
 
-	var r struct {
-		Hits struct {
-			Hits []struct {
-				Source indexedUser `json:"_source"`
-			} `json:"hits"`
-		} `json:"hits"`
+	return query
+}
+
+const (
+	// pitKeepAlive is how long a discover session's point in time is kept
+	// between pages.
+	pitKeepAlive = "5m"
+	// superLikeBoost is added to the score of people who super liked the
+	// searcher. It is far above what the ranking weights add up to, so they
+	// come first whatever the strategy.
+	superLikeBoost = 100
+	// minDistanceScaleKm is how far away someone can be before their distance
+	// score halves, unless the searcher's maximum distance is further.
+	minDistanceScaleKm = 10
+	// activityScale is how long after someone was last active their activity
+	// score halves.
+	activityScale = "3d"
+	// minAgeScale is how many years from the ideal age someone's age fit halves.
+	minAgeScale = 3
+)
+
+// rankingFunctions scores people on the signals Elasticsearch can compute. Each
+// function scores between 0 and 1 before its weight is applied.
+func rankingFunctions(params entity.SearchParams) []map[string]interface{} {
+	weights := params.Ranking
+	functions := []map[string]interface{}{}
+
+	if weights.Distance > 0 {
+		scale := math.Max(minDistanceScaleKm, params.MaxDistanceKm.Float64/2)
+
+		functions = append(functions, map[string]interface{}{
+			"gauss": map[string]interface{}{
+				"location": map[string]interface{}{
+					"origin": map[string]interface{}{"lat": params.Lat, "lon": params.Lon},
+					"scale":  fmt.Sprintf("%fkm", scale),
+					"decay":  0.5,
+				},
+			},
+			"weight": weights.Distance,
+		})
 	}
 
-	if err := json.NewDecoder(resp.Body).Decode(&r); err != nil {
-		return nil, fmt.Errorf("failed to decode response: %w", err)
+	if origin, scale, ok := idealAge(params); ok && weights.AgeFit > 0 {
+		functions = append(functions, map[string]interface{}{
+			"gauss": map[string]interface{}{
+				"age": map[string]interface{}{
+					"origin": origin,
+					"scale":  scale,
+					"decay":  0.5,
+				},
+			},
+			"weight": weights.AgeFit,
+		})
 	}
 
-	users := make([]entity.User, len(r.Hits.Hits))
-	for i, hit := range r.Hits.Hits {
-		users[i] = entity.User{
-			ID:       hit.Source.ID,
-			Email:    hit.Source.Email,
-			Name:     hit.Source.Name,
-			Gender:   hit.Source.Gender,
-			Age:      hit.Source.Age,
-			Location: hit.Source.Location,
+	if weights.Activity > 0 {
+		// Activity decays from when the session started rather than now, so
+		// scores do not drift from one page to the next.
+		origin := "now"
+		if !params.At.IsZero() {
+			origin = params.At.UTC().Format(time.RFC3339)
 		}
+
+		// Decay functions score documents without the field as 1, so only
+		// apply it to people whose activity is known.
+		functions = append(functions, map[string]interface{}{
+			"filter": map[string]interface{}{"exists": map[string]interface{}{"field": "last_active_at"}},
+			"gauss": map[string]interface{}{
+				"last_active_at": map[string]interface{}{
+					"origin": origin,
+					"scale":  activityScale,
+					"decay":  0.5,
+				},
+			},
+			"weight": weights.Activity,
+		})
+	}
+
+	if weights.Completeness > 0 {
+		functions = append(functions, map[string]interface{}{
+			"field_value_factor": map[string]interface{}{
+				"field":   "completeness",
+				"missing": 0,
+			},
+			"weight": weights.Completeness,
+		})
 	}
 
-	return users, nil
+	return functions
+}
+
+// idealAge is the middle of the age range searched for, or the searcher's own
+// age when the range is open, and how far either side of it still fits well.
+func idealAge(params entity.SearchParams) (float64, float64, bool) {
+	switch {
+	case params.MinAge.Valid && params.MaxAge.Valid:
+		spread := float64(params.MaxAge.Int64-params.MinAge.Int64) / 2
+
+		return float64(params.MinAge.Int64) + spread, math.Max(minAgeScale, spread), true
+	case params.SearcherAge.Valid:
+		return float64(params.SearcherAge.Int64), minAgeScale, true
+	default:
+		return 0, 0, false
+	}
 }
@@ -0,0 +1,272 @@
+package user
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"strings"
+	"testing"
+	"time"
+
+	esv7 "github.com/elastic/go-elasticsearch/v7"
+	null "github.com/guregu/null/v5"
+
+	"github.com/colmmurphy91/muzz/internal/entity"
+)
+
+// BenchmarkSearchOthers measures discovery latency as the number of people the
+// searcher has swiped on grows, comparing the swiped-index terms lookup with
+// sending every id inline. It needs a disposable Elasticsearch, e.g.
+//
+//	ES_URL=http://localhost:9200 go test -run ^$ -bench SearchOthers ./internal/adapter/elasticsearch/
+func BenchmarkSearchOthers(b *testing.B) {
+	esURL := os.Getenv("ES_URL")
+	if esURL == "" {
+		b.Skip("ES_URL not set")
+	}
+
+	client, err := esv7.NewClient(esv7.Config{Addresses: []string{esURL}})
+	if err != nil {
+		b.Fatal(err)
+	}
+
+	const (
+		users       = 60000
+		searcherID  = users + 1
+		usersIndex  = "bench_users"
+		swipedIndex = "bench_swiped"
+	)
+
+	seedBenchmarkUsers(b, client, usersIndex, users)
+
+	index := &User{client: client, index: usersIndex, swipedIndex: swipedIndex}
+	swiped := &Swiped{client: client, index: swipedIndex}
+	ctx := context.Background()
+
+	for _, swipes := range []int{0, 1000, 10000, 50000} {
+		targetIDs := make([]int, swipes)
+		for i := range targetIDs {
+			targetIDs[i] = i + 1
+		}
+
+		if err := swiped.ReplaceSwiped(ctx, searcherID, targetIDs); err != nil {
+			b.Fatal(err)
+		}
+
+		refresh(b, client, swipedIndex)
+
+		params := entity.SearchParams{Lat: 51.5, Lon: -0.12, Limit: 20, ExcludeUserIDs: []int{searcherID}}
+
+		b.Run(fmt.Sprintf("lookup/swipes=%d", swipes), func(b *testing.B) {
+			lookup := params
+			lookup.ExcludeSwipedBy = searcherID
+
+			for i := 0; i < b.N; i++ {
+				if _, err := index.SearchOthers(ctx, lookup); err != nil {
+					b.Fatal(err)
+				}
+			}
+		})
+
+		b.Run(fmt.Sprintf("inline/swipes=%d", swipes), func(b *testing.B) {
+			inline := params
+			inline.ExcludeUserIDs = append([]int{searcherID}, targetIDs...)
+
+			for i := 0; i < b.N; i++ {
+				if _, err := index.SearchOthers(ctx, inline); err != nil {
+					b.Fatal(err)
+				}
+			}
+		})
+	}
+}
+
+func seedBenchmarkUsers(b *testing.B, client *esv7.Client, index string, count int) {
+	b.Helper()
+
+	var buf bytes.Buffer
+
+	for id := 1; id <= count; id++ {
+		fmt.Fprintf(&buf, `{"index":{"_index":%q,"_id":"%d"}}`+"\n", index, id)
+
+		doc, _ := json.Marshal(indexedUser{
+			ID:       id,
+			Name:     fmt.Sprintf("user %d", id),
+			Gender:   []string{"male", "female"}[id%2],
+			Age:      18 + id%50,
+			Location: entity.Location{Lat: 51.5 + float64(id%100)/100, Lon: -0.12 + float64(id%70)/100},
+		})
+		buf.Write(doc)
+		buf.WriteByte('\n')
+
+		if id%5000 == 0 || id == count {
+			resp, err := client.Bulk(strings.NewReader(buf.String()))
+			if err != nil {
+				b.Fatal(err)
+			}
+
+			resp.Body.Close()
+			buf.Reset()
+		}
+	}
+
+	refresh(b, client, index)
+}
+
+func refresh(b *testing.B, client *esv7.Client, index string) {
+	b.Helper()
+
+	resp, err := client.Indices.Refresh(client.Indices.Refresh.WithIndex(index))
+	if err != nil {
+		b.Fatal(err)
+	}
+
+	resp.Body.Close()
+}
+
+func BenchmarkSearchQuery(b *testing.B) {
+	index := &User{index: "users", swipedIndex: swipedIndex}
+	params := entity.SearchParams{Lat: 51.5, Lon: -0.12, Limit: 20, ExcludeUserIDs: []int{1}, ExcludeSwipedBy: 1}
+
+	for i := 0; i < b.N; i++ {
+		if err := json.NewEncoder(&bytes.Buffer{}).Encode(index.searchQuery(params)); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func TestSearchQuery_ExcludesSwipedWithLookup(t *testing.T) {
+	index := &User{index: "users", swipedIndex: swipedIndex}
+
+	body, err := json.Marshal(index.searchQuery(entity.SearchParams{ExcludeUserIDs: []int{7}, ExcludeSwipedBy: 7}))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for _, expected := range []string{
+		`{"terms":{"id":[7]}}`,
+		`{"terms":{"id":{"id":"7","index":"swiped","path":"target_ids"}}}`,
+	} {
+		if !strings.Contains(string(body), expected) {
+			t.Errorf("expected query to contain %s, got %s", expected, body)
+		}
+	}
+}
+
+func TestSearchQuery_ExcludesShadowBanned(t *testing.T) {
+	index := &User{index: "users", swipedIndex: swipedIndex}
+
+	body, err := json.Marshal(index.searchQuery(entity.SearchParams{Lat: 51.5, Lon: -0.12}))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	expected := `"must_not":[{"term":{"shadow_banned":true}}]`
+	if !strings.Contains(string(body), expected) {
+		t.Errorf("expected query to contain %s, got %s", expected, body)
+	}
+}
+
+func TestSearchQuery_BoostsSuperLikers(t *testing.T) {
+	index := &User{index: "users", swipedIndex: swipedIndex}
+
+	query := index.searchQuery(entity.SearchParams{Lat: 51.5, Lon: -0.12, BoostSuperLikersOf: 7})
+
+	body, err := json.Marshal(query)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	expected := `{"filter":{"term":{"super_liked":7}},"weight":100}`
+	if !strings.Contains(string(body), expected) {
+		t.Errorf("expected query to contain %s, got %s", expected, body)
+	}
+}
+
+func TestSearchQuery_Ranking(t *testing.T) {
+	index := &User{index: "users", swipedIndex: swipedIndex}
+
+	unranked := index.searchQuery(entity.SearchParams{Lat: 51.5, Lon: -0.12})
+	if _, ok := unranked["query"].(map[string]interface{})["bool"]; !ok {
+		t.Errorf("expected a plain bool query without ranking weights, got %v", unranked["query"])
+	}
+
+	ranked := index.searchQuery(entity.SearchParams{
+		Lat:         51.5,
+		Lon:         -0.12,
+		SearcherAge: null.IntFrom(30),
+		Ranking:     entity.RankingWeights{Distance: 1, AgeFit: 0.5, Activity: 0.5, Completeness: 0.25, Desirability: 1},
+		After:       &entity.DiscoverCursor{Sort: []float64{1.2, 3.4, 5, 9}},
+		PIT:         "pit-1",
+		At:          time.Date(2024, 7, 8, 12, 0, 0, 0, time.UTC),
+	})
+
+	functionScore, ok := ranked["query"].(map[string]interface{})["function_score"].(map[string]interface{})
+	if !ok {
+		t.Fatalf("expected a function_score query, got %v", ranked["query"])
+	}
+
+	// Desirability is ranked in Go, so only the other four are scored here.
+	if functions := functionScore["functions"].([]map[string]interface{}); len(functions) != 4 {
+		t.Errorf("expected 4 functions, got %d", len(functions))
+	}
+
+	body, err := json.Marshal(ranked)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// Every page searches the same point in time, with activity decaying from
+	// when the session started.
+	for _, expected := range []string{
+		`"search_after":[1.2,3.4,5,9]`,
+		`"pit":{"id":"pit-1","keep_alive":"5m"}`,
+		`"last_active_at":{"decay":0.5,"origin":"2024-07-08T12:00:00Z"`,
+	} {
+		if !strings.Contains(string(body), expected) {
+			t.Errorf("expected query to contain %s, got %s", expected, body)
+		}
+	}
+}
+
+func TestIdealAge(t *testing.T) {
+	tests := []struct {
+		name           string
+		params         entity.SearchParams
+		expectedOrigin float64
+		expectedScale  float64
+		expectedOK     bool
+	}{
+		{
+			name:           "middle of the preferred range",
+			params:         entity.SearchParams{MinAge: null.IntFrom(20), MaxAge: null.IntFrom(40), SearcherAge: null.IntFrom(50)},
+			expectedOrigin: 30,
+			expectedScale:  10,
+			expectedOK:     true,
+		},
+		{
+			name:           "searcher's own age for an open range",
+			params:         entity.SearchParams{MinAge: null.IntFrom(20), SearcherAge: null.IntFrom(33)},
+			expectedOrigin: 33,
+			expectedScale:  minAgeScale,
+			expectedOK:     true,
+		},
+		{
+			name:   "nothing to go on",
+			params: entity.SearchParams{},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			origin, scale, ok := idealAge(tt.params)
+
+			if origin != tt.expectedOrigin || scale != tt.expectedScale || ok != tt.expectedOK {
+				t.Errorf("expected (%v, %v, %v), got (%v, %v, %v)",
+					tt.expectedOrigin, tt.expectedScale, tt.expectedOK, origin, scale, ok)
+			}
+		})
+	}
+}
@@ -0,0 +1,144 @@
+package user
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+
+	esv7 "github.com/elastic/go-elasticsearch/v7"
+	esv7api "github.com/elastic/go-elasticsearch/v7/esapi"
+)
+
+const swipedIndex = "swiped"
+
+// Swiped keeps one document per user listing everyone they have swiped on, so
+// discovery can exclude them with a terms lookup instead of sending every id.
+// Everyone a user super liked is listed on their own document in the users
+// index instead, so discovery can put them first from the same point in time as
+// the rest of the search.
+type Swiped struct {
+	client     *esv7.Client
+	index      string
+	usersIndex string
+}
+
+func NewSwiped(client *esv7.Client) *Swiped {
+	return &Swiped{
+		client:     client,
+		index:      swipedIndex,
+		usersIndex: "users",
+	}
+}
+
+type swipedDocument struct {
+	TargetIDs []int `json:"target_ids"`
+}
+
+// AddSwiped records that userID has swiped on targetID. It is idempotent.
+func (s *Swiped) AddSwiped(ctx context.Context, userID, targetID int) error {
+	body := addScript("target_ids", targetID)
+	body["upsert"] = swipedDocument{TargetIDs: []int{targetID}}
+
+	return s.update(ctx, s.index, userID, body)
+}
+
+// RemoveSwiped forgets that userID swiped on targetID, so they can be discovered again.
+func (s *Swiped) RemoveSwiped(ctx context.Context, userID, targetID int) error {
+	body := removeScript("target_ids", targetID)
+	body["upsert"] = swipedDocument{TargetIDs: []int{}}
+
+	return s.update(ctx, s.index, userID, body)
+}
+
+// AddSuperLike records that fromID super liked userID. It is idempotent, and
+// does nothing when fromID is not indexed.
+func (s *Swiped) AddSuperLike(ctx context.Context, userID, fromID int) error {
+	return s.update(ctx, s.usersIndex, fromID, addScript("super_liked", userID))
+}
+
+// RemoveSuperLike forgets that fromID super liked userID.
+func (s *Swiped) RemoveSuperLike(ctx context.Context, userID, fromID int) error {
+	return s.update(ctx, s.usersIndex, fromID, removeScript("super_liked", userID))
+}
+
+// ReplaceSwiped overwrites everyone userID has swiped on, e.g. when backfilling
+// from MySQL.
+func (s *Swiped) ReplaceSwiped(ctx context.Context, userID int, targetIDs []int) error {
+	body := map[string]interface{}{
+		"doc":           map[string]interface{}{"target_ids": targetIDs},
+		"doc_as_upsert": true,
+	}
+
+	return s.update(ctx, s.index, userID, body)
+}
+
+// ReplaceSuperLiked overwrites everyone userID has super liked, e.g. when
+// backfilling from MySQL.
+func (s *Swiped) ReplaceSuperLiked(ctx context.Context, userID int, targetIDs []int) error {
+	body := map[string]interface{}{
+		"doc": map[string]interface{}{"super_liked": targetIDs},
+	}
+
+	return s.update(ctx, s.usersIndex, userID, body)
+}
+
+// addScript adds id to the list in field, creating the list when missing.
+func addScript(field string, id int) map[string]interface{} {
+	return map[string]interface{}{
+		"script": map[string]interface{}{
+			"source": "if (ctx._source[params.field] == null) { ctx._source[params.field] = [] } " +
+				"if (!ctx._source[params.field].contains(params.id)) { ctx._source[params.field].add(params.id) } " +
+				"else { ctx.op = 'noop' }",
+			"params": map[string]interface{}{"field": field, "id": id},
+		},
+	}
+}
+
+// removeScript removes id from the list in field.
+func removeScript(field string, id int) map[string]interface{} {
+	return map[string]interface{}{
+		"script": map[string]interface{}{
+			"source": "if (ctx._source[params.field] == null || !ctx._source[params.field].removeIf(id -> id == params.id)) { ctx.op = 'noop' }",
+			"params": map[string]interface{}{"field": field, "id": id},
+		},
+	}
+}
+
+// update updates a document in index. Users' documents are never created here,
+// so one that is missing is left as is; swiped documents are upserted.
+func (s *Swiped) update(ctx context.Context, index string, userID int, body interface{}) error {
+	var buf bytes.Buffer
+
+	if err := json.NewEncoder(&buf).Encode(body); err != nil {
+		return fmt.Errorf("failed to encode body: %w", err)
+	}
+
+	retries := 3
+
+	req := esv7api.UpdateRequest{
+		Index:           index,
+		DocumentID:      fmt.Sprint(userID),
+		Body:            &buf,
+		RetryOnConflict: &retries,
+		// Discovery must not show someone again straight after the swipe. Waiting
+		// for the next scheduled refresh gives that without forcing one per swipe.
+		Refresh: "wait_for",
+	}
+
+	resp, err := req.Do(ctx, s.client)
+	if err != nil {
+		return fmt.Errorf("failed to update swiped: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.IsError() && !(index == s.usersIndex && resp.StatusCode == http.StatusNotFound) {
+		return fmt.Errorf("failed to update swiped: %s", resp.String())
+	}
+
+	io.Copy(io.Discard, resp.Body) //nolint: errcheck
+
+	return nil
+}
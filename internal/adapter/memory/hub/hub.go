@@ -0,0 +1,73 @@
+package hub
+
+import (
+	"context"
+	"sync"
+
+	"github.com/colmmurphy91/muzz/internal/entity"
+)
+
+// subscriberBuffer is how many notifications a device can fall behind by
+// before newer ones are dropped for it.
+const subscriberBuffer = 16
+
+// Hub delivers notifications to the devices connected to this instance. A user
+// connected to another instance won't get them pushed, so running more than
+// one instance needs a hub that fans out between them.
+type Hub struct {
+	mu          sync.Mutex
+	subscribers map[int]map[chan entity.Notification]struct{}
+}
+
+func NewHub() *Hub {
+	return &Hub{
+		subscribers: map[int]map[chan entity.Notification]struct{}{},
+	}
+}
+
+// Publish sends the notification to each of the user's devices. It never
+// blocks on a slow device; the notification is dropped for it instead and the
+// device catches up from the inbox.
+func (h *Hub) Publish(_ context.Context, notification entity.Notification) error {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	for ch := range h.subscribers[notification.UserID] {
+		select {
+		case ch <- notification:
+		default:
+		}
+	}
+
+	return nil
+}
+
+// Subscribe connects a device of the user. The returned func disconnects it
+// and closes the channel.
+func (h *Hub) Subscribe(userID int) (<-chan entity.Notification, func()) {
+	ch := make(chan entity.Notification, subscriberBuffer)
+
+	h.mu.Lock()
+	if h.subscribers[userID] == nil {
+		h.subscribers[userID] = map[chan entity.Notification]struct{}{}
+	}
+	h.subscribers[userID][ch] = struct{}{}
+	h.mu.Unlock()
+
+	var once sync.Once
+
+	return ch, func() {
+		once.Do(func() {
+			h.mu.Lock()
+			defer h.mu.Unlock()
+
+			delete(h.subscribers[userID], ch)
+
+			if len(h.subscribers[userID]) == 0 {
+				delete(h.subscribers, userID)
+			}
+
+			close(ch)
+		})
+	}
+}
@@ -0,0 +1,44 @@
+package hub
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/colmmurphy91/muzz/internal/entity"
+)
+
+func TestHub(t *testing.T) {
+	ctx := context.Background()
+	hub := NewHub()
+
+	phone, unsubscribePhone := hub.Subscribe(1)
+	laptop, unsubscribeLaptop := hub.Subscribe(1)
+	other, unsubscribeOther := hub.Subscribe(2)
+
+	notification := entity.Notification{ID: 1, UserID: 1, Type: entity.NotificationMatchCreated, ActorID: 2, MatchID: 3}
+	assert.NoError(t, hub.Publish(ctx, notification))
+
+	// Every device of the user gets it, other users don't.
+	assert.Equal(t, notification, <-phone)
+	assert.Equal(t, notification, <-laptop)
+	assert.Empty(t, other)
+
+	unsubscribePhone()
+	unsubscribePhone()
+
+	_, open := <-phone
+	assert.False(t, open)
+
+	// A device that falls behind drops notifications rather than blocking.
+	for i := 0; i < subscriberBuffer+1; i++ {
+		assert.NoError(t, hub.Publish(ctx, notification))
+	}
+
+	assert.Len(t, laptop, subscriberBuffer)
+
+	unsubscribeLaptop()
+	unsubscribeOther()
+	assert.Empty(t, hub.subscribers)
+}
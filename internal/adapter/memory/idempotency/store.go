@@ -0,0 +1,76 @@
+package idempotency
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/colmmurphy91/muzz/internal/pkg/idempotency"
+)
+
+// Store keeps idempotent responses in memory. Each instance keeps its own and
+// forgets them on restart, so it suits a single instance or local development.
+type Store struct {
+	mu      sync.Mutex
+	records map[string]record
+	now     func() time.Time
+}
+
+type record struct {
+	idempotency.Record
+	expiresAt time.Time
+}
+
+func NewStore() *Store {
+	return &Store{
+		records: map[string]record{},
+		now:     time.Now,
+	}
+}
+
+func (s *Store) Reserve(_ context.Context, key, fingerprint string, ttl time.Duration) (idempotency.Record, bool, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	now := s.now()
+
+	for k, r := range s.records {
+		if !r.expiresAt.After(now) {
+			delete(s.records, k)
+		}
+	}
+
+	if existing, ok := s.records[key]; ok {
+		return existing.Record, false, nil
+	}
+
+	s.records[key] = record{
+		Record:    idempotency.Record{Fingerprint: fingerprint},
+		expiresAt: now.Add(ttl),
+	}
+
+	return idempotency.Record{}, true, nil
+}
+
+func (s *Store) Complete(_ context.Context, key string, resp idempotency.Response) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if existing, ok := s.records[key]; ok {
+		existing.Response = &resp
+		s.records[key] = existing
+	}
+
+	return nil
+}
+
+func (s *Store) Release(_ context.Context, key string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if existing, ok := s.records[key]; ok && existing.Response == nil {
+		delete(s.records, key)
+	}
+
+	return nil
+}
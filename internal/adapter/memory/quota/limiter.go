@@ -0,0 +1,94 @@
+package quota
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/colmmurphy91/muzz/internal/entity"
+)
+
+// Limiter limits likes with the uses kept in memory. Each instance counts on its
+// own and forgets everything on restart, so it suits a single instance or
+// local development. Uses are not part of the unit of work, so a use taken for
+// a swipe that then fails is given back with Refund.
+type Limiter struct {
+	mu   sync.Mutex
+	uses map[int][]time.Time
+}
+
+func NewLimiter() *Limiter {
+	return &Limiter{
+		uses: map[int][]time.Time{},
+	}
+}
+
+// Take uses one of the user's likes, failing with entity.QuotaExceededError when
+// none are left.
+func (l *Limiter) Take(_ context.Context, userID int, rule entity.QuotaRule, now time.Time) (entity.Quota, error) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	uses := l.inWindow(userID, rule, now)
+
+	if quota := entity.NewQuota(rule, uses); quota.Remaining == 0 {
+		return quota, entity.QuotaExceededError{ResetAt: quota.ResetAt.Time}
+	}
+
+	uses = append(uses, now)
+	l.uses[userID] = uses
+
+	return entity.NewQuota(rule, uses), nil
+}
+
+// Refund gives back the use taken at usedAt, if it is still in the window.
+func (l *Limiter) Refund(_ context.Context, userID int, usedAt time.Time) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	uses := l.uses[userID]
+
+	for i := len(uses) - 1; i >= 0; i-- {
+		if uses[i].Equal(usedAt) {
+			uses = append(uses[:i], uses[i+1:]...)
+			break
+		}
+	}
+
+	if len(uses) == 0 {
+		delete(l.uses, userID)
+		return
+	}
+
+	l.uses[userID] = uses
+}
+
+// Quota returns how many likes the user has left.
+func (l *Limiter) Quota(_ context.Context, userID int, rule entity.QuotaRule, now time.Time) (entity.Quota, error) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	return entity.NewQuota(rule, l.inWindow(userID, rule, now)), nil
+}
+
+// inWindow drops the user's uses that have left the window and returns the rest.
+func (l *Limiter) inWindow(userID int, rule entity.QuotaRule, now time.Time) []time.Time {
+	uses := l.uses[userID]
+	since := now.Add(-rule.Window)
+
+	expired := 0
+	for expired < len(uses) && !uses[expired].After(since) {
+		expired++
+	}
+
+	uses = uses[expired:]
+
+	if len(uses) == 0 {
+		delete(l.uses, userID)
+		return nil
+	}
+
+	l.uses[userID] = uses
+
+	return uses
+}
@@ -0,0 +1,69 @@
+package quota
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	null "github.com/guregu/null/v5"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/colmmurphy91/muzz/internal/entity"
+)
+
+func TestLimiter(t *testing.T) {
+	ctx := context.Background()
+	rule := entity.QuotaRule{Limit: 2, Window: 24 * time.Hour}
+	start := time.Date(2024, 6, 30, 9, 0, 0, 0, time.UTC)
+	limiter := NewLimiter()
+
+	quota, err := limiter.Quota(ctx, 1, rule, start)
+	assert.NoError(t, err)
+	assert.Equal(t, entity.Quota{Limit: 2, Remaining: 2}, quota)
+
+	_, err = limiter.Take(ctx, 1, rule, start)
+	assert.NoError(t, err)
+
+	quota, err = limiter.Take(ctx, 1, rule, start.Add(time.Hour))
+	assert.NoError(t, err)
+	assert.Equal(t, entity.Quota{Limit: 2, Used: 2, Remaining: 0, ResetAt: null.TimeFrom(start.Add(24 * time.Hour))}, quota)
+
+	_, err = limiter.Take(ctx, 1, rule, start.Add(2*time.Hour))
+
+	var quotaErr entity.QuotaExceededError
+	assert.True(t, errors.As(err, &quotaErr))
+	assert.True(t, errors.Is(err, entity.ErrQuotaExceeded))
+	assert.Equal(t, start.Add(24*time.Hour), quotaErr.ResetAt)
+
+	// Other users have their own quota.
+	_, err = limiter.Take(ctx, 2, rule, start.Add(2*time.Hour))
+	assert.NoError(t, err)
+
+	// The first like leaves the window a day later, freeing one up.
+	quota, err = limiter.Take(ctx, 1, rule, start.Add(24*time.Hour+time.Minute))
+	assert.NoError(t, err)
+	assert.Equal(t, entity.Quota{Limit: 2, Used: 2, Remaining: 0, ResetAt: null.TimeFrom(start.Add(25 * time.Hour))}, quota)
+}
+
+func TestLimiter_Refund(t *testing.T) {
+	ctx := context.Background()
+	rule := entity.QuotaRule{Limit: 1, Window: 24 * time.Hour}
+	start := time.Date(2024, 6, 30, 9, 0, 0, 0, time.UTC)
+	limiter := NewLimiter()
+
+	_, err := limiter.Take(ctx, 1, rule, start)
+	assert.NoError(t, err)
+
+	// A use that was never taken changes nothing.
+	limiter.Refund(ctx, 1, start.Add(time.Hour))
+
+	_, err = limiter.Take(ctx, 1, rule, start.Add(time.Hour))
+	assert.True(t, errors.Is(err, entity.ErrQuotaExceeded))
+
+	limiter.Refund(ctx, 1, start)
+
+	quota, err := limiter.Quota(ctx, 1, rule, start.Add(time.Hour))
+	assert.NoError(t, err)
+	assert.Equal(t, entity.Quota{Limit: 1, Remaining: 1}, quota)
+}
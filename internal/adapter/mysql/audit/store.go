@@ -0,0 +1,44 @@
+package audit
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/jmoiron/sqlx"
+	"go.uber.org/zap"
+
+	"github.com/colmmurphy91/muzz/internal/adapter/mysql/uow"
+	"github.com/colmmurphy91/muzz/internal/entity"
+)
+
+type Store struct {
+	log *zap.SugaredLogger
+	db  *sqlx.DB
+}
+
+func NewStore(log *zap.SugaredLogger, db *sqlx.DB) *Store {
+	return &Store{
+		log: log,
+		db:  db,
+	}
+}
+
+// conn is the transaction of the unit of work ctx belongs to, if any.
+func (s *Store) conn(ctx context.Context) uow.Conn {
+	return uow.ConnFrom(ctx, s.db)
+}
+
+// CreateAuditEntry records an action taken through the admin API. Written in
+// the unit of work of the action, the entry exists exactly when the action does.
+func (s *Store) CreateAuditEntry(ctx context.Context, entry entity.AuditEntry) error {
+	query := `
+		INSERT INTO audit_log (actor_id, action, target_id, detail, reason, created_at)
+		VALUES (:actor_id, :action, :target_id, :detail, :reason, :created_at)
+	`
+
+	if _, err := s.conn(ctx).NamedExecContext(ctx, query, entry); err != nil {
+		return fmt.Errorf("failed to create audit entry: %w", err)
+	}
+
+	return nil
+}
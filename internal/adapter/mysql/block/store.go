@@ -0,0 +1,75 @@
+package block
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/jmoiron/sqlx"
+	"go.uber.org/zap"
+
+	"github.com/colmmurphy91/muzz/internal/adapter/mysql/uow"
+)
+
+type Store struct {
+	log *zap.SugaredLogger
+	db  *sqlx.DB
+}
+
+func NewStore(log *zap.SugaredLogger, db *sqlx.DB) *Store {
+	return &Store{
+		log: log,
+		db:  db,
+	}
+}
+
+// conn is the transaction of the unit of work ctx belongs to, if any.
+func (s *Store) conn(ctx context.Context) uow.Conn {
+	return uow.ConnFrom(ctx, s.db)
+}
+
+// Block records that blockerID blocked blockedID. Blocking again does nothing.
+func (s *Store) Block(ctx context.Context, blockerID, blockedID int) error {
+	query := "INSERT IGNORE INTO blocks (blocker_id, blocked_id) VALUES (?, ?)"
+
+	if _, err := s.conn(ctx).ExecContext(ctx, query, blockerID, blockedID); err != nil {
+		return fmt.Errorf("failed to block: %w", err)
+	}
+
+	return nil
+}
+
+// IsBlocked reports whether either of the pair has blocked the other. In a
+// unit of work the pair's blocks, even missing ones, stay locked until it
+// ends, so a block made meanwhile waits for it and sees what it did, such as a
+// match it created.
+func (s *Store) IsBlocked(ctx context.Context, userID, otherID int) (bool, error) {
+	var blocked bool
+
+	query := `
+		SELECT COUNT(*) > 0 FROM blocks
+		WHERE (blocker_id = ? AND blocked_id = ?) OR (blocker_id = ? AND blocked_id = ?)
+		FOR SHARE
+	`
+
+	if err := s.conn(ctx).GetContext(ctx, &blocked, query, userID, otherID, otherID, userID); err != nil {
+		return false, fmt.Errorf("failed to find block: %w", err)
+	}
+
+	return blocked, nil
+}
+
+// BlockedIDs returns everyone the user has blocked or been blocked by.
+func (s *Store) BlockedIDs(ctx context.Context, userID int) ([]int, error) {
+	ids := []int{}
+	query := `
+		SELECT blocked_id FROM blocks WHERE blocker_id = ?
+		UNION
+		SELECT blocker_id FROM blocks WHERE blocked_id = ?
+	`
+
+	if err := s.conn(ctx).SelectContext(ctx, &ids, query, userID, userID); err != nil {
+		return nil, fmt.Errorf("failed to find blocks: %w", err)
+	}
+
+	return ids, nil
+}
@@ -0,0 +1,105 @@
+package idempotency
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	null "github.com/guregu/null/v5"
+	"github.com/jmoiron/sqlx"
+	"go.uber.org/zap"
+
+	"github.com/colmmurphy91/muzz/internal/pkg/idempotency"
+)
+
+// pruneBatch is how many expired keys are deleted each time a key is reserved.
+const pruneBatch = 100
+
+// Store keeps idempotent responses in MySQL, so they are shared by every instance.
+type Store struct {
+	log *zap.SugaredLogger
+	db  *sqlx.DB
+	now func() time.Time
+}
+
+func NewStore(log *zap.SugaredLogger, db *sqlx.DB) *Store {
+	return &Store{
+		log: log,
+		db:  db,
+		now: time.Now,
+	}
+}
+
+// Reserve claims key, or an expired claim on it, in a single statement, so only
+// one of two requests sent at once gets it.
+func (s *Store) Reserve(ctx context.Context, key, fingerprint string, ttl time.Duration) (idempotency.Record, bool, error) {
+	now := s.now().UTC()
+
+	if _, err := s.db.ExecContext(ctx, "DELETE FROM idempotency_keys WHERE expires_at <= ? LIMIT ?", now, pruneBatch); err != nil {
+		return idempotency.Record{}, false, fmt.Errorf("failed to prune idempotency keys: %w", err)
+	}
+
+	// Columns are assigned left to right, so expires_at is compared before it
+	// is replaced.
+	query := `
+		INSERT INTO idempotency_keys (key_hash, fingerprint, expires_at)
+		VALUES (?, ?, ?)
+		ON DUPLICATE KEY UPDATE
+			fingerprint = IF(expires_at <= ?, VALUES(fingerprint), fingerprint),
+			status = IF(expires_at <= ?, NULL, status),
+			body = IF(expires_at <= ?, NULL, body),
+			expires_at = IF(expires_at <= ?, VALUES(expires_at), expires_at)
+	`
+
+	result, err := s.db.ExecContext(ctx, query, key, fingerprint, now.Add(ttl), now, now, now, now)
+	if err != nil {
+		return idempotency.Record{}, false, fmt.Errorf("failed to reserve idempotency key: %w", err)
+	}
+
+	// 1 when inserted, 2 when an expired claim was replaced and 0 when a live
+	// claim was left alone.
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return idempotency.Record{}, false, fmt.Errorf("failed to reserve idempotency key: %w", err)
+	}
+
+	if affected > 0 {
+		return idempotency.Record{}, true, nil
+	}
+
+	var row struct {
+		Fingerprint string   `db:"fingerprint"`
+		Status      null.Int `db:"status"`
+		Body        []byte   `db:"body"`
+	}
+
+	if err := s.db.GetContext(ctx, &row, "SELECT fingerprint, status, body FROM idempotency_keys WHERE key_hash = ?", key); err != nil {
+		return idempotency.Record{}, false, fmt.Errorf("failed to find idempotency key: %w", err)
+	}
+
+	record := idempotency.Record{Fingerprint: row.Fingerprint}
+
+	if row.Status.Valid {
+		record.Response = &idempotency.Response{Status: int(row.Status.Int64), Body: row.Body}
+	}
+
+	return record, false, nil
+}
+
+func (s *Store) Complete(ctx context.Context, key string, resp idempotency.Response) error {
+	query := "UPDATE idempotency_keys SET status = ?, body = ? WHERE key_hash = ?"
+
+	if _, err := s.db.ExecContext(ctx, query, resp.Status, resp.Body, key); err != nil {
+		return fmt.Errorf("failed to save idempotent response: %w", err)
+	}
+
+	return nil
+}
+
+func (s *Store) Release(ctx context.Context, key string) error {
+	if _, err := s.db.ExecContext(ctx, "DELETE FROM idempotency_keys WHERE key_hash = ? AND status IS NULL", key); err != nil {
+		return fmt.Errorf("failed to release idempotency key: %w", err)
+	}
+
+	return nil
+}
@@ -2,6 +2,7 @@ package match
 
 import (
 	"context"
+	"database/sql"
 	"errors"
 	"fmt"
 
@@ -9,6 +10,7 @@ import (
 	"github.com/jmoiron/sqlx"
 	"go.uber.org/zap"
 
+	"github.com/colmmurphy91/muzz/internal/adapter/mysql/uow"
 	"github.com/colmmurphy91/muzz/internal/entity"
 )
 
@@ -24,13 +26,18 @@ func NewStore(log *zap.SugaredLogger, db *sqlx.DB) *Store {
 	}
 }
 
+// conn is the transaction of the unit of work ctx belongs to, if any.
+func (s *Store) conn(ctx context.Context) uow.Conn {
+	return uow.ConnFrom(ctx, s.db)
+}
+
 func (s *Store) CreateMatch(ctx context.Context, match entity.Match) (entity.Match, error) {
 	query := `
 		INSERT INTO matches (user1_id, user2_id, match_id)
 		VALUES (:user1_id, :user2_id, :match_id)
 	`
 
-	result, err := s.db.NamedExecContext(ctx, query, match)
+	result, err := s.conn(ctx).NamedExecContext(ctx, query, match)
 	if err != nil {
 		var mysqlErr *mysql.MySQLError
 		if errors.As(err, &mysqlErr) && mysqlErr.Number == 1062 {
@@ -49,3 +56,84 @@ func (s *Store) CreateMatch(ctx context.Context, match entity.Match) (entity.Mat
 
 	return match, nil
 }
+
+// GetMatches returns up to limit of the user's active matches, newest first. When
+// beforeID is set only matches older than it are returned.
+func (s *Store) GetMatches(ctx context.Context, userID, beforeID, limit int) ([]entity.Match, error) {
+	matches := []entity.Match{}
+	query := `
+		SELECT id, user1_id, user2_id, match_id, created_at, unmatched_at, unmatched_by
+		FROM matches
+		WHERE (user1_id = ? OR user2_id = ?) AND unmatched_at IS NULL
+	`
+	args := []interface{}{userID, userID}
+
+	if beforeID > 0 {
+		query += " AND id < ?"
+		args = append(args, beforeID)
+	}
+
+	query += " ORDER BY id DESC LIMIT ?"
+	args = append(args, limit)
+
+	if err := s.conn(ctx).SelectContext(ctx, &matches, query, args...); err != nil {
+		return nil, fmt.Errorf("failed to find matches: %w", err)
+	}
+
+	return matches, nil
+}
+
+func (s *Store) FindMatch(ctx context.Context, id int) (entity.Match, error) {
+	var match entity.Match
+	query := `
+		SELECT id, user1_id, user2_id, match_id, created_at, unmatched_at, unmatched_by
+		FROM matches
+		WHERE id = ?
+	`
+
+	if err := s.conn(ctx).GetContext(ctx, &match, query, id); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return entity.Match{}, entity.ErrMatchNotFound
+		}
+
+		return entity.Match{}, fmt.Errorf("failed to find match: %w", err)
+	}
+
+	return match, nil
+}
+
+// Unmatch soft deletes an active match, recording that userID unmatched it.
+func (s *Store) Unmatch(ctx context.Context, id, userID int) error {
+	query := `
+		UPDATE matches
+		SET unmatched_at = CURRENT_TIMESTAMP, unmatched_by = ?
+		WHERE id = ? AND unmatched_at IS NULL
+	`
+
+	if _, err := s.conn(ctx).ExecContext(ctx, query, userID, id); err != nil {
+		return fmt.Errorf("failed to unmatch: %w", err)
+	}
+
+	return nil
+}
+
+// FindMatchByPair returns the match between two people, active or not. The
+// pair must be in order, as entity.NewMatch makes it.
+func (s *Store) FindMatchByPair(ctx context.Context, user1ID, user2ID int) (entity.Match, error) {
+	var match entity.Match
+	query := `
+		SELECT id, user1_id, user2_id, match_id, created_at, unmatched_at, unmatched_by
+		FROM matches
+		WHERE user1_id = ? AND user2_id = ?
+	`
+
+	if err := s.conn(ctx).GetContext(ctx, &match, query, user1ID, user2ID); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return entity.Match{}, entity.ErrMatchNotFound
+		}
+
+		return entity.Match{}, fmt.Errorf("failed to find match: %w", err)
+	}
+
+	return match, nil
+}
@@ -0,0 +1,204 @@
+package message
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+
+	"github.com/jmoiron/sqlx"
+	"go.uber.org/zap"
+
+	"github.com/colmmurphy91/muzz/internal/adapter/mysql/uow"
+	"github.com/colmmurphy91/muzz/internal/entity"
+)
+
+type Store struct {
+	log *zap.SugaredLogger
+	db  *sqlx.DB
+}
+
+func NewStore(log *zap.SugaredLogger, db *sqlx.DB) *Store {
+	return &Store{
+		log: log,
+		db:  db,
+	}
+}
+
+// conn is the transaction of the unit of work ctx belongs to, if any.
+func (s *Store) conn(ctx context.Context) uow.Conn {
+	return uow.ConnFrom(ctx, s.db)
+}
+
+// StartConversation makes sure the match has a conversation. It does nothing
+// when it already has one.
+func (s *Store) StartConversation(ctx context.Context, matchID int) error {
+	query := "INSERT IGNORE INTO conversations (match_id) VALUES (?)"
+
+	if _, err := s.conn(ctx).ExecContext(ctx, query, matchID); err != nil {
+		return fmt.Errorf("failed to start conversation: %w", err)
+	}
+
+	return nil
+}
+
+// CreateMessage saves a message to the conversation of its match. The match is
+// read in the same statement, so a message racing an unmatch is either saved
+// first or fails with entity.ErrMatchEnded.
+func (s *Store) CreateMessage(ctx context.Context, message entity.Message) (entity.Message, error) {
+	query := `
+		INSERT INTO messages (match_id, sender_id, body, created_at)
+		SELECT id, ?, ?, ?
+		FROM matches
+		WHERE id = ? AND unmatched_at IS NULL
+	`
+
+	result, err := s.conn(ctx).ExecContext(ctx, query, message.SenderID, message.Body, message.CreatedAt, message.MatchID)
+	if err != nil {
+		return entity.Message{}, fmt.Errorf("failed to create message: %w", err)
+	}
+
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return entity.Message{}, fmt.Errorf("failed to read affected rows: %w", err)
+	}
+
+	if affected == 0 {
+		return entity.Message{}, entity.ErrMatchEnded
+	}
+
+	id, err := result.LastInsertId()
+	if err != nil {
+		return entity.Message{}, fmt.Errorf("failed to extract id: %w", err)
+	}
+
+	message.ID = int(id)
+
+	query = "UPDATE conversations SET last_message_id = ? WHERE match_id = ?"
+
+	if _, err := s.conn(ctx).ExecContext(ctx, query, message.ID, message.MatchID); err != nil {
+		return entity.Message{}, fmt.Errorf("failed to update conversation: %w", err)
+	}
+
+	return message, nil
+}
+
+// GetMessages returns up to limit messages of the match's conversation, newest
+// first. When beforeID is set only messages older than it are returned.
+func (s *Store) GetMessages(ctx context.Context, matchID, beforeID, limit int) ([]entity.Message, error) {
+	messages := []entity.Message{}
+	query := `
+		SELECT id, match_id, sender_id, body, created_at
+		FROM messages
+		WHERE match_id = ?
+	`
+	args := []interface{}{matchID}
+
+	if beforeID > 0 {
+		query += " AND id < ?"
+		args = append(args, beforeID)
+	}
+
+	query += " ORDER BY id DESC LIMIT ?"
+	args = append(args, limit)
+
+	if err := s.conn(ctx).SelectContext(ctx, &messages, query, args...); err != nil {
+		return nil, fmt.Errorf("failed to find messages: %w", err)
+	}
+
+	return messages, nil
+}
+
+// LastMessageID returns the id of the newest message of the match's
+// conversation, or 0 when it has none.
+func (s *Store) LastMessageID(ctx context.Context, matchID int) (int, error) {
+	var id int
+
+	query := "SELECT last_message_id FROM conversations WHERE match_id = ?"
+
+	if err := s.conn(ctx).GetContext(ctx, &id, query, matchID); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return 0, nil
+		}
+
+		return 0, fmt.Errorf("failed to find conversation: %w", err)
+	}
+
+	return id, nil
+}
+
+// GetReads returns how far each of the pair has read the match's
+// conversation. Someone who has read none of it has no entry.
+func (s *Store) GetReads(ctx context.Context, matchID int) ([]entity.MessageRead, error) {
+	reads := []entity.MessageRead{}
+	query := `
+		SELECT match_id, user_id, last_read_id, read_at
+		FROM conversation_reads
+		WHERE match_id = ?
+	`
+
+	if err := s.conn(ctx).SelectContext(ctx, &reads, query, matchID); err != nil {
+		return nil, fmt.Errorf("failed to find reads: %w", err)
+	}
+
+	return reads, nil
+}
+
+// MarkRead records that the user has read up to read.LastReadID. It never
+// moves backwards, so an older receipt arriving late is ignored.
+func (s *Store) MarkRead(ctx context.Context, read entity.MessageRead) error {
+	// read_at is assigned first, as it compares against the old last_read_id.
+	query := `
+		INSERT INTO conversation_reads (match_id, user_id, last_read_id, read_at)
+		VALUES (:match_id, :user_id, :last_read_id, :read_at)
+		ON DUPLICATE KEY UPDATE
+			read_at = IF(VALUES(last_read_id) > last_read_id, VALUES(read_at), read_at),
+			last_read_id = GREATEST(last_read_id, VALUES(last_read_id))
+	`
+
+	if _, err := s.conn(ctx).NamedExecContext(ctx, query, read); err != nil {
+		return fmt.Errorf("failed to mark read: %w", err)
+	}
+
+	return nil
+}
+
+// CountUnread returns how many messages of the match's conversation after
+// afterID were sent by someone other than the user.
+func (s *Store) CountUnread(ctx context.Context, matchID, userID, afterID int) (int, error) {
+	var count int
+
+	query := `
+		SELECT COUNT(*)
+		FROM messages
+		WHERE match_id = ? AND sender_id <> ? AND id > ?
+	`
+
+	if err := s.conn(ctx).GetContext(ctx, &count, query, matchID, userID, afterID); err != nil {
+		return 0, fmt.Errorf("failed to count unread messages: %w", err)
+	}
+
+	return count, nil
+}
+
+// GetUnreadCounts returns how many unread messages the user has in each of
+// their active matches, leaving out matches with none.
+func (s *Store) GetUnreadCounts(ctx context.Context, userID int) ([]entity.UnreadCount, error) {
+	counts := []entity.UnreadCount{}
+	query := `
+		SELECT m.match_id, COUNT(*) AS unread
+		FROM messages m
+		JOIN matches ma ON ma.id = m.match_id
+		LEFT JOIN conversation_reads r ON r.match_id = m.match_id AND r.user_id = ?
+		WHERE (ma.user1_id = ? OR ma.user2_id = ?) AND ma.unmatched_at IS NULL
+			AND m.sender_id <> ? AND m.id > COALESCE(r.last_read_id, 0)
+		GROUP BY m.match_id
+		ORDER BY m.match_id DESC
+	`
+
+	if err := s.conn(ctx).SelectContext(ctx, &counts, query, userID, userID, userID, userID); err != nil {
+		return nil, fmt.Errorf("failed to count unread messages: %w", err)
+	}
+
+	return counts, nil
+}
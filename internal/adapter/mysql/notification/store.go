@@ -0,0 +1,78 @@
+package notification
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/jmoiron/sqlx"
+	"go.uber.org/zap"
+
+	"github.com/colmmurphy91/muzz/internal/adapter/mysql/uow"
+	"github.com/colmmurphy91/muzz/internal/entity"
+)
+
+type Store struct {
+	log *zap.SugaredLogger
+	db  *sqlx.DB
+}
+
+func NewStore(log *zap.SugaredLogger, db *sqlx.DB) *Store {
+	return &Store{
+		log: log,
+		db:  db,
+	}
+}
+
+// conn is the transaction of the unit of work ctx belongs to, if any.
+func (s *Store) conn(ctx context.Context) uow.Conn {
+	return uow.ConnFrom(ctx, s.db)
+}
+
+// CreateNotification saves a notification. In a unit of work it is only saved
+// if the change it tells about is.
+func (s *Store) CreateNotification(ctx context.Context, notification entity.Notification) (entity.Notification, error) {
+	query := `
+		INSERT INTO notifications (user_id, type, actor_id, match_id, created_at)
+		VALUES (:user_id, :type, :actor_id, :match_id, :created_at)
+	`
+
+	result, err := s.conn(ctx).NamedExecContext(ctx, query, notification)
+	if err != nil {
+		return entity.Notification{}, fmt.Errorf("failed to create notification: %w", err)
+	}
+
+	id, err := result.LastInsertId()
+	if err != nil {
+		return entity.Notification{}, fmt.Errorf("failed to extract id: %w", err)
+	}
+
+	notification.ID = int(id)
+
+	return notification, nil
+}
+
+// GetNotifications returns up to limit of the user's notifications, newest
+// first. When beforeID is set only notifications older than it are returned.
+func (s *Store) GetNotifications(ctx context.Context, userID, beforeID, limit int) ([]entity.Notification, error) {
+	notifications := []entity.Notification{}
+	query := `
+		SELECT id, user_id, type, actor_id, match_id, created_at
+		FROM notifications
+		WHERE user_id = ?
+	`
+	args := []interface{}{userID}
+
+	if beforeID > 0 {
+		query += " AND id < ?"
+		args = append(args, beforeID)
+	}
+
+	query += " ORDER BY id DESC LIMIT ?"
+	args = append(args, limit)
+
+	if err := s.conn(ctx).SelectContext(ctx, &notifications, query, args...); err != nil {
+		return nil, fmt.Errorf("failed to find notifications: %w", err)
+	}
+
+	return notifications, nil
+}
@@ -0,0 +1,106 @@
+package preference
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"strings"
+
+	null "github.com/guregu/null/v5"
+	"github.com/jmoiron/sqlx"
+	"go.uber.org/zap"
+
+	"github.com/colmmurphy91/muzz/internal/entity"
+)
+
+type Store struct {
+	log *zap.SugaredLogger
+	db  *sqlx.DB
+}
+
+func NewStore(log *zap.SugaredLogger, db *sqlx.DB) *Store {
+	return &Store{
+		log: log,
+		db:  db,
+	}
+}
+
+// preferencesRow stores genders as a comma separated list.
+type preferencesRow struct {
+	UserID        int        `db:"user_id"`
+	MinAge        null.Int   `db:"min_age"`
+	MaxAge        null.Int   `db:"max_age"`
+	Genders       string     `db:"genders"`
+	MaxDistanceKm null.Float `db:"max_distance_km"`
+	ShowMe        bool       `db:"show_me"`
+}
+
+func (s *Store) GetPreferences(ctx context.Context, userID int) (entity.Preferences, error) {
+	var row preferencesRow
+	query := `
+		SELECT user_id, min_age, max_age, genders, max_distance_km, show_me
+		FROM discovery_preferences
+		WHERE user_id = ?
+	`
+
+	err := s.db.GetContext(ctx, &row, query, userID)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return entity.Preferences{}, entity.ErrPreferencesNotFound
+		}
+
+		return entity.Preferences{}, fmt.Errorf("failed to find preferences: %w", err)
+	}
+
+	genders := []string{}
+	if row.Genders != "" {
+		genders = strings.Split(row.Genders, ",")
+	}
+
+	return entity.Preferences{
+		UserID:        row.UserID,
+		MinAge:        row.MinAge,
+		MaxAge:        row.MaxAge,
+		Genders:       genders,
+		MaxDistanceKm: row.MaxDistanceKm,
+		ShowMe:        row.ShowMe,
+	}, nil
+}
+
+func (s *Store) SavePreferences(ctx context.Context, prefs entity.Preferences) error {
+	query := `
+		INSERT INTO discovery_preferences (user_id, min_age, max_age, genders, max_distance_km, show_me)
+		VALUES (:user_id, :min_age, :max_age, :genders, :max_distance_km, :show_me)
+		ON DUPLICATE KEY UPDATE
+			min_age = VALUES(min_age),
+			max_age = VALUES(max_age),
+			genders = VALUES(genders),
+			max_distance_km = VALUES(max_distance_km),
+			show_me = VALUES(show_me)
+	`
+
+	_, err := s.db.NamedExecContext(ctx, query, preferencesRow{
+		UserID:        prefs.UserID,
+		MinAge:        prefs.MinAge,
+		MaxAge:        prefs.MaxAge,
+		Genders:       strings.Join(prefs.Genders, ","),
+		MaxDistanceKm: prefs.MaxDistanceKm,
+		ShowMe:        prefs.ShowMe,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to save preferences: %w", err)
+	}
+
+	return nil
+}
+
+func (s *Store) DeletePreferences(ctx context.Context, userID int) error {
+	query := "DELETE FROM discovery_preferences WHERE user_id = ?"
+
+	if _, err := s.db.ExecContext(ctx, query, userID); err != nil {
+		return fmt.Errorf("failed to delete preferences: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,93 @@
+package quota
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/jmoiron/sqlx"
+	"go.uber.org/zap"
+
+	"github.com/colmmurphy91/muzz/internal/adapter/mysql/uow"
+	"github.com/colmmurphy91/muzz/internal/entity"
+)
+
+// Store limits likes with the uses kept in MySQL, so the limit holds across
+// instances. Used in a unit of work, a use is only kept if the rest of the work
+// is.
+type Store struct {
+	log *zap.SugaredLogger
+	db  *sqlx.DB
+}
+
+func NewStore(log *zap.SugaredLogger, db *sqlx.DB) *Store {
+	return &Store{
+		log: log,
+		db:  db,
+	}
+}
+
+// conn is the transaction of the unit of work ctx belongs to, if any.
+func (s *Store) conn(ctx context.Context) uow.Conn {
+	return uow.ConnFrom(ctx, s.db)
+}
+
+// Take uses one of the user's likes, failing with entity.QuotaExceededError when
+// none are left. In a unit of work the user's uses stay locked until the
+// transaction ends, so concurrent likes cannot both take the last one.
+func (s *Store) Take(ctx context.Context, userID int, rule entity.QuotaRule, now time.Time) (entity.Quota, error) {
+	since := now.Add(-rule.Window)
+
+	if _, err := s.conn(ctx).ExecContext(ctx, "DELETE FROM like_quota_uses WHERE user_id = ? AND used_at <= ?", userID, since); err != nil {
+		return entity.Quota{}, fmt.Errorf("failed to prune quota: %w", err)
+	}
+
+	uses, err := s.uses(ctx, userID, since, true)
+	if err != nil {
+		return entity.Quota{}, err
+	}
+
+	if quota := entity.NewQuota(rule, uses); quota.Remaining == 0 {
+		return quota, entity.QuotaExceededError{ResetAt: quota.ResetAt.Time}
+	}
+
+	if _, err := s.conn(ctx).ExecContext(ctx, "INSERT INTO like_quota_uses (user_id, used_at) VALUES (?, ?)", userID, now); err != nil {
+		return entity.Quota{}, fmt.Errorf("failed to use quota: %w", err)
+	}
+
+	return entity.NewQuota(rule, append(uses, now)), nil
+}
+
+// Refund does nothing: uses taken in a unit of work are rolled back with it.
+func (s *Store) Refund(_ context.Context, _ int, _ time.Time) {}
+
+// Quota returns how many likes the user has left.
+func (s *Store) Quota(ctx context.Context, userID int, rule entity.QuotaRule, now time.Time) (entity.Quota, error) {
+	uses, err := s.uses(ctx, userID, now.Add(-rule.Window), false)
+	if err != nil {
+		return entity.Quota{}, err
+	}
+
+	return entity.NewQuota(rule, uses), nil
+}
+
+// uses returns when the user used their quota after since, oldest first.
+func (s *Store) uses(ctx context.Context, userID int, since time.Time, lock bool) ([]time.Time, error) {
+	uses := []time.Time{}
+	query := `
+		SELECT used_at
+		FROM like_quota_uses
+		WHERE user_id = ? AND used_at > ?
+		ORDER BY used_at
+	`
+
+	if lock {
+		query += " FOR UPDATE"
+	}
+
+	if err := s.conn(ctx).SelectContext(ctx, &uses, query, userID, since); err != nil {
+		return nil, fmt.Errorf("failed to find quota uses: %w", err)
+	}
+
+	return uses, nil
+}
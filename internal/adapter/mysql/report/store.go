@@ -0,0 +1,109 @@
+package report
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+
+	"github.com/jmoiron/sqlx"
+	"go.uber.org/zap"
+
+	"github.com/colmmurphy91/muzz/internal/adapter/mysql/uow"
+	"github.com/colmmurphy91/muzz/internal/entity"
+)
+
+type Store struct {
+	log *zap.SugaredLogger
+	db  *sqlx.DB
+}
+
+func NewStore(log *zap.SugaredLogger, db *sqlx.DB) *Store {
+	return &Store{
+		log: log,
+		db:  db,
+	}
+}
+
+// conn is the transaction of the unit of work ctx belongs to, if any.
+func (s *Store) conn(ctx context.Context) uow.Conn {
+	return uow.ConnFrom(ctx, s.db)
+}
+
+// CreateReport adds a report to the moderation queue.
+func (s *Store) CreateReport(ctx context.Context, report entity.Report) (entity.Report, error) {
+	query := `
+		INSERT INTO reports (reporter_id, reported_id, reason, details, status, created_at, updated_at)
+		VALUES (:reporter_id, :reported_id, :reason, :details, :status, :created_at, :updated_at)
+	`
+
+	result, err := s.conn(ctx).NamedExecContext(ctx, query, report)
+	if err != nil {
+		return entity.Report{}, fmt.Errorf("failed to create report: %w", err)
+	}
+
+	id, err := result.LastInsertId()
+	if err != nil {
+		return entity.Report{}, fmt.Errorf("failed to extract id: %w", err)
+	}
+
+	report.ID = int(id)
+
+	return report, nil
+}
+
+// FindReportForUpdate returns a report, locking it until the unit of work ctx
+// belongs to ends, so two moderators cannot move it at once.
+func (s *Store) FindReportForUpdate(ctx context.Context, id int) (entity.Report, error) {
+	var report entity.Report
+	query := `
+		SELECT id, reporter_id, reported_id, reason, details, status, reviewed_by, created_at, updated_at
+		FROM reports
+		WHERE id = ?
+		FOR UPDATE
+	`
+
+	if err := s.conn(ctx).GetContext(ctx, &report, query, id); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return entity.Report{}, entity.ErrReportNotFound
+		}
+
+		return entity.Report{}, fmt.Errorf("failed to find report: %w", err)
+	}
+
+	return report, nil
+}
+
+// GetReports returns up to limit reports with the status, oldest first. When
+// afterID is set only reports after it are returned.
+func (s *Store) GetReports(ctx context.Context, status entity.ReportStatus, afterID, limit int) ([]entity.Report, error) {
+	reports := []entity.Report{}
+	query := `
+		SELECT id, reporter_id, reported_id, reason, details, status, reviewed_by, created_at, updated_at
+		FROM reports
+		WHERE status = ? AND id > ?
+		ORDER BY id
+		LIMIT ?
+	`
+
+	if err := s.conn(ctx).SelectContext(ctx, &reports, query, status, afterID, limit); err != nil {
+		return nil, fmt.Errorf("failed to find reports: %w", err)
+	}
+
+	return reports, nil
+}
+
+// UpdateReportStatus moves a report to its status, recording who moved it.
+func (s *Store) UpdateReportStatus(ctx context.Context, report entity.Report) error {
+	query := `
+		UPDATE reports
+		SET status = :status, reviewed_by = :reviewed_by, updated_at = :updated_at
+		WHERE id = :id
+	`
+
+	if _, err := s.conn(ctx).NamedExecContext(ctx, query, report); err != nil {
+		return fmt.Errorf("failed to update report: %w", err)
+	}
+
+	return nil
+}
@@ -2,11 +2,15 @@ package store
 
 import (
 	"context"
+	"database/sql"
+	"errors"
 	"fmt"
+	"time"
 
 	"github.com/jmoiron/sqlx"
 	"go.uber.org/zap"
 
+	"github.com/colmmurphy91/muzz/internal/adapter/mysql/uow"
 	"github.com/colmmurphy91/muzz/internal/entity"
 )
 
@@ -22,16 +26,21 @@ func NewStore(log *zap.SugaredLogger, db *sqlx.DB) *Store {
 	}
 }
 
-// GetUsersYesSwipes retrieves all swipes where the user swiped "YES"
+// conn is the transaction of the unit of work ctx belongs to, if any.
+func (s *Store) conn(ctx context.Context) uow.Conn {
+	return uow.ConnFrom(ctx, s.db)
+}
+
+// GetUsersYesSwipes retrieves all swipes where the user swiped "YES" or "SUPER"
 func (s *Store) GetTargetsYesSwipes(ctx context.Context, userID int) (map[int]entity.Swipe, error) {
 	swipes := []entity.Swipe{}
 	query := `
 		SELECT id, user_id, target_id, preference
 		FROM swipes
-		WHERE user_id = ? AND preference = 'YES'
+		WHERE user_id = ? AND preference IN ('YES', 'SUPER')
 	`
 
-	err := s.db.SelectContext(ctx, &swipes, query, userID)
+	err := s.conn(ctx).SelectContext(ctx, &swipes, query, userID)
 	if err != nil {
 		return nil, fmt.Errorf("failed to find target swipes: %w", err)
 	}
@@ -44,15 +53,84 @@ func (s *Store) GetTargetsYesSwipes(ctx context.Context, userID int) (map[int]en
 	return swipeMap, nil
 }
 
-// SaveSwipe saves a new swipe without overwriting existing records
+// HasLiked reports whether userID swiped yes on targetID. In a unit of work the
+// swipe, or the gap where it would be, stays locked until the transaction ends,
+// so two people swiping yes on each other at once cannot both miss the match.
+func (s *Store) HasLiked(ctx context.Context, userID, targetID int) (bool, error) {
+	var id int
+	query := `
+		SELECT id
+		FROM swipes
+		WHERE user_id = ? AND target_id = ? AND preference IN ('YES', 'SUPER')
+		FOR UPDATE
+	`
+
+	if err := s.conn(ctx).GetContext(ctx, &id, query, userID, targetID); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return false, nil
+		}
+
+		return false, fmt.Errorf("failed to find swipe: %w", err)
+	}
+
+	return true, nil
+}
+
+// FindSwipe returns userID's swipe on targetID, locking it until the unit of
+// work ends.
+func (s *Store) FindSwipe(ctx context.Context, userID, targetID int) (entity.Swipe, error) {
+	var swipe entity.Swipe
+	query := `
+		SELECT id, user_id, target_id, LOWER(preference) AS preference
+		FROM swipes
+		WHERE user_id = ? AND target_id = ?
+		FOR UPDATE
+	`
+
+	if err := s.conn(ctx).GetContext(ctx, &swipe, query, userID, targetID); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return entity.Swipe{}, entity.ErrSwipeNotFound
+		}
+
+		return entity.Swipe{}, fmt.Errorf("failed to find swipe: %w", err)
+	}
+
+	return swipe, nil
+}
+
+// LastSwipe returns the swipe userID made or changed most recently, locking it
+// until the unit of work ends.
+func (s *Store) LastSwipe(ctx context.Context, userID int) (entity.Swipe, error) {
+	var swipe entity.Swipe
+	query := `
+		SELECT id, user_id, target_id, LOWER(preference) AS preference
+		FROM swipes
+		WHERE user_id = ?
+		ORDER BY updated_at DESC, id DESC
+		LIMIT 1
+		FOR UPDATE
+	`
+
+	if err := s.conn(ctx).GetContext(ctx, &swipe, query, userID); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return entity.Swipe{}, entity.ErrSwipeNotFound
+		}
+
+		return entity.Swipe{}, fmt.Errorf("failed to find last swipe: %w", err)
+	}
+
+	return swipe, nil
+}
+
+// SaveSwipe saves a swipe, replacing the preference of an earlier swipe on the same target
 func (s *Store) SaveSwipe(ctx context.Context, swipe entity.Swipe) error {
 	query := `
 		INSERT INTO swipes (user_id, target_id, preference)
 		VALUES (:user_id, :target_id, :preference)
-		ON DUPLICATE KEY UPDATE id = id
+		ON DUPLICATE KEY UPDATE preference = VALUES(preference)
 	`
 
-	_, err := s.db.NamedExecContext(ctx, query, swipe)
+	_, err := s.conn(ctx).NamedExecContext(ctx, query, swipe)
 	if err != nil {
 		return fmt.Errorf("failed to save swipe: %w", err)
 	}
@@ -63,15 +141,187 @@ func (s *Store) SaveSwipe(ctx context.Context, swipe entity.Swipe) error {
 func (s *Store) GetUserSwipes(ctx context.Context, userID int) ([]entity.Swipe, error) {
 	swipes := []entity.Swipe{}
 	query := `
-		SELECT id, user_id, target_id, preference
+		SELECT id, user_id, target_id, LOWER(preference) AS preference
 		FROM swipes
 		WHERE user_id = ?
 	`
 
-	err := s.db.SelectContext(ctx, &swipes, query, userID)
+	err := s.conn(ctx).SelectContext(ctx, &swipes, query, userID)
 	if err != nil {
 		return nil, fmt.Errorf("failed to find target swipes: %w", err)
 	}
 
 	return swipes, nil
 }
+
+// GetSwiperIDs returns up to limit ids, greater than afterID, of users who have swiped at least once.
+func (s *Store) GetSwiperIDs(ctx context.Context, afterID, limit int) ([]int, error) {
+	ids := []int{}
+	query := `
+		SELECT DISTINCT user_id
+		FROM swipes
+		WHERE user_id > ?
+		ORDER BY user_id
+		LIMIT ?
+	`
+
+	err := s.conn(ctx).SelectContext(ctx, &ids, query, afterID, limit)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find swipers: %w", err)
+	}
+
+	return ids, nil
+}
+
+// GetReceivedSwipeCounts counts the swipes each of targetIDs has received. Users
+// nobody has swiped on are left out.
+func (s *Store) GetReceivedSwipeCounts(ctx context.Context, targetIDs []int) (map[int]entity.SwipeCounts, error) {
+	if len(targetIDs) == 0 {
+		return map[int]entity.SwipeCounts{}, nil
+	}
+
+	query, args, err := sqlx.In(`
+		SELECT target_id, SUM(preference IN ('YES', 'SUPER')) AS yes, COUNT(*) AS total
+		FROM swipes
+		WHERE target_id IN (?)
+		GROUP BY target_id
+	`, targetIDs)
+	if err != nil {
+		return nil, fmt.Errorf("failed to build query: %w", err)
+	}
+
+	counts := []entity.SwipeCounts{}
+
+	if err := s.conn(ctx).SelectContext(ctx, &counts, s.conn(ctx).Rebind(query), args...); err != nil {
+		return nil, fmt.Errorf("failed to count received swipes: %w", err)
+	}
+
+	countMap := make(map[int]entity.SwipeCounts, len(counts))
+	for _, count := range counts {
+		countMap[count.TargetID] = count
+	}
+
+	return countMap, nil
+}
+
+// GetReceivedLikes returns up to limit yes and super swipes on userID from people userID
+// has not swiped on, newest first. When beforeID is set only swipes older than
+// it are returned.
+func (s *Store) GetReceivedLikes(ctx context.Context, userID, beforeID, limit int) ([]entity.ReceivedLike, error) {
+	likes := []entity.ReceivedLike{}
+	query := `
+		SELECT s.id, s.user_id, s.preference = 'SUPER' AS super, s.created_at
+		FROM swipes s
+		LEFT JOIN swipes back ON back.user_id = s.target_id AND back.target_id = s.user_id
+		WHERE s.target_id = ? AND s.preference IN ('YES', 'SUPER') AND back.id IS NULL
+	`
+	args := []interface{}{userID}
+
+	if beforeID > 0 {
+		query += " AND s.id < ?"
+		args = append(args, beforeID)
+	}
+
+	query += " ORDER BY s.id DESC LIMIT ?"
+	args = append(args, limit)
+
+	if err := s.conn(ctx).SelectContext(ctx, &likes, query, args...); err != nil {
+		return nil, fmt.Errorf("failed to find received likes: %w", err)
+	}
+
+	return likes, nil
+}
+
+func (s *Store) DeleteSwipe(ctx context.Context, id int) error {
+	if _, err := s.conn(ctx).ExecContext(ctx, "DELETE FROM swipes WHERE id = ?", id); err != nil {
+		return fmt.Errorf("failed to delete swipe: %w", err)
+	}
+
+	return nil
+}
+
+// RecordSwipeEvent adds an entry to the user's swipe history.
+func (s *Store) RecordSwipeEvent(ctx context.Context, event entity.SwipeEvent) error {
+	query := `
+		INSERT INTO swipe_history (user_id, target_id, preference, action)
+		VALUES (:user_id, :target_id, :preference, :action)
+	`
+
+	if _, err := s.conn(ctx).NamedExecContext(ctx, query, event); err != nil {
+		return fmt.Errorf("failed to record swipe event: %w", err)
+	}
+
+	return nil
+}
+
+// CountSwipeEvents counts the user's history entries of an action since the given time.
+func (s *Store) CountSwipeEvents(ctx context.Context, userID int, action entity.SwipeAction, since time.Time) (int, error) {
+	var count int
+	query := `
+		SELECT COUNT(*)
+		FROM swipe_history
+		WHERE user_id = ? AND action = ? AND created_at >= ?
+	`
+
+	if err := s.conn(ctx).GetContext(ctx, &count, query, userID, action, since); err != nil {
+		return 0, fmt.Errorf("failed to count swipe events: %w", err)
+	}
+
+	return count, nil
+}
+
+// CountSuperLikes counts the super likes the user has made since the given
+// time, whether as a first swipe or by changing an earlier one. Rewound super
+// likes still count. In a unit of work the counted range stays locked until the
+// transaction ends.
+func (s *Store) CountSuperLikes(ctx context.Context, userID int, since time.Time) (int, error) {
+	var count int
+	query := `
+		SELECT COUNT(*)
+		FROM swipe_history
+		WHERE user_id = ? AND preference = 'SUPER' AND action IN ('SWIPE', 'RESWIPE') AND created_at >= ?
+		FOR UPDATE
+	`
+
+	if err := s.conn(ctx).GetContext(ctx, &count, query, userID, since); err != nil {
+		return 0, fmt.Errorf("failed to count super likes: %w", err)
+	}
+
+	return count, nil
+}
+
+// FindSwipeKey returns the batched swipe the user sent under key. In a unit of
+// work the key, or the gap where it would be, stays locked until the transaction
+// ends, so the same key sent twice at once is only swiped once.
+func (s *Store) FindSwipeKey(ctx context.Context, userID int, key string) (entity.SwipeKey, error) {
+	var swipeKey entity.SwipeKey
+	query := `
+		SELECT user_id, swipe_key, target_id, LOWER(preference) AS preference, match_id
+		FROM swipe_keys
+		WHERE user_id = ? AND swipe_key = ?
+		FOR UPDATE
+	`
+
+	if err := s.conn(ctx).GetContext(ctx, &swipeKey, query, userID, key); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return entity.SwipeKey{}, entity.ErrSwipeKeyNotFound
+		}
+
+		return entity.SwipeKey{}, fmt.Errorf("failed to find swipe key: %w", err)
+	}
+
+	return swipeKey, nil
+}
+
+func (s *Store) SaveSwipeKey(ctx context.Context, swipeKey entity.SwipeKey) error {
+	query := `
+		INSERT INTO swipe_keys (user_id, swipe_key, target_id, preference, match_id)
+		VALUES (:user_id, :swipe_key, :target_id, :preference, :match_id)
+	`
+
+	if _, err := s.conn(ctx).NamedExecContext(ctx, query, swipeKey); err != nil {
+		return fmt.Errorf("failed to save swipe key: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,171 @@
+package token
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/jmoiron/sqlx"
+	"go.uber.org/zap"
+
+	"github.com/colmmurphy91/muzz/internal/adapter/mysql/uow"
+	"github.com/colmmurphy91/muzz/internal/entity"
+)
+
+type Store struct {
+	log *zap.SugaredLogger
+	db  *sqlx.DB
+}
+
+func NewStore(log *zap.SugaredLogger, db *sqlx.DB) *Store {
+	return &Store{
+		log: log,
+		db:  db,
+	}
+}
+
+// conn is the transaction of the unit of work ctx belongs to, if any.
+func (s *Store) conn(ctx context.Context) uow.Conn {
+	return uow.ConnFrom(ctx, s.db)
+}
+
+func (s *Store) CreateRefreshToken(ctx context.Context, token entity.RefreshToken) error {
+	query := `
+		INSERT INTO refresh_tokens (family_id, user_id, token_hash, access_jti, access_expires_at, expires_at)
+		VALUES (:family_id, :user_id, :token_hash, :access_jti, :access_expires_at, :expires_at)
+	`
+
+	if _, err := s.conn(ctx).NamedExecContext(ctx, query, token); err != nil {
+		return fmt.Errorf("failed to create refresh token: %w", err)
+	}
+
+	return nil
+}
+
+func (s *Store) FindRefreshToken(ctx context.Context, tokenHash string) (entity.RefreshToken, error) {
+	var token entity.RefreshToken
+	query := `
+		SELECT id, family_id, user_id, token_hash, access_jti, access_expires_at, expires_at, rotated_at, revoked_at
+		FROM refresh_tokens
+		WHERE token_hash = ?
+	`
+
+	err := s.db.GetContext(ctx, &token, query, tokenHash)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return entity.RefreshToken{}, entity.ErrRefreshTokenNotFound
+		}
+
+		return entity.RefreshToken{}, fmt.Errorf("failed to find refresh token: %w", err)
+	}
+
+	return token, nil
+}
+
+// MarkRotated records that a refresh token has been exchanged. It reports false
+// when the token had already been rotated, so two concurrent refreshes with the
+// same token cannot both succeed.
+func (s *Store) MarkRotated(ctx context.Context, id int) (bool, error) {
+	query := "UPDATE refresh_tokens SET rotated_at = NOW() WHERE id = ? AND rotated_at IS NULL"
+
+	result, err := s.conn(ctx).ExecContext(ctx, query, id)
+	if err != nil {
+		return false, fmt.Errorf("failed to rotate refresh token: %w", err)
+	}
+
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return false, fmt.Errorf("failed to read affected rows: %w", err)
+	}
+
+	return affected == 1, nil
+}
+
+// RevokeFamily revokes every refresh token in a family and deny-lists the
+// access tokens issued alongside them that have not yet expired.
+func (s *Store) RevokeFamily(ctx context.Context, familyID string) error {
+	tx, err := s.db.BeginTxx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+
+	defer tx.Rollback() //nolint:errcheck
+
+	revokeAccess := `
+		INSERT INTO revoked_tokens (jti, expires_at)
+		SELECT access_jti, access_expires_at
+		FROM refresh_tokens
+		WHERE family_id = ? AND access_expires_at > NOW()
+		ON DUPLICATE KEY UPDATE jti = jti
+	`
+
+	if _, err := tx.ExecContext(ctx, revokeAccess, familyID); err != nil {
+		return fmt.Errorf("failed to revoke access tokens: %w", err)
+	}
+
+	revokeRefresh := "UPDATE refresh_tokens SET revoked_at = NOW() WHERE family_id = ? AND revoked_at IS NULL"
+
+	if _, err := tx.ExecContext(ctx, revokeRefresh, familyID); err != nil {
+		return fmt.Errorf("failed to revoke refresh tokens: %w", err)
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit: %w", err)
+	}
+
+	return nil
+}
+
+// RevokeUserTokens revokes every refresh token of a user and deny-lists the
+// access tokens issued alongside them that have not yet expired, signing them
+// out everywhere. It joins the unit of work ctx belongs to, if any.
+func (s *Store) RevokeUserTokens(ctx context.Context, userID int) error {
+	conn := s.conn(ctx)
+
+	revokeAccess := `
+		INSERT INTO revoked_tokens (jti, expires_at)
+		SELECT access_jti, access_expires_at
+		FROM refresh_tokens
+		WHERE user_id = ? AND access_expires_at > NOW()
+		ON DUPLICATE KEY UPDATE jti = jti
+	`
+
+	if _, err := conn.ExecContext(ctx, revokeAccess, userID); err != nil {
+		return fmt.Errorf("failed to revoke access tokens: %w", err)
+	}
+
+	revokeRefresh := "UPDATE refresh_tokens SET revoked_at = NOW() WHERE user_id = ? AND revoked_at IS NULL"
+
+	if _, err := conn.ExecContext(ctx, revokeRefresh, userID); err != nil {
+		return fmt.Errorf("failed to revoke refresh tokens: %w", err)
+	}
+
+	return nil
+}
+
+func (s *Store) RevokeAccessToken(ctx context.Context, jti string, expiresAt time.Time) error {
+	query := `
+		INSERT INTO revoked_tokens (jti, expires_at)
+		VALUES (?, ?)
+		ON DUPLICATE KEY UPDATE jti = jti
+	`
+
+	if _, err := s.db.ExecContext(ctx, query, jti, expiresAt); err != nil {
+		return fmt.Errorf("failed to revoke access token: %w", err)
+	}
+
+	return nil
+}
+
+func (s *Store) IsRevoked(ctx context.Context, jti string) (bool, error) {
+	var revoked bool
+	query := "SELECT EXISTS(SELECT 1 FROM revoked_tokens WHERE jti = ? AND expires_at > NOW())"
+
+	if err := s.db.GetContext(ctx, &revoked, query, jti); err != nil {
+		return false, fmt.Errorf("failed to check revoked token: %w", err)
+	}
+
+	return revoked, nil
+}
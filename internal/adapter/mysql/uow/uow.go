@@ -0,0 +1,104 @@
+package uow
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+
+	"github.com/go-sql-driver/mysql"
+	"github.com/jmoiron/sqlx"
+	"go.uber.org/zap"
+)
+
+const (
+	// mysqlDeadlock and mysqlLockWaitTimeout are returned when a transaction
+	// lost a race for a lock and was rolled back. Running it again is safe.
+	mysqlDeadlock        = 1213
+	mysqlLockWaitTimeout = 1205
+
+	maxAttempts = 3
+)
+
+// Conn is implemented by both *sqlx.DB and *sqlx.Tx, so a store can run the
+// same queries inside and outside a unit of work.
+type Conn interface {
+	sqlx.ExtContext
+	GetContext(ctx context.Context, dest interface{}, query string, args ...interface{}) error
+	SelectContext(ctx context.Context, dest interface{}, query string, args ...interface{}) error
+	NamedExecContext(ctx context.Context, query string, arg interface{}) (sql.Result, error)
+}
+
+type txKey struct{}
+
+// UnitOfWork runs several store calls in one transaction.
+type UnitOfWork struct {
+	log *zap.SugaredLogger
+	db  *sqlx.DB
+}
+
+func NewUnitOfWork(log *zap.SugaredLogger, db *sqlx.DB) *UnitOfWork {
+	return &UnitOfWork{
+		log: log,
+		db:  db,
+	}
+}
+
+// Do runs fn in a transaction that stores pick up from the context it is given.
+// The transaction commits when fn returns nil and rolls back otherwise. If it
+// is rolled back to break a deadlock fn is run again, so it must not have side
+// effects outside the database. Calling Do from within fn joins the outer
+// transaction.
+func (u *UnitOfWork) Do(ctx context.Context, fn func(ctx context.Context) error) error {
+	if _, ok := ctx.Value(txKey{}).(*sqlx.Tx); ok {
+		return fn(ctx)
+	}
+
+	var err error
+
+	for attempt := 1; attempt <= maxAttempts; attempt++ {
+		err = u.do(ctx, fn)
+		if !retryable(err) {
+			return err
+		}
+
+		u.log.Infow("retrying transaction", "attempt", attempt, "error", err)
+	}
+
+	return err
+}
+
+func (u *UnitOfWork) do(ctx context.Context, fn func(ctx context.Context) error) error {
+	tx, err := u.db.BeginTxx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+
+	defer tx.Rollback() //nolint:errcheck
+
+	if err := fn(context.WithValue(ctx, txKey{}, tx)); err != nil {
+		return err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit: %w", err)
+	}
+
+	return nil
+}
+
+func retryable(err error) bool {
+	var mysqlErr *mysql.MySQLError
+
+	return errors.As(err, &mysqlErr) && (mysqlErr.Number == mysqlDeadlock || mysqlErr.Number == mysqlLockWaitTimeout)
+}
+
+// ConnFrom returns the transaction of the unit of work ctx belongs to, or db
+// when there is none.
+func ConnFrom(ctx context.Context, db *sqlx.DB) Conn {
+	if tx, ok := ctx.Value(txKey{}).(*sqlx.Tx); ok {
+		return tx
+	}
+
+	return db
+}
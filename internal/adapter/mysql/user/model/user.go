@@ -1,12 +1,45 @@
 package model
 
+import (
+	"time"
+
+	null "github.com/guregu/null/v5"
+
+	"github.com/colmmurphy91/muzz/internal/entity"
+)
+
 type User struct {
-	ID       int     `db:"id"`
-	Email    string  `db:"email"`
-	Password string  `db:"password"`
-	Name     string  `db:"name"`
-	Gender   string  `db:"gender"`
-	Age      int     `db:"age"`
-	Lon      float64 `json:"lon"`
-	Lat      float64 `json:"lat"`
+	ID          int       `db:"id"`
+	Email       string    `db:"email"`
+	Password    string    `db:"password"`
+	Name        string    `db:"name"`
+	Bio         string    `db:"bio"`
+	Gender      string    `db:"gender"`
+	DateOfBirth time.Time `db:"date_of_birth"`
+	Age         int       `db:"age"`
+	Lon         float64   `json:"lon"`
+	Lat         float64   `json:"lat"`
+	// LocationUpdatedAt is when the user last moved to a new grid cell.
+	LocationUpdatedAt null.Time `db:"location_updated_at"`
+	// Role, Status and ShadowBanned are set by staff through the admin API.
+	Role         entity.Role       `db:"role"`
+	Status       entity.UserStatus `db:"status"`
+	ShadowBanned bool              `db:"shadow_banned"`
+	// Version is bumped by every profile edit.
+	Version int `db:"version"`
+}
+
+// Profile is what other users are allowed to see of the user.
+func (u User) Profile() entity.User {
+	return entity.User{
+		ID:     u.ID,
+		Name:   u.Name,
+		Bio:    u.Bio,
+		Gender: u.Gender,
+		Age:    u.Age,
+		Location: entity.Location{
+			Lat: u.Lat,
+			Lon: u.Lon,
+		},
+	}
 }
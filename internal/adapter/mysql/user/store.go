@@ -5,11 +5,13 @@ import (
 	"database/sql"
 	"errors"
 	"fmt"
+	"time"
 
 	"github.com/go-sql-driver/mysql"
 	"github.com/jmoiron/sqlx"
 	"go.uber.org/zap"
 
+	"github.com/colmmurphy91/muzz/internal/adapter/mysql/uow"
 	"github.com/colmmurphy91/muzz/internal/adapter/mysql/user/model"
 	"github.com/colmmurphy91/muzz/internal/entity"
 )
@@ -26,9 +28,14 @@ func NewStore(log *zap.SugaredLogger, db *sqlx.DB) *Store {
 	}
 }
 
+// conn is the transaction of the unit of work ctx belongs to, if any.
+func (s *Store) conn(ctx context.Context) uow.Conn {
+	return uow.ConnFrom(ctx, s.db)
+}
+
 func (s *Store) CreateUser(ctx context.Context, user model.User) (model.User, error) {
-	query := `INSERT INTO users(email, password, name, gender,age, lat, lon) 
-	VALUES (:email, :password, :name, :gender, :age, :lat, :lon)`
+	query := `INSERT INTO users(email, password, name, gender, date_of_birth, age, lat, lon) 
+	VALUES (:email, :password, :name, :gender, :date_of_birth, :age, :lat, :lon)`
 
 	result, err := s.db.NamedExecContext(ctx, query, user)
 	if err != nil {
@@ -46,13 +53,19 @@ func (s *Store) CreateUser(ctx context.Context, user model.User) (model.User, er
 	}
 
 	user.ID = int(id)
+	// New profiles start at the column's default version.
+	user.Version = 1
 
 	return user, nil
 }
 
 func (s *Store) FindByEmail(ctx context.Context, email string) (model.User, error) {
 	var user model.User
-	query := "SELECT id, email, password, name, gender, age, lat, lon FROM users WHERE email = ?"
+	query := `
+		SELECT id, email, password, name, bio, gender, date_of_birth, age, lat, lon, location_updated_at, role, status, shadow_banned, version
+		FROM users
+		WHERE email = ?
+	`
 
 	err := s.db.GetContext(ctx, &user, query, email)
 	if err != nil {
@@ -65,3 +78,171 @@ func (s *Store) FindByEmail(ctx context.Context, email string) (model.User, erro
 
 	return user, nil
 }
+
+func (s *Store) FindByID(ctx context.Context, userID int) (model.User, error) {
+	var user model.User
+	query := `
+		SELECT id, email, password, name, bio, gender, date_of_birth, age, lat, lon, location_updated_at, role, status, shadow_banned, version
+		FROM users
+		WHERE id = ?
+	`
+
+	err := s.conn(ctx).GetContext(ctx, &user, query, userID)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return model.User{}, entity.ErrUserNotFound
+		}
+
+		return model.User{}, fmt.Errorf("error finding user: %w", err)
+	}
+
+	return user, nil
+}
+
+// FindByIDs returns the public profiles of the given users, leaving out any
+// that do not exist or have been deleted.
+func (s *Store) FindByIDs(ctx context.Context, userIDs []int) ([]model.User, error) {
+	users := []model.User{}
+	if len(userIDs) == 0 {
+		return users, nil
+	}
+
+	query, args, err := sqlx.In(
+		"SELECT id, name, bio, gender, date_of_birth, age, lat, lon FROM users WHERE id IN (?) AND deleted_at IS NULL",
+		userIDs,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to build query: %w", err)
+	}
+
+	if err := s.db.SelectContext(ctx, &users, s.db.Rebind(query), args...); err != nil {
+		return nil, fmt.Errorf("error finding users: %w", err)
+	}
+
+	return users, nil
+}
+
+func (s *Store) UpdatePassword(ctx context.Context, userID int, passwordHash string) error {
+	query := "UPDATE users SET password = ? WHERE id = ?"
+
+	if _, err := s.db.ExecContext(ctx, query, passwordHash, userID); err != nil {
+		return fmt.Errorf("failed to update password: %w", err)
+	}
+
+	return nil
+}
+
+// UpdateProfile saves the user's profile details as long as their profile is
+// still at user.Version, bumping it. Otherwise it fails with
+// entity.ErrVersionConflict, as someone else changed the profile first. Their
+// location is left alone, as it moves without a version.
+func (s *Store) UpdateProfile(ctx context.Context, user model.User) error {
+	query := `
+		UPDATE users
+		SET name = :name, bio = :bio, gender = :gender, date_of_birth = :date_of_birth, age = :age,
+			version = version + 1
+		WHERE id = :id AND version = :version
+	`
+
+	result, err := s.conn(ctx).NamedExecContext(ctx, query, user)
+	if err != nil {
+		return fmt.Errorf("failed to update profile: %w", err)
+	}
+
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to read affected rows: %w", err)
+	}
+
+	if affected == 0 {
+		return entity.ErrVersionConflict
+	}
+
+	return nil
+}
+
+// UpdateLocation moves a user to loc, recording when they moved. It fails with
+// entity.ErrLocationThrottled if they last moved less than
+// entity.LocationUpdateInterval before at, checked in the same statement so
+// concurrent moves cannot both get through.
+func (s *Store) UpdateLocation(ctx context.Context, userID int, loc entity.Location, at time.Time) error {
+	query := `
+		UPDATE users SET lat = ?, lon = ?, location_updated_at = ?
+		WHERE id = ? AND (location_updated_at IS NULL OR location_updated_at <= ?)
+	`
+
+	lastMoveAllowed := at.Add(-entity.LocationUpdateInterval)
+
+	result, err := s.conn(ctx).ExecContext(ctx, query, loc.Lat, loc.Lon, at, userID, lastMoveAllowed)
+	if err != nil {
+		return fmt.Errorf("failed to update location: %w", err)
+	}
+
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to read affected rows: %w", err)
+	}
+
+	if affected == 0 {
+		return entity.ErrLocationThrottled
+	}
+
+	return nil
+}
+
+// UpdateStatus moves a user to status.
+func (s *Store) UpdateStatus(ctx context.Context, userID int, status entity.UserStatus) error {
+	query := "UPDATE users SET status = ? WHERE id = ?"
+
+	if _, err := s.conn(ctx).ExecContext(ctx, query, status, userID); err != nil {
+		return fmt.Errorf("failed to update status: %w", err)
+	}
+
+	return nil
+}
+
+// UpdateShadowBanned sets whether a user is hidden from everyone else's
+// discover results.
+func (s *Store) UpdateShadowBanned(ctx context.Context, userID int, shadowBanned bool) error {
+	query := "UPDATE users SET shadow_banned = ? WHERE id = ?"
+
+	if _, err := s.conn(ctx).ExecContext(ctx, query, shadowBanned, userID); err != nil {
+		return fmt.Errorf("failed to update shadow ban: %w", err)
+	}
+
+	return nil
+}
+
+// UpdateRole changes what a user is allowed to do.
+func (s *Store) UpdateRole(ctx context.Context, userID int, role entity.Role) error {
+	query := "UPDATE users SET role = ? WHERE id = ?"
+
+	if _, err := s.conn(ctx).ExecContext(ctx, query, role, userID); err != nil {
+		return fmt.Errorf("failed to update role: %w", err)
+	}
+
+	return nil
+}
+
+// FindUnhashedPasswords returns up to limit users after afterID whose password
+// is not stored as a bcrypt or argon2id hash.
+func (s *Store) FindUnhashedPasswords(ctx context.Context, afterID, limit int) ([]model.User, error) {
+	users := []model.User{}
+	query := `
+		SELECT id, password
+		FROM users
+		WHERE id > ?
+		  AND password NOT LIKE '$2a$%'
+		  AND password NOT LIKE '$2b$%'
+		  AND password NOT LIKE '$2y$%'
+		  AND password NOT LIKE '$argon2id$%'
+		ORDER BY id
+		LIMIT ?
+	`
+
+	if err := s.db.SelectContext(ctx, &users, query, afterID, limit); err != nil {
+		return nil, fmt.Errorf("failed to find unhashed passwords: %w", err)
+	}
+
+	return users, nil
+}
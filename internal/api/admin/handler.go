@@ -0,0 +1,243 @@
+package admin
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"strconv"
+
+	chi "github.com/go-chi/chi/v5"
+	"go.uber.org/zap"
+
+	"github.com/colmmurphy91/muzz/internal/api/admin/model"
+	"github.com/colmmurphy91/muzz/internal/api/response"
+	"github.com/colmmurphy91/muzz/internal/entity"
+	"github.com/colmmurphy91/muzz/internal/pkg"
+	"github.com/colmmurphy91/muzz/internal/usecase/admin"
+)
+
+type Handler struct {
+	logger       *zap.SugaredLogger
+	adminService *admin.Service
+}
+
+func NewHandler(logger *zap.SugaredLogger, adminService *admin.Service) *Handler {
+	return &Handler{logger: logger, adminService: adminService}
+}
+
+// Register adds the admin routes. They are open to moderators and admins, so
+// the router must only let staff through; banning and changing roles are kept
+// to admins here.
+func (h *Handler) Register(r chi.Router) {
+	adminOnly := r.With(pkg.RequireRole(string(entity.RoleAdmin)))
+
+	r.Get("/reports", h.reports)
+	r.Get("/users/{userID}", h.user)
+	r.Post("/reports/{reportID}/status", h.review)
+	r.Post("/users/{userID}/suspend", h.userAction(h.adminService.Suspend))
+	r.Post("/users/{userID}/reinstate", h.userAction(h.adminService.Reinstate))
+	r.Put("/users/{userID}/shadow-ban", h.shadowBan)
+	r.Post("/matches/{matchID}/unmatch", h.forceUnmatch)
+	adminOnly.Post("/users/{userID}/ban", h.userAction(h.adminService.Ban))
+	adminOnly.Put("/users/{userID}/role", h.setRole)
+}
+
+func (h *Handler) reports(w http.ResponseWriter, r *http.Request) {
+	var (
+		limit int
+		after *entity.ReportsCursor
+	)
+
+	if limitParam := r.URL.Query().Get("limit"); limitParam != "" {
+		parsed, err := strconv.Atoi(limitParam)
+		if err != nil || parsed < 0 || parsed > entity.MaxReportsLimit {
+			response.RenderErrorResponse(w, "invalid param", entity.ErrInvalidParam)
+			return
+		}
+
+		limit = parsed
+	}
+
+	if cursorParam := r.URL.Query().Get("cursor"); cursorParam != "" {
+		cursor, err := model.DecodeCursor(cursorParam)
+		if err != nil {
+			response.RenderErrorResponse(w, "invalid param", err)
+			return
+		}
+
+		after = &cursor
+	}
+
+	status := entity.ReportStatus(r.URL.Query().Get("status"))
+
+	page, err := h.adminService.Reports(r.Context(), status, limit, after)
+	if err != nil {
+		response.RenderErrorResponse(w, "failed to get reports", err)
+		return
+	}
+
+	response.RenderResponse(w, model.NewReportsResponse(page), http.StatusOK)
+}
+
+func (h *Handler) user(w http.ResponseWriter, r *http.Request) {
+	userID, err := strconv.Atoi(chi.URLParam(r, "userID"))
+	if err != nil {
+		response.RenderErrorResponse(w, "invalid param", entity.ErrInvalidParam)
+		return
+	}
+
+	user, err := h.adminService.User(r.Context(), userID)
+	if err != nil {
+		response.RenderErrorResponse(w, "failed to get user", err)
+		return
+	}
+
+	response.RenderResponse(w, model.NewUserResponse(user), http.StatusOK)
+}
+
+func (h *Handler) review(w http.ResponseWriter, r *http.Request) {
+	actorID, ok := r.Context().Value(pkg.CTXUserKey).(int)
+	if !ok {
+		response.RenderErrorResponse(w, "forbidden", entity.ErrForbidden)
+		return
+	}
+
+	reportID, err := strconv.Atoi(chi.URLParam(r, "reportID"))
+	if err != nil {
+		response.RenderErrorResponse(w, "invalid param", entity.ErrInvalidParam)
+		return
+	}
+
+	var req model.ReviewRequest
+
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		response.RenderErrorResponse(w, "invalid body", entity.ErrInvalidParam)
+		return
+	}
+
+	report, err := h.adminService.ReviewReport(r.Context(), actorID, reportID, req.Status, req.Reason)
+	if err != nil {
+		response.RenderErrorResponse(w, "failed to review report", err)
+		return
+	}
+
+	response.RenderResponse(w, report, http.StatusOK)
+}
+
+// userAction handles an action against the user in the path, whose body only
+// carries an optional reason.
+func (h *Handler) userAction(action func(ctx context.Context, actorID, userID int, reason string) error) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		actorID, ok := r.Context().Value(pkg.CTXUserKey).(int)
+		if !ok {
+			response.RenderErrorResponse(w, "forbidden", entity.ErrForbidden)
+			return
+		}
+
+		userID, err := strconv.Atoi(chi.URLParam(r, "userID"))
+		if err != nil {
+			response.RenderErrorResponse(w, "invalid param", entity.ErrInvalidParam)
+			return
+		}
+
+		var req model.ActionRequest
+
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil && !errors.Is(err, io.EOF) {
+			response.RenderErrorResponse(w, "invalid body", entity.ErrInvalidParam)
+			return
+		}
+
+		if err := action(r.Context(), actorID, userID, req.Reason); err != nil {
+			response.RenderErrorResponse(w, "failed to update user", err)
+			return
+		}
+
+		w.WriteHeader(http.StatusNoContent)
+	}
+}
+
+func (h *Handler) shadowBan(w http.ResponseWriter, r *http.Request) {
+	actorID, ok := r.Context().Value(pkg.CTXUserKey).(int)
+	if !ok {
+		response.RenderErrorResponse(w, "forbidden", entity.ErrForbidden)
+		return
+	}
+
+	userID, err := strconv.Atoi(chi.URLParam(r, "userID"))
+	if err != nil {
+		response.RenderErrorResponse(w, "invalid param", entity.ErrInvalidParam)
+		return
+	}
+
+	var req model.ShadowBanRequest
+
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		response.RenderErrorResponse(w, "invalid body", entity.ErrInvalidParam)
+		return
+	}
+
+	if err := h.adminService.ShadowBan(r.Context(), actorID, userID, req.ShadowBanned, req.Reason); err != nil {
+		response.RenderErrorResponse(w, "failed to shadow ban", err)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (h *Handler) forceUnmatch(w http.ResponseWriter, r *http.Request) {
+	actorID, ok := r.Context().Value(pkg.CTXUserKey).(int)
+	if !ok {
+		response.RenderErrorResponse(w, "forbidden", entity.ErrForbidden)
+		return
+	}
+
+	matchID, err := strconv.Atoi(chi.URLParam(r, "matchID"))
+	if err != nil {
+		response.RenderErrorResponse(w, "invalid param", entity.ErrInvalidParam)
+		return
+	}
+
+	var req model.ActionRequest
+
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil && !errors.Is(err, io.EOF) {
+		response.RenderErrorResponse(w, "invalid body", entity.ErrInvalidParam)
+		return
+	}
+
+	if err := h.adminService.ForceUnmatch(r.Context(), actorID, matchID, req.Reason); err != nil {
+		response.RenderErrorResponse(w, "failed to unmatch", err)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (h *Handler) setRole(w http.ResponseWriter, r *http.Request) {
+	actorID, ok := r.Context().Value(pkg.CTXUserKey).(int)
+	if !ok {
+		response.RenderErrorResponse(w, "forbidden", entity.ErrForbidden)
+		return
+	}
+
+	userID, err := strconv.Atoi(chi.URLParam(r, "userID"))
+	if err != nil {
+		response.RenderErrorResponse(w, "invalid param", entity.ErrInvalidParam)
+		return
+	}
+
+	var req model.RoleRequest
+
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		response.RenderErrorResponse(w, "invalid body", entity.ErrInvalidParam)
+		return
+	}
+
+	if err := h.adminService.SetRole(r.Context(), actorID, userID, req.Role, req.Reason); err != nil {
+		response.RenderErrorResponse(w, "failed to set role", err)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
@@ -0,0 +1,71 @@
+package model
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+
+	"github.com/colmmurphy91/muzz/internal/entity"
+)
+
+// ActionRequest is the body of an action against a user or match. The reason
+// is kept in the audit log.
+type ActionRequest struct {
+	Reason string `json:"reason"`
+}
+
+type ReviewRequest struct {
+	Status entity.ReportStatus `json:"status"`
+	Reason string              `json:"reason"`
+}
+
+type ShadowBanRequest struct {
+	ShadowBanned bool   `json:"shadow_banned"`
+	Reason       string `json:"reason"`
+}
+
+type RoleRequest struct {
+	Role   entity.Role `json:"role"`
+	Reason string      `json:"reason"`
+}
+
+type ReportsResponse struct {
+	Results    []entity.Report `json:"results"`
+	NextCursor string          `json:"next_cursor,omitempty"`
+}
+
+func NewReportsResponse(page entity.ReportsPage) ReportsResponse {
+	resp := ReportsResponse{Results: page.Reports}
+
+	if resp.Results == nil {
+		resp.Results = []entity.Report{}
+	}
+
+	if page.Next != nil {
+		resp.NextCursor = EncodeCursor(*page.Next)
+	}
+
+	return resp
+}
+
+// EncodeCursor turns a cursor into the opaque string handed to clients.
+func EncodeCursor(cursor entity.ReportsCursor) string {
+	content, _ := json.Marshal(cursor) //nolint:errchkjson
+
+	return base64.RawURLEncoding.EncodeToString(content)
+}
+
+func DecodeCursor(value string) (entity.ReportsCursor, error) {
+	var cursor entity.ReportsCursor
+
+	content, err := base64.RawURLEncoding.DecodeString(value)
+	if err != nil {
+		return entity.ReportsCursor{}, fmt.Errorf("invalid cursor: %w", entity.ErrInvalidParam)
+	}
+
+	if err := json.Unmarshal(content, &cursor); err != nil || cursor.ID <= 0 {
+		return entity.ReportsCursor{}, fmt.Errorf("invalid cursor: %w", entity.ErrInvalidParam)
+	}
+
+	return cursor, nil
+}
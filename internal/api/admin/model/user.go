@@ -0,0 +1,46 @@
+package model
+
+import (
+	"time"
+
+	"github.com/colmmurphy91/muzz/internal/adapter/mysql/user/model"
+	"github.com/colmmurphy91/muzz/internal/entity"
+)
+
+const dateOfBirthLayout = "2006-01-02"
+
+// UserResponse is a user as staff see them: their profile, how to reach them
+// and their moderation state, but never their password.
+type UserResponse struct {
+	ID                int               `json:"id"`
+	Email             string            `json:"email"`
+	Name              string            `json:"name"`
+	Bio               string            `json:"bio"`
+	Gender            string            `json:"gender"`
+	DateOfBirth       string            `json:"date_of_birth"`
+	Age               int               `json:"age"`
+	Location          entity.Location   `json:"location"`
+	LocationUpdatedAt *time.Time        `json:"location_updated_at,omitempty"`
+	Role              entity.Role       `json:"role"`
+	Status            entity.UserStatus `json:"status"`
+	ShadowBanned      bool              `json:"shadow_banned"`
+	Version           int               `json:"version"`
+}
+
+func NewUserResponse(user model.User) UserResponse {
+	return UserResponse{
+		ID:                user.ID,
+		Email:             user.Email,
+		Name:              user.Name,
+		Bio:               user.Bio,
+		Gender:            user.Gender,
+		DateOfBirth:       user.DateOfBirth.Format(dateOfBirthLayout),
+		Age:               user.Age,
+		Location:          entity.Location{Lat: user.Lat, Lon: user.Lon},
+		LocationUpdatedAt: user.LocationUpdatedAt.Ptr(),
+		Role:              user.Role,
+		Status:            user.Status,
+		ShadowBanned:      user.ShadowBanned,
+		Version:           user.Version,
+	}
+}
@@ -0,0 +1,48 @@
+package block
+
+import (
+	"net/http"
+	"strconv"
+
+	chi "github.com/go-chi/chi/v5"
+	"go.uber.org/zap"
+
+	"github.com/colmmurphy91/muzz/internal/api/response"
+	"github.com/colmmurphy91/muzz/internal/entity"
+	"github.com/colmmurphy91/muzz/internal/pkg"
+	"github.com/colmmurphy91/muzz/internal/usecase/block"
+)
+
+type Handler struct {
+	logger       *zap.SugaredLogger
+	blockService *block.Service
+}
+
+func NewHandler(logger *zap.SugaredLogger, blockService *block.Service) *Handler {
+	return &Handler{logger: logger, blockService: blockService}
+}
+
+func (h *Handler) Register(r chi.Router) {
+	r.Post("/users/{userID}/block", h.block)
+}
+
+func (h *Handler) block(w http.ResponseWriter, r *http.Request) {
+	userID, ok := r.Context().Value(pkg.CTXUserKey).(int)
+	if !ok {
+		response.RenderErrorResponse(w, "forbidden", entity.ErrForbidden)
+		return
+	}
+
+	targetID, err := strconv.Atoi(chi.URLParam(r, "userID"))
+	if err != nil {
+		response.RenderErrorResponse(w, "invalid param", entity.ErrInvalidParam)
+		return
+	}
+
+	if err := h.blockService.Block(r.Context(), userID, targetID); err != nil {
+		response.RenderErrorResponse(w, "failed to block", err)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
@@ -1,7 +1,9 @@
 package discover
 
 import (
+	"crypto/cipher"
 	"github.com/colmmurphy91/muzz/internal/pkg"
+	"math"
 	"net/http"
 	"strconv"
 
@@ -9,6 +11,7 @@ import (
 	null "github.com/guregu/null/v5"
 	"go.uber.org/zap"
 
+	"github.com/colmmurphy91/muzz/internal/api/discover/model"
 	"github.com/colmmurphy91/muzz/internal/api/response"
 	"github.com/colmmurphy91/muzz/internal/entity"
 	"github.com/colmmurphy91/muzz/internal/usecase/discover"
@@ -17,10 +20,12 @@ import (
 type Handler struct {
 	logger          *zap.SugaredLogger
 	discoverService *discover.Service
+	cursors         cipher.AEAD
 }
 
-func NewHandler(logger *zap.SugaredLogger, discoverService *discover.Service) *Handler {
-	return &Handler{logger: logger, discoverService: discoverService}
+// NewHandler serves discover, sealing the cursors it hands out with cursors.
+func NewHandler(logger *zap.SugaredLogger, discoverService *discover.Service, cursors cipher.AEAD) *Handler {
+	return &Handler{logger: logger, discoverService: discoverService, cursors: cursors}
 }
 
 func (h *Handler) Register(r chi.Router) {
@@ -53,6 +58,13 @@ func (h *Handler) discover(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	// Written so NaN fails too.
+	if !(lat >= -90 && lat <= 90) || !(lon >= -180 && lon <= 180) {
+		response.RenderErrorResponse(w, "invalid param", entity.ErrInvalidParam)
+
+		return
+	}
+
 	if minAgeParam := r.URL.Query().Get("min_age"); minAgeParam != "" {
 		minAge, err := strconv.Atoi(minAgeParam)
 		if err != nil {
@@ -75,6 +87,39 @@ func (h *Handler) discover(w http.ResponseWriter, r *http.Request) {
 		params.MaxAge = null.IntFrom(int64(maxAge))
 	}
 
+	if maxDistanceParam := r.URL.Query().Get("max_distance_km"); maxDistanceParam != "" {
+		maxDistance, err := strconv.ParseFloat(maxDistanceParam, 64)
+		if err != nil || math.IsNaN(maxDistance) || math.IsInf(maxDistance, 0) {
+			response.RenderErrorResponse(w, "invalid param", entity.ErrInvalidParam)
+
+			return
+		}
+
+		params.MaxDistanceKm = null.FloatFrom(maxDistance)
+	}
+
+	if limitParam := r.URL.Query().Get("limit"); limitParam != "" {
+		limit, err := strconv.Atoi(limitParam)
+		if err != nil {
+			response.RenderErrorResponse(w, "invalid param", entity.ErrInvalidParam)
+
+			return
+		}
+
+		params.Limit = limit
+	}
+
+	if cursorParam := r.URL.Query().Get("cursor"); cursorParam != "" {
+		cursor, err := model.DecodeCursor(h.cursors, cursorParam)
+		if err != nil {
+			response.RenderErrorResponse(w, "invalid param", err)
+
+			return
+		}
+
+		params.After = &cursor
+	}
+
 	if genderParam := r.URL.Query().Get("gender"); genderParam != "" {
 		params.Gender = null.StringFrom(genderParam)
 	}
@@ -87,11 +132,11 @@ func (h *Handler) discover(w http.ResponseWriter, r *http.Request) {
 	params.Lat = lat
 	params.Lon = lon
 
-	people, err := h.discoverService.DiscoverPeople(r.Context(), userID, params)
+	page, err := h.discoverService.DiscoverPeople(r.Context(), userID, params)
 	if err != nil {
 		response.RenderErrorResponse(w, "failed to discover", err)
 		return
 	}
 
-	response.RenderResponse(w, people, http.StatusOK)
+	response.RenderResponse(w, model.NewDiscoverResponse(page, h.cursors), http.StatusOK)
 }
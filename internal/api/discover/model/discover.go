@@ -0,0 +1,73 @@
+package model
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+
+	"github.com/colmmurphy91/muzz/internal/api/model"
+	"github.com/colmmurphy91/muzz/internal/entity"
+)
+
+type DiscoverResponse struct {
+	Results    []model.UserCard `json:"results"`
+	NextCursor string           `json:"next_cursor,omitempty"`
+	Ranking    string           `json:"ranking"`
+}
+
+func NewDiscoverResponse(page entity.DiscoverPage, cursors cipher.AEAD) DiscoverResponse {
+	resp := DiscoverResponse{Results: model.NewUserCards(page.Users), Ranking: page.Strategy}
+
+	if page.Next != nil {
+		resp.NextCursor = EncodeCursor(cursors, *page.Next)
+	}
+
+	return resp
+}
+
+// NewCursorCipher returns the cipher discover cursors are sealed with, for a
+// 16, 24 or 32 byte AES key.
+func NewCursorCipher(key []byte) (cipher.AEAD, error) {
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create cipher: %w", err)
+	}
+
+	return cipher.NewGCM(block)
+}
+
+// EncodeCursor turns a cursor into the opaque string handed to clients. It
+// holds exact distances, so it is sealed rather than only encoded.
+func EncodeCursor(cursors cipher.AEAD, cursor entity.DiscoverCursor) string {
+	content, _ := json.Marshal(cursor) //nolint:errchkjson
+
+	nonce := make([]byte, cursors.NonceSize())
+	rand.Read(nonce) //nolint:errcheck
+
+	return base64.RawURLEncoding.EncodeToString(cursors.Seal(nonce, nonce, content, nil))
+}
+
+func DecodeCursor(cursors cipher.AEAD, value string) (entity.DiscoverCursor, error) {
+	var cursor entity.DiscoverCursor
+
+	sealed, err := base64.RawURLEncoding.DecodeString(value)
+	if err != nil || len(sealed) < cursors.NonceSize() {
+		return entity.DiscoverCursor{}, fmt.Errorf("invalid cursor: %w", entity.ErrInvalidParam)
+	}
+
+	nonce, sealed := sealed[:cursors.NonceSize()], sealed[cursors.NonceSize():]
+
+	content, err := cursors.Open(nil, nonce, sealed, nil)
+	if err != nil {
+		return entity.DiscoverCursor{}, fmt.Errorf("invalid cursor: %w", entity.ErrInvalidParam)
+	}
+
+	if err := json.Unmarshal(content, &cursor); err != nil || cursor.PIT == "" || len(cursor.Sort) == 0 {
+		return entity.DiscoverCursor{}, fmt.Errorf("invalid cursor: %w", entity.ErrInvalidParam)
+	}
+
+	return cursor, nil
+}
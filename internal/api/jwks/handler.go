@@ -0,0 +1,31 @@
+package jwks
+
+import (
+	"net/http"
+
+	chi "github.com/go-chi/chi/v5"
+	"go.uber.org/zap"
+
+	"github.com/colmmurphy91/muzz/internal/api/response"
+	"github.com/colmmurphy91/muzz/internal/pkg/signing"
+)
+
+type Handler struct {
+	logger *zap.SugaredLogger
+	keys   *signing.KeySet
+}
+
+func NewHandler(logger *zap.SugaredLogger, keys *signing.KeySet) *Handler {
+	return &Handler{logger: logger, keys: keys}
+}
+
+func (h *Handler) Register(r chi.Router) {
+	r.Get("/.well-known/jwks.json", h.jwks)
+}
+
+func (h *Handler) jwks(w http.ResponseWriter, _ *http.Request) {
+	// Short enough that verifiers pick up a rotated key well within an access token's lifetime.
+	w.Header().Set("Cache-Control", "public, max-age=300")
+
+	response.RenderResponse(w, h.keys.JWKS(), http.StatusOK)
+}
@@ -0,0 +1,69 @@
+package like
+
+import (
+	"net/http"
+	"strconv"
+
+	chi "github.com/go-chi/chi/v5"
+	"go.uber.org/zap"
+
+	"github.com/colmmurphy91/muzz/internal/api/like/model"
+	"github.com/colmmurphy91/muzz/internal/api/response"
+	"github.com/colmmurphy91/muzz/internal/entity"
+	"github.com/colmmurphy91/muzz/internal/pkg"
+	"github.com/colmmurphy91/muzz/internal/usecase/like"
+)
+
+type Handler struct {
+	logger      *zap.SugaredLogger
+	likeService *like.Service
+}
+
+func NewHandler(logger *zap.SugaredLogger, likeService *like.Service) *Handler {
+	return &Handler{logger: logger, likeService: likeService}
+}
+
+func (h *Handler) Register(r chi.Router) {
+	r.Get("/likes/received", h.receivedLikes)
+}
+
+func (h *Handler) receivedLikes(w http.ResponseWriter, r *http.Request) {
+	userID, ok := r.Context().Value(pkg.CTXUserKey).(int)
+	if !ok {
+		response.RenderErrorResponse(w, "forbidden", entity.ErrForbidden)
+		return
+	}
+
+	var (
+		limit int
+		after *entity.LikesCursor
+	)
+
+	if limitParam := r.URL.Query().Get("limit"); limitParam != "" {
+		parsed, err := strconv.Atoi(limitParam)
+		if err != nil || parsed < 0 || parsed > entity.MaxLikesLimit {
+			response.RenderErrorResponse(w, "invalid param", entity.ErrInvalidParam)
+			return
+		}
+
+		limit = parsed
+	}
+
+	if cursorParam := r.URL.Query().Get("cursor"); cursorParam != "" {
+		cursor, err := model.DecodeCursor(cursorParam)
+		if err != nil {
+			response.RenderErrorResponse(w, "invalid param", err)
+			return
+		}
+
+		after = &cursor
+	}
+
+	page, err := h.likeService.ReceivedLikes(r.Context(), userID, limit, after)
+	if err != nil {
+		response.RenderErrorResponse(w, "failed to get likes", err)
+		return
+	}
+
+	response.RenderResponse(w, model.NewLikesResponse(page), http.StatusOK)
+}
@@ -0,0 +1,63 @@
+package model
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/colmmurphy91/muzz/internal/api/model"
+	"github.com/colmmurphy91/muzz/internal/entity"
+)
+
+// LikeResponse is someone who swiped yes on the user.
+type LikeResponse struct {
+	User    model.UserCard `json:"user"`
+	Super   bool           `json:"super"`
+	LikedAt time.Time      `json:"liked_at"`
+}
+
+type LikesResponse struct {
+	Results    []LikeResponse `json:"results"`
+	NextCursor string         `json:"next_cursor,omitempty"`
+}
+
+func NewLikesResponse(page entity.LikesPage) LikesResponse {
+	resp := LikesResponse{Results: make([]LikeResponse, 0, len(page.Likes))}
+
+	for _, like := range page.Likes {
+		resp.Results = append(resp.Results, LikeResponse{
+			User:    model.NewUserCard(like.User),
+			Super:   like.Super,
+			LikedAt: like.LikedAt,
+		})
+	}
+
+	if page.Next != nil {
+		resp.NextCursor = EncodeCursor(*page.Next)
+	}
+
+	return resp
+}
+
+// EncodeCursor turns a cursor into the opaque string handed to clients.
+func EncodeCursor(cursor entity.LikesCursor) string {
+	content, _ := json.Marshal(cursor) //nolint:errchkjson
+
+	return base64.RawURLEncoding.EncodeToString(content)
+}
+
+func DecodeCursor(value string) (entity.LikesCursor, error) {
+	var cursor entity.LikesCursor
+
+	content, err := base64.RawURLEncoding.DecodeString(value)
+	if err != nil {
+		return entity.LikesCursor{}, fmt.Errorf("invalid cursor: %w", entity.ErrInvalidParam)
+	}
+
+	if err := json.Unmarshal(content, &cursor); err != nil || cursor.SwipeID <= 0 {
+		return entity.LikesCursor{}, fmt.Errorf("invalid cursor: %w", entity.ErrInvalidParam)
+	}
+
+	return cursor, nil
+}
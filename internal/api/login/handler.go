@@ -9,6 +9,8 @@ import (
 
 	"github.com/colmmurphy91/muzz/internal/api/login/model"
 	"github.com/colmmurphy91/muzz/internal/api/response"
+	"github.com/colmmurphy91/muzz/internal/entity"
+	"github.com/colmmurphy91/muzz/internal/pkg"
 	"github.com/colmmurphy91/muzz/internal/usecase/auth"
 )
 
@@ -23,6 +25,12 @@ func NewHandler(logger *zap.SugaredLogger, auth *auth.Service) *Handler {
 
 func (h *Handler) Register(r chi.Router) {
 	r.Post("/login", h.login)
+	r.Post("/token/refresh", h.refresh)
+}
+
+// RegisterAuthenticated registers the routes that require a valid access token.
+func (h *Handler) RegisterAuthenticated(r chi.Router) {
+	r.Post("/logout", h.logout)
 }
 
 func (h *Handler) login(w http.ResponseWriter, r *http.Request) {
@@ -40,13 +48,69 @@ func (h *Handler) login(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	token, err := h.authService.Authenticate(r.Context(), loginRequest.Email, loginRequest.Password)
+	tokens, err := h.authService.Authenticate(r.Context(), loginRequest.Email, loginRequest.Password)
 	if err != nil {
 		response.RenderErrorResponse(w, "failed to authenticate", err)
 		return
 	}
 
-	response.RenderResponse(w, model.TokenResponse{
-		Token: token,
-	}, http.StatusOK)
+	response.RenderResponse(w, toTokenResponse(tokens), http.StatusOK)
+}
+
+func (h *Handler) refresh(w http.ResponseWriter, r *http.Request) {
+	var refreshRequest model.RefreshRequest
+
+	if err := json.NewDecoder(r.Body).Decode(&refreshRequest); err != nil {
+		response.RenderErrorResponse(w, "Invalid request payload", err)
+		return
+	}
+
+	if err := refreshRequest.Validate(); err != nil {
+		response.RenderErrorResponse(w, "Validation failed", err)
+		return
+	}
+
+	tokens, err := h.authService.Refresh(r.Context(), refreshRequest.RefreshToken)
+	if err != nil {
+		response.RenderErrorResponse(w, "failed to refresh token", err)
+		return
+	}
+
+	response.RenderResponse(w, toTokenResponse(tokens), http.StatusOK)
+}
+
+func (h *Handler) logout(w http.ResponseWriter, r *http.Request) {
+	var refreshRequest model.RefreshRequest
+
+	if err := json.NewDecoder(r.Body).Decode(&refreshRequest); err != nil {
+		response.RenderErrorResponse(w, "Invalid request payload", err)
+		return
+	}
+
+	if err := refreshRequest.Validate(); err != nil {
+		response.RenderErrorResponse(w, "Validation failed", err)
+		return
+	}
+
+	claims, ok := r.Context().Value(pkg.CTXClaimsKey).(*pkg.CustomClaims)
+	if !ok {
+		response.RenderErrorResponse(w, "forbidden", entity.ErrForbidden)
+		return
+	}
+
+	err := h.authService.Logout(r.Context(), claims.UserID, refreshRequest.RefreshToken, claims.ID, claims.ExpiresAt.Time)
+	if err != nil {
+		response.RenderErrorResponse(w, "failed to logout", err)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func toTokenResponse(tokens entity.TokenPair) model.TokenResponse {
+	return model.TokenResponse{
+		Token:        tokens.AccessToken,
+		RefreshToken: tokens.RefreshToken,
+		ExpiresIn:    int(tokens.ExpiresIn.Seconds()),
+	}
 }
@@ -18,6 +18,19 @@ func (lr *LoginRequest) Validate() error {
 	)
 }
 
+type RefreshRequest struct {
+	RefreshToken string `json:"refresh_token"`
+}
+
+func (rr *RefreshRequest) Validate() error {
+	return validation.ValidateStruct(
+		rr,
+		validation.Field(&rr.RefreshToken, validation.Required),
+	)
+}
+
 type TokenResponse struct {
-	Token string `json:"token"`
+	Token        string `json:"token"`
+	RefreshToken string `json:"refresh_token"`
+	ExpiresIn    int    `json:"expires_in"`
 }
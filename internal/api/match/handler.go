@@ -0,0 +1,91 @@
+package match
+
+import (
+	"net/http"
+	"strconv"
+
+	chi "github.com/go-chi/chi/v5"
+	"go.uber.org/zap"
+
+	"github.com/colmmurphy91/muzz/internal/api/match/model"
+	"github.com/colmmurphy91/muzz/internal/api/response"
+	"github.com/colmmurphy91/muzz/internal/entity"
+	"github.com/colmmurphy91/muzz/internal/pkg"
+	"github.com/colmmurphy91/muzz/internal/usecase/match"
+)
+
+type Handler struct {
+	logger       *zap.SugaredLogger
+	matchService *match.Service
+}
+
+func NewHandler(logger *zap.SugaredLogger, matchService *match.Service) *Handler {
+	return &Handler{logger: logger, matchService: matchService}
+}
+
+func (h *Handler) Register(r chi.Router) {
+	r.Get("/matches", h.getMatches)
+	r.Delete("/matches/{matchID}", h.unmatch)
+}
+
+func (h *Handler) getMatches(w http.ResponseWriter, r *http.Request) {
+	userID, ok := r.Context().Value(pkg.CTXUserKey).(int)
+	if !ok {
+		response.RenderErrorResponse(w, "forbidden", entity.ErrForbidden)
+		return
+	}
+
+	var (
+		limit int
+		after *entity.MatchesCursor
+	)
+
+	if limitParam := r.URL.Query().Get("limit"); limitParam != "" {
+		parsed, err := strconv.Atoi(limitParam)
+		if err != nil || parsed < 0 || parsed > entity.MaxMatchesLimit {
+			response.RenderErrorResponse(w, "invalid param", entity.ErrInvalidParam)
+			return
+		}
+
+		limit = parsed
+	}
+
+	if cursorParam := r.URL.Query().Get("cursor"); cursorParam != "" {
+		cursor, err := model.DecodeCursor(cursorParam)
+		if err != nil {
+			response.RenderErrorResponse(w, "invalid param", err)
+			return
+		}
+
+		after = &cursor
+	}
+
+	page, err := h.matchService.GetMatches(r.Context(), userID, limit, after)
+	if err != nil {
+		response.RenderErrorResponse(w, "failed to get matches", err)
+		return
+	}
+
+	response.RenderResponse(w, model.NewMatchesResponse(page), http.StatusOK)
+}
+
+func (h *Handler) unmatch(w http.ResponseWriter, r *http.Request) {
+	userID, ok := r.Context().Value(pkg.CTXUserKey).(int)
+	if !ok {
+		response.RenderErrorResponse(w, "forbidden", entity.ErrForbidden)
+		return
+	}
+
+	matchID, err := strconv.Atoi(chi.URLParam(r, "matchID"))
+	if err != nil {
+		response.RenderErrorResponse(w, "invalid param", entity.ErrInvalidParam)
+		return
+	}
+
+	if err := h.matchService.Unmatch(r.Context(), userID, matchID); err != nil {
+		response.RenderErrorResponse(w, "failed to unmatch", err)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
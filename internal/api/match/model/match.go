@@ -0,0 +1,63 @@
+package model
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/colmmurphy91/muzz/internal/api/model"
+	"github.com/colmmurphy91/muzz/internal/entity"
+)
+
+// MatchResponse is a match, with the other person's card.
+type MatchResponse struct {
+	ID        int            `json:"id"`
+	User      model.UserCard `json:"user"`
+	MatchedAt time.Time      `json:"matched_at"`
+}
+
+type MatchesResponse struct {
+	Results    []MatchResponse `json:"results"`
+	NextCursor string          `json:"next_cursor,omitempty"`
+}
+
+func NewMatchesResponse(page entity.MatchesPage) MatchesResponse {
+	resp := MatchesResponse{Results: make([]MatchResponse, 0, len(page.Matches))}
+
+	for _, match := range page.Matches {
+		resp.Results = append(resp.Results, MatchResponse{
+			ID:        match.ID,
+			User:      model.NewUserCard(match.User),
+			MatchedAt: match.MatchedAt,
+		})
+	}
+
+	if page.Next != nil {
+		resp.NextCursor = EncodeCursor(*page.Next)
+	}
+
+	return resp
+}
+
+// EncodeCursor turns a cursor into the opaque string handed to clients.
+func EncodeCursor(cursor entity.MatchesCursor) string {
+	content, _ := json.Marshal(cursor) //nolint:errchkjson
+
+	return base64.RawURLEncoding.EncodeToString(content)
+}
+
+func DecodeCursor(value string) (entity.MatchesCursor, error) {
+	var cursor entity.MatchesCursor
+
+	content, err := base64.RawURLEncoding.DecodeString(value)
+	if err != nil {
+		return entity.MatchesCursor{}, fmt.Errorf("invalid cursor: %w", entity.ErrInvalidParam)
+	}
+
+	if err := json.Unmarshal(content, &cursor); err != nil || cursor.ID <= 0 {
+		return entity.MatchesCursor{}, fmt.Errorf("invalid cursor: %w", entity.ErrInvalidParam)
+	}
+
+	return cursor, nil
+}
@@ -0,0 +1,155 @@
+package message
+
+import (
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"strconv"
+
+	chi "github.com/go-chi/chi/v5"
+	"go.uber.org/zap"
+
+	"github.com/colmmurphy91/muzz/internal/api/message/model"
+	"github.com/colmmurphy91/muzz/internal/api/response"
+	"github.com/colmmurphy91/muzz/internal/entity"
+	"github.com/colmmurphy91/muzz/internal/pkg"
+	"github.com/colmmurphy91/muzz/internal/usecase/message"
+)
+
+type Handler struct {
+	logger         *zap.SugaredLogger
+	messageService *message.Service
+}
+
+func NewHandler(logger *zap.SugaredLogger, messageService *message.Service) *Handler {
+	return &Handler{logger: logger, messageService: messageService}
+}
+
+func (h *Handler) Register(r chi.Router) {
+	r.Post("/matches/{matchID}/messages", h.send)
+	r.Get("/matches/{matchID}/messages", h.messages)
+	r.Post("/matches/{matchID}/messages/read", h.markRead)
+	r.Get("/messages/unread", h.unread)
+}
+
+func (h *Handler) send(w http.ResponseWriter, r *http.Request) {
+	userID, ok := r.Context().Value(pkg.CTXUserKey).(int)
+	if !ok {
+		response.RenderErrorResponse(w, "forbidden", entity.ErrForbidden)
+		return
+	}
+
+	matchID, err := strconv.Atoi(chi.URLParam(r, "matchID"))
+	if err != nil {
+		response.RenderErrorResponse(w, "invalid param", entity.ErrInvalidParam)
+		return
+	}
+
+	var req model.SendRequest
+
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		response.RenderErrorResponse(w, "invalid body", entity.ErrInvalidParam)
+		return
+	}
+
+	sent, err := h.messageService.Send(r.Context(), userID, matchID, req.Body)
+	if err != nil {
+		response.RenderErrorResponse(w, "failed to send message", err)
+		return
+	}
+
+	response.RenderResponse(w, sent, http.StatusCreated)
+}
+
+func (h *Handler) messages(w http.ResponseWriter, r *http.Request) {
+	userID, ok := r.Context().Value(pkg.CTXUserKey).(int)
+	if !ok {
+		response.RenderErrorResponse(w, "forbidden", entity.ErrForbidden)
+		return
+	}
+
+	matchID, err := strconv.Atoi(chi.URLParam(r, "matchID"))
+	if err != nil {
+		response.RenderErrorResponse(w, "invalid param", entity.ErrInvalidParam)
+		return
+	}
+
+	var (
+		limit int
+		after *entity.MessagesCursor
+	)
+
+	if limitParam := r.URL.Query().Get("limit"); limitParam != "" {
+		parsed, err := strconv.Atoi(limitParam)
+		if err != nil || parsed < 0 || parsed > entity.MaxMessagesLimit {
+			response.RenderErrorResponse(w, "invalid param", entity.ErrInvalidParam)
+			return
+		}
+
+		limit = parsed
+	}
+
+	if cursorParam := r.URL.Query().Get("cursor"); cursorParam != "" {
+		cursor, err := model.DecodeCursor(cursorParam)
+		if err != nil {
+			response.RenderErrorResponse(w, "invalid param", err)
+			return
+		}
+
+		after = &cursor
+	}
+
+	page, err := h.messageService.Messages(r.Context(), userID, matchID, limit, after)
+	if err != nil {
+		response.RenderErrorResponse(w, "failed to get messages", err)
+		return
+	}
+
+	response.RenderResponse(w, model.NewMessagesResponse(page), http.StatusOK)
+}
+
+func (h *Handler) markRead(w http.ResponseWriter, r *http.Request) {
+	userID, ok := r.Context().Value(pkg.CTXUserKey).(int)
+	if !ok {
+		response.RenderErrorResponse(w, "forbidden", entity.ErrForbidden)
+		return
+	}
+
+	matchID, err := strconv.Atoi(chi.URLParam(r, "matchID"))
+	if err != nil {
+		response.RenderErrorResponse(w, "invalid param", entity.ErrInvalidParam)
+		return
+	}
+
+	var req model.ReadRequest
+
+	// The body is optional; without one everything is marked read.
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil && !errors.Is(err, io.EOF) {
+		response.RenderErrorResponse(w, "invalid body", entity.ErrInvalidParam)
+		return
+	}
+
+	if err := h.messageService.MarkRead(r.Context(), userID, matchID, req.MessageID); err != nil {
+		response.RenderErrorResponse(w, "failed to mark read", err)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (h *Handler) unread(w http.ResponseWriter, r *http.Request) {
+	userID, ok := r.Context().Value(pkg.CTXUserKey).(int)
+	if !ok {
+		response.RenderErrorResponse(w, "forbidden", entity.ErrForbidden)
+		return
+	}
+
+	counts, err := h.messageService.UnreadCounts(r.Context(), userID)
+	if err != nil {
+		response.RenderErrorResponse(w, "failed to get unread counts", err)
+		return
+	}
+
+	response.RenderResponse(w, model.NewUnreadResponse(counts), http.StatusOK)
+}
@@ -0,0 +1,87 @@
+package model
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+
+	"github.com/colmmurphy91/muzz/internal/entity"
+)
+
+type SendRequest struct {
+	Body string `json:"body"`
+}
+
+// ReadRequest marks messages read up to and including MessageID, or all of
+// them when it is left out.
+type ReadRequest struct {
+	MessageID int `json:"message_id"`
+}
+
+type MessagesResponse struct {
+	Results    []entity.Message `json:"results"`
+	NextCursor string           `json:"next_cursor,omitempty"`
+	// Unread is how many of the other person's messages the user has not read.
+	Unread int `json:"unread"`
+	// OtherLastReadID is the newest message the other person has read.
+	OtherLastReadID int `json:"other_last_read_id"`
+}
+
+func NewMessagesResponse(page entity.MessagesPage) MessagesResponse {
+	resp := MessagesResponse{
+		Results:         page.Messages,
+		Unread:          page.Unread,
+		OtherLastReadID: page.OtherLastReadID,
+	}
+
+	if resp.Results == nil {
+		resp.Results = []entity.Message{}
+	}
+
+	if page.Next != nil {
+		resp.NextCursor = EncodeCursor(*page.Next)
+	}
+
+	return resp
+}
+
+type UnreadResponse struct {
+	Results []entity.UnreadCount `json:"results"`
+	Total   int                  `json:"total"`
+}
+
+func NewUnreadResponse(counts []entity.UnreadCount) UnreadResponse {
+	resp := UnreadResponse{Results: counts}
+
+	if resp.Results == nil {
+		resp.Results = []entity.UnreadCount{}
+	}
+
+	for _, count := range counts {
+		resp.Total += count.Unread
+	}
+
+	return resp
+}
+
+// EncodeCursor turns a cursor into the opaque string handed to clients.
+func EncodeCursor(cursor entity.MessagesCursor) string {
+	content, _ := json.Marshal(cursor) //nolint:errchkjson
+
+	return base64.RawURLEncoding.EncodeToString(content)
+}
+
+func DecodeCursor(value string) (entity.MessagesCursor, error) {
+	var cursor entity.MessagesCursor
+
+	content, err := base64.RawURLEncoding.DecodeString(value)
+	if err != nil {
+		return entity.MessagesCursor{}, fmt.Errorf("invalid cursor: %w", entity.ErrInvalidParam)
+	}
+
+	if err := json.Unmarshal(content, &cursor); err != nil || cursor.ID <= 0 {
+		return entity.MessagesCursor{}, fmt.Errorf("invalid cursor: %w", entity.ErrInvalidParam)
+	}
+
+	return cursor, nil
+}
@@ -1,9 +1,15 @@
 package model
 
-import validation "github.com/go-ozzo/ozzo-validation/v4"
+import (
+	"time"
+
+	validation "github.com/go-ozzo/ozzo-validation/v4"
+)
 
 type ErrorResponse struct {
 	Error       string            `json:"error"`
 	Validations validation.Errors `json:"validations,omitempty"`
 	Reason      string            `json:"reason,omitempty"`
+	// ResetAt is when a limit that was hit allows the request again.
+	ResetAt *time.Time `json:"reset_at,omitempty"`
 }
@@ -0,0 +1,35 @@
+package model
+
+import "github.com/colmmurphy91/muzz/internal/entity"
+
+// UserCard is what a user is shown of someone else, in discover results, likes
+// and matches. It carries no account details and no coordinates; how far away
+// someone is comes as a rounded distance.
+type UserCard struct {
+	ID             int     `json:"id"`
+	Name           string  `json:"name"`
+	Bio            string  `json:"bio"`
+	Gender         string  `json:"gender"`
+	Age            int     `json:"age"`
+	DistanceFromMe float64 `json:"distanceFromMe,omitempty"`
+}
+
+func NewUserCard(user entity.User) UserCard {
+	return UserCard{
+		ID:             user.ID,
+		Name:           user.Name,
+		Bio:            user.Bio,
+		Gender:         user.Gender,
+		Age:            user.Age,
+		DistanceFromMe: user.DistanceFromMe,
+	}
+}
+
+func NewUserCards(users []entity.User) []UserCard {
+	cards := make([]UserCard, 0, len(users))
+	for _, user := range users {
+		cards = append(cards, NewUserCard(user))
+	}
+
+	return cards
+}
@@ -0,0 +1,167 @@
+package notification
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strconv"
+	"time"
+
+	chi "github.com/go-chi/chi/v5"
+	"go.uber.org/zap"
+
+	"github.com/colmmurphy91/muzz/internal/api/notification/model"
+	"github.com/colmmurphy91/muzz/internal/api/response"
+	"github.com/colmmurphy91/muzz/internal/entity"
+	"github.com/colmmurphy91/muzz/internal/pkg"
+	"github.com/colmmurphy91/muzz/internal/usecase/notification"
+)
+
+// heartbeatInterval is how often an idle event stream is written to, so
+// proxies don't close it.
+const heartbeatInterval = 25 * time.Second
+
+type Handler struct {
+	logger              *zap.SugaredLogger
+	notificationService *notification.Service
+}
+
+func NewHandler(logger *zap.SugaredLogger, notificationService *notification.Service) *Handler {
+	return &Handler{logger: logger, notificationService: notificationService}
+}
+
+func (h *Handler) Register(r chi.Router) {
+	r.Get("/notifications", h.notifications)
+	r.Get("/events", h.events)
+}
+
+func (h *Handler) notifications(w http.ResponseWriter, r *http.Request) {
+	userID, ok := r.Context().Value(pkg.CTXUserKey).(int)
+	if !ok {
+		response.RenderErrorResponse(w, "forbidden", entity.ErrForbidden)
+		return
+	}
+
+	var (
+		limit int
+		after *entity.NotificationsCursor
+	)
+
+	if limitParam := r.URL.Query().Get("limit"); limitParam != "" {
+		parsed, err := strconv.Atoi(limitParam)
+		if err != nil || parsed < 0 || parsed > entity.MaxNotificationsLimit {
+			response.RenderErrorResponse(w, "invalid param", entity.ErrInvalidParam)
+			return
+		}
+
+		limit = parsed
+	}
+
+	if cursorParam := r.URL.Query().Get("cursor"); cursorParam != "" {
+		cursor, err := model.DecodeCursor(cursorParam)
+		if err != nil {
+			response.RenderErrorResponse(w, "invalid param", err)
+			return
+		}
+
+		after = &cursor
+	}
+
+	page, err := h.notificationService.Notifications(r.Context(), userID, limit, after)
+	if err != nil {
+		response.RenderErrorResponse(w, "failed to get notifications", err)
+		return
+	}
+
+	response.RenderResponse(w, model.NewNotificationsResponse(page), http.StatusOK)
+}
+
+// events streams the user's notifications as server-sent events while they are
+// connected. Each device opens its own stream. The stream ends when the token
+// does, and the client reconnects with a fresh one, catching up on anything
+// missed from /notifications.
+func (h *Handler) events(w http.ResponseWriter, r *http.Request) {
+	userID, ok := r.Context().Value(pkg.CTXUserKey).(int)
+	if !ok {
+		response.RenderErrorResponse(w, "forbidden", entity.ErrForbidden)
+		return
+	}
+
+	// The stream ends when the token expires, so one without an expiry is
+	// refused rather than streamed to forever.
+	claims, ok := r.Context().Value(pkg.CTXClaimsKey).(*pkg.CustomClaims)
+	if !ok || claims.ExpiresAt == nil {
+		response.RenderErrorResponse(w, "forbidden", entity.ErrForbidden)
+		return
+	}
+
+	// The stream outlives the server's write timeout.
+	rc := http.NewResponseController(w)
+	if err := rc.SetWriteDeadline(time.Time{}); err != nil {
+		h.logger.Errorw("failed to clear write deadline", "error", err)
+	}
+
+	notifications, unsubscribe := h.notificationService.Subscribe(userID)
+	defer unsubscribe()
+
+	w.Header().Set("Content-Type", "text/event-stream")
+	w.Header().Set("Cache-Control", "no-cache")
+	w.Header().Set("Connection", "keep-alive")
+	w.WriteHeader(http.StatusOK)
+
+	if err := rc.Flush(); err != nil {
+		h.logger.Errorw("failed to flush event stream", "error", err)
+		return
+	}
+
+	expired := time.NewTimer(time.Until(claims.ExpiresAt.Time))
+	defer expired.Stop()
+
+	heartbeat := time.NewTicker(heartbeatInterval)
+	defer heartbeat.Stop()
+
+	for {
+		var err error
+
+		select {
+		case <-r.Context().Done():
+			return
+		case <-expired.C:
+			return
+		case <-heartbeat.C:
+			_, err = fmt.Fprint(w, ": heartbeat\n\n")
+		case notification, open := <-notifications:
+			if !open {
+				return
+			}
+
+			err = writeEvent(w, notification)
+		}
+
+		if err == nil {
+			err = rc.Flush()
+		}
+
+		if err != nil {
+			return
+		}
+	}
+}
+
+func writeEvent(w http.ResponseWriter, notification entity.Notification) error {
+	data, err := json.Marshal(notification)
+	if err != nil {
+		return fmt.Errorf("failed to marshal notification: %w", err)
+	}
+
+	// Only notifications kept in the inbox have an id to resume from.
+	if notification.ID > 0 {
+		if _, err := fmt.Fprintf(w, "id: %d\n", notification.ID); err != nil {
+			return err
+		}
+	}
+
+	_, err = fmt.Fprintf(w, "event: %s\ndata: %s\n\n", notification.Type, data)
+
+	return err
+}
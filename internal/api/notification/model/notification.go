@@ -0,0 +1,50 @@
+package model
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+
+	"github.com/colmmurphy91/muzz/internal/entity"
+)
+
+type NotificationsResponse struct {
+	Results    []entity.Notification `json:"results"`
+	NextCursor string                `json:"next_cursor,omitempty"`
+}
+
+func NewNotificationsResponse(page entity.NotificationsPage) NotificationsResponse {
+	resp := NotificationsResponse{Results: page.Notifications}
+
+	if resp.Results == nil {
+		resp.Results = []entity.Notification{}
+	}
+
+	if page.Next != nil {
+		resp.NextCursor = EncodeCursor(*page.Next)
+	}
+
+	return resp
+}
+
+// EncodeCursor turns a cursor into the opaque string handed to clients.
+func EncodeCursor(cursor entity.NotificationsCursor) string {
+	content, _ := json.Marshal(cursor) //nolint:errchkjson
+
+	return base64.RawURLEncoding.EncodeToString(content)
+}
+
+func DecodeCursor(value string) (entity.NotificationsCursor, error) {
+	var cursor entity.NotificationsCursor
+
+	content, err := base64.RawURLEncoding.DecodeString(value)
+	if err != nil {
+		return entity.NotificationsCursor{}, fmt.Errorf("invalid cursor: %w", entity.ErrInvalidParam)
+	}
+
+	if err := json.Unmarshal(content, &cursor); err != nil || cursor.ID <= 0 {
+		return entity.NotificationsCursor{}, fmt.Errorf("invalid cursor: %w", entity.ErrInvalidParam)
+	}
+
+	return cursor, nil
+}
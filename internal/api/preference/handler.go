@@ -0,0 +1,86 @@
+package preference
+
+import (
+	"encoding/json"
+	"net/http"
+
+	chi "github.com/go-chi/chi/v5"
+	"go.uber.org/zap"
+
+	"github.com/colmmurphy91/muzz/internal/api/response"
+	"github.com/colmmurphy91/muzz/internal/entity"
+	"github.com/colmmurphy91/muzz/internal/pkg"
+	"github.com/colmmurphy91/muzz/internal/usecase/preference"
+)
+
+type Handler struct {
+	logger            *zap.SugaredLogger
+	preferenceService *preference.Service
+}
+
+func NewHandler(logger *zap.SugaredLogger, preferenceService *preference.Service) *Handler {
+	return &Handler{logger: logger, preferenceService: preferenceService}
+}
+
+func (h *Handler) Register(r chi.Router) {
+	r.Get("/preferences", h.getPreferences)
+	r.Put("/preferences", h.savePreferences)
+	r.Delete("/preferences", h.deletePreferences)
+}
+
+func (h *Handler) getPreferences(w http.ResponseWriter, r *http.Request) {
+	userID, ok := r.Context().Value(pkg.CTXUserKey).(int)
+	if !ok {
+		response.RenderErrorResponse(w, "forbidden", entity.ErrForbidden)
+		return
+	}
+
+	prefs, err := h.preferenceService.GetPreferences(r.Context(), userID)
+	if err != nil {
+		response.RenderErrorResponse(w, "failed to get preferences", err)
+		return
+	}
+
+	response.RenderResponse(w, prefs, http.StatusOK)
+}
+
+func (h *Handler) savePreferences(w http.ResponseWriter, r *http.Request) {
+	userID, ok := r.Context().Value(pkg.CTXUserKey).(int)
+	if !ok {
+		response.RenderErrorResponse(w, "forbidden", entity.ErrForbidden)
+		return
+	}
+
+	// Fields left out of the body keep their default value.
+	prefs := entity.DefaultPreferences(userID)
+
+	if err := json.NewDecoder(r.Body).Decode(&prefs); err != nil {
+		response.RenderErrorResponse(w, "Invalid request payload", err)
+		return
+	}
+
+	prefs.UserID = userID
+
+	saved, err := h.preferenceService.SavePreferences(r.Context(), prefs)
+	if err != nil {
+		response.RenderErrorResponse(w, "failed to save preferences", err)
+		return
+	}
+
+	response.RenderResponse(w, saved, http.StatusOK)
+}
+
+func (h *Handler) deletePreferences(w http.ResponseWriter, r *http.Request) {
+	userID, ok := r.Context().Value(pkg.CTXUserKey).(int)
+	if !ok {
+		response.RenderErrorResponse(w, "forbidden", entity.ErrForbidden)
+		return
+	}
+
+	if err := h.preferenceService.DeletePreferences(r.Context(), userID); err != nil {
+		response.RenderErrorResponse(w, "failed to delete preferences", err)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
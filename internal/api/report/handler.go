@@ -0,0 +1,63 @@
+package report
+
+import (
+	"encoding/json"
+	"net/http"
+	"strconv"
+
+	chi "github.com/go-chi/chi/v5"
+	"go.uber.org/zap"
+
+	"github.com/colmmurphy91/muzz/internal/api/report/model"
+	"github.com/colmmurphy91/muzz/internal/api/response"
+	"github.com/colmmurphy91/muzz/internal/entity"
+	"github.com/colmmurphy91/muzz/internal/pkg"
+	"github.com/colmmurphy91/muzz/internal/usecase/report"
+)
+
+type Handler struct {
+	logger        *zap.SugaredLogger
+	reportService *report.Service
+}
+
+func NewHandler(logger *zap.SugaredLogger, reportService *report.Service) *Handler {
+	return &Handler{logger: logger, reportService: reportService}
+}
+
+func (h *Handler) Register(r chi.Router) {
+	r.Post("/users/{userID}/report", h.report)
+}
+
+func (h *Handler) report(w http.ResponseWriter, r *http.Request) {
+	userID, ok := r.Context().Value(pkg.CTXUserKey).(int)
+	if !ok {
+		response.RenderErrorResponse(w, "forbidden", entity.ErrForbidden)
+		return
+	}
+
+	reportedID, err := strconv.Atoi(chi.URLParam(r, "userID"))
+	if err != nil {
+		response.RenderErrorResponse(w, "invalid param", entity.ErrInvalidParam)
+		return
+	}
+
+	var req model.ReportRequest
+
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		response.RenderErrorResponse(w, "invalid body", entity.ErrInvalidParam)
+		return
+	}
+
+	saved, err := h.reportService.Report(r.Context(), entity.Report{
+		ReporterID: userID,
+		ReportedID: reportedID,
+		Reason:     req.Reason,
+		Details:    req.Details,
+	})
+	if err != nil {
+		response.RenderErrorResponse(w, "failed to report", err)
+		return
+	}
+
+	response.RenderResponse(w, saved, http.StatusCreated)
+}
@@ -0,0 +1,8 @@
+package model
+
+import "github.com/colmmurphy91/muzz/internal/entity"
+
+type ReportRequest struct {
+	Reason  entity.ReportReason `json:"reason"`
+	Details string              `json:"details"`
+}
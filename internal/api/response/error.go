@@ -2,7 +2,10 @@ package response
 
 import (
 	"errors"
+	"math"
 	"net/http"
+	"strconv"
+	"time"
 
 	validation "github.com/go-ozzo/ozzo-validation/v4"
 
@@ -12,29 +15,106 @@ import (
 )
 
 func RenderErrorResponse(w http.ResponseWriter, msg string, err error) {
+	resp, status := NewErrorResponse(msg, err)
+
+	if resp.ResetAt != nil {
+		retryAfter := math.Ceil(time.Until(*resp.ResetAt).Seconds())
+		w.Header().Set("Retry-After", strconv.Itoa(int(math.Max(retryAfter, 0))))
+	}
+
+	RenderResponse(w, resp, status)
+}
+
+// NewErrorResponse describes err to the client, with the status it maps to.
+func NewErrorResponse(msg string, err error) (model.ErrorResponse, int) {
 	resp := model.ErrorResponse{Error: msg}
 	status := http.StatusInternalServerError
 
-	var validationErrs validation.Errors
+	var (
+		validationErrs validation.Errors
+		quotaErr       entity.QuotaExceededError
+	)
 
 	switch {
 	case errors.As(err, &validationErrs):
 		status = http.StatusBadRequest
 		resp.Error = "Validation failed"
 		resp.Validations = validationErrs
+	case errors.Is(err, entity.ErrInvalidParam):
+		status = http.StatusBadRequest
+		resp.Reason = err.Error()
+	case errors.Is(err, entity.ErrCursorExpired):
+		status = http.StatusGone
+		resp.Reason = "cursor has expired, start again from the first page"
 	case errors.Is(err, entity.ErrUserNotFound):
 		status = http.StatusNotFound
 		resp.Reason = "User does not exist"
+	case errors.Is(err, entity.ErrMatchNotFound):
+		status = http.StatusNotFound
+		resp.Reason = "Match does not exist"
+	case errors.Is(err, entity.ErrMatchEnded):
+		status = http.StatusGone
+		resp.Reason = "match has ended"
+	case errors.Is(err, entity.ErrReportNotFound):
+		status = http.StatusNotFound
+		resp.Reason = "Report does not exist"
+	case errors.Is(err, entity.ErrReportTransition):
+		status = http.StatusConflict
+		resp.Reason = "report cannot move to that status"
+	case errors.Is(err, entity.ErrBlocked):
+		status = http.StatusForbidden
+		resp.Reason = "user is blocked"
+	case errors.Is(err, entity.ErrSwipeNotFound):
+		status = http.StatusNotFound
+		resp.Reason = "Swipe does not exist"
+	case errors.Is(err, entity.ErrSwipeLocked):
+		status = http.StatusConflict
+		resp.Reason = "swipe is part of a match"
+	case errors.Is(err, entity.ErrRewindLimitReached):
+		status = http.StatusTooManyRequests
+		resp.Reason = "no rewinds left today"
+	case errors.Is(err, entity.ErrSuperLikeLimitReached):
+		status = http.StatusTooManyRequests
+		resp.Reason = "no super likes left today"
+	case errors.Is(err, entity.ErrLocationThrottled) && errors.As(err, &quotaErr):
+		status = http.StatusTooManyRequests
+		resp.Reason = "location was updated too recently"
+		resp.ResetAt = &quotaErr.ResetAt
+	case errors.As(err, &quotaErr):
+		status = http.StatusTooManyRequests
+		resp.Reason = "no likes left"
+		resp.ResetAt = &quotaErr.ResetAt
+	case errors.Is(err, entity.ErrSwipeKeyReused):
+		status = http.StatusConflict
+		resp.Reason = "key was used for a different swipe"
+	case errors.Is(err, entity.ErrIdempotencyKeyReused):
+		status = http.StatusUnprocessableEntity
+		resp.Reason = "key was used for a different request"
+	case errors.Is(err, entity.ErrIdempotencyKeyInFlight):
+		status = http.StatusConflict
+		resp.Reason = "a request with this key is still in progress"
 	case errors.Is(err, entity.ErrEmailAlreadyExists), errors.Is(err, entity.ErrMatchAlreadyExists):
 		status = http.StatusConflict
 		resp.Reason = "already exists"
 	case errors.Is(err, auth.ErrPasswordDoesNotMatch):
 		status = http.StatusUnauthorized
 		resp.Reason = "password does not match"
+	case errors.Is(err, auth.ErrInvalidRefreshToken), errors.Is(err, auth.ErrRefreshTokenReused):
+		status = http.StatusUnauthorized
+		resp.Reason = "refresh token is invalid or has been revoked"
+	case errors.Is(err, auth.ErrAccountSuspended):
+		status = http.StatusForbidden
+		resp.Reason = "account is suspended"
+	case errors.Is(err, entity.ErrVersionConflict):
+		status = http.StatusConflict
+		resp.Reason = "profile was changed since it was read"
+	case errors.Is(err, entity.ErrUserStatusTransition):
+		status = http.StatusConflict
+		resp.Reason = "user cannot move to that status"
 	case errors.Is(err, entity.ErrForbidden):
 		status = http.StatusForbidden
 		resp.Reason = msg
 	}
 
-	RenderResponse(w, resp, status)
+	return resp, status
 }
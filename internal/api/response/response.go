@@ -16,9 +16,16 @@ func RenderResponse(w http.ResponseWriter, res interface{}, status int) {
 		return
 	}
 
+	RenderRaw(w, content, status)
+}
+
+// RenderRaw writes a body that is already encoded, e.g. one RenderResponse wrote
+// before, byte for byte.
+func RenderRaw(w http.ResponseWriter, content []byte, status int) {
+	w.Header().Set("Content-Type", "application/json")
 	w.WriteHeader(status)
 
-	if _, err = w.Write(content); err != nil {
+	if _, err := w.Write(content); err != nil {
 		log.Printf("Failed to write response: %v", err)
 	}
 }
@@ -0,0 +1,166 @@
+package response_test
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"sort"
+	"testing"
+	"time"
+
+	null "github.com/guregu/null/v5"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/colmmurphy91/muzz/internal/adapter/mysql/user/model"
+	adminModel "github.com/colmmurphy91/muzz/internal/api/admin/model"
+	discoverModel "github.com/colmmurphy91/muzz/internal/api/discover/model"
+	likeModel "github.com/colmmurphy91/muzz/internal/api/like/model"
+	matchModel "github.com/colmmurphy91/muzz/internal/api/match/model"
+	"github.com/colmmurphy91/muzz/internal/api/response"
+	userModel "github.com/colmmurphy91/muzz/internal/api/user/model"
+	"github.com/colmmurphy91/muzz/internal/entity"
+)
+
+// alice is stored with every detail set, so anything that leaks shows up.
+var alice = model.User{
+	ID:                2,
+	Email:             "alice@example.com",
+	Password:          "$argon2id$v=19$m=65536,t=1,p=4$c2FsdA$aGFzaA",
+	Name:              "Alice",
+	Bio:               "Climber",
+	Gender:            "female",
+	DateOfBirth:       time.Date(1995, 4, 21, 0, 0, 0, 0, time.UTC),
+	Age:               29,
+	Lat:               51.51,
+	Lon:               -0.13,
+	LocationUpdatedAt: null.TimeFrom(time.Date(2024, 7, 8, 12, 0, 0, 0, time.UTC)),
+	Role:              entity.RoleUser,
+	Status:            entity.UserStatusActive,
+	Version:           3,
+}
+
+var cardFields = []string{"age", "bio", "distanceFromMe", "gender", "id", "name"}
+
+func render(t *testing.T, res interface{}) []byte {
+	t.Helper()
+
+	w := httptest.NewRecorder()
+	response.RenderResponse(w, res, http.StatusOK)
+	require.Equal(t, http.StatusOK, w.Code)
+
+	return w.Body.Bytes()
+}
+
+func keys(t *testing.T, content json.RawMessage) []string {
+	t.Helper()
+
+	var fields map[string]json.RawMessage
+	require.NoError(t, json.Unmarshal(content, &fields))
+
+	names := make([]string, 0, len(fields))
+	for name := range fields {
+		names = append(names, name)
+	}
+
+	sort.Strings(names)
+
+	return names
+}
+
+func TestRenderResponse_OtherUsersOnlyShowCards(t *testing.T) {
+	profile := alice.Profile()
+	profile.DistanceFromMe = 2
+
+	tests := []struct {
+		name string
+		res  interface{}
+		card func(body []byte) json.RawMessage
+	}{
+		{
+			name: "discover",
+			res:  discoverModel.NewDiscoverResponse(entity.DiscoverPage{Users: []entity.User{profile}}, nil),
+			card: func(body []byte) json.RawMessage {
+				var resp struct {
+					Results []json.RawMessage `json:"results"`
+				}
+				require.NoError(t, json.Unmarshal(body, &resp))
+				require.Len(t, resp.Results, 1)
+
+				return resp.Results[0]
+			},
+		},
+		{
+			name: "likes",
+			res:  likeModel.NewLikesResponse(entity.LikesPage{Likes: []entity.Like{{User: profile, Super: true}}}),
+			card: func(body []byte) json.RawMessage {
+				var resp struct {
+					Results []struct {
+						User json.RawMessage `json:"user"`
+					} `json:"results"`
+				}
+				require.NoError(t, json.Unmarshal(body, &resp))
+				require.Len(t, resp.Results, 1)
+
+				return resp.Results[0].User
+			},
+		},
+		{
+			name: "matches",
+			res:  matchModel.NewMatchesResponse(entity.MatchesPage{Matches: []entity.MatchView{{ID: 7, User: profile}}}),
+			card: func(body []byte) json.RawMessage {
+				var resp struct {
+					Results []struct {
+						User json.RawMessage `json:"user"`
+					} `json:"results"`
+				}
+				require.NoError(t, json.Unmarshal(body, &resp))
+				require.Len(t, resp.Results, 1)
+
+				return resp.Results[0].User
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			body := render(t, tt.res)
+
+			assert.NotContains(t, string(body), alice.Email)
+			assert.NotContains(t, string(body), alice.Password)
+			assert.NotContains(t, string(body), "51.51")
+			assert.Equal(t, cardFields, keys(t, tt.card(body)))
+		})
+	}
+}
+
+func TestRenderResponse_OwnAndStaffViews(t *testing.T) {
+	tests := []struct {
+		name     string
+		res      interface{}
+		expected []string
+	}{
+		{
+			name:     "own profile",
+			res:      userModel.NewProfileResponse(alice),
+			expected: []string{"age", "bio", "date_of_birth", "email", "gender", "id", "location", "name", "version"},
+		},
+		{
+			name: "admin view",
+			res:  adminModel.NewUserResponse(alice),
+			expected: []string{
+				"age", "bio", "date_of_birth", "email", "gender", "id", "location", "location_updated_at", "name", "role",
+				"shadow_banned", "status", "version",
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			body := render(t, tt.res)
+
+			assert.NotContains(t, string(body), alice.Password)
+			assert.Equal(t, tt.expected, keys(t, body))
+		})
+	}
+}
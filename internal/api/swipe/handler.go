@@ -2,6 +2,7 @@ package swipe
 
 import (
 	"encoding/json"
+	"fmt"
 	"github.com/colmmurphy91/muzz/internal/pkg"
 	"net/http"
 
@@ -9,6 +10,7 @@ import (
 	"go.uber.org/zap"
 
 	"github.com/colmmurphy91/muzz/internal/api/response"
+	"github.com/colmmurphy91/muzz/internal/api/swipe/model"
 	"github.com/colmmurphy91/muzz/internal/entity"
 	swipeService "github.com/colmmurphy91/muzz/internal/usecase/swipe"
 )
@@ -24,6 +26,9 @@ func NewHandler(logger *zap.SugaredLogger, swipeService *swipeService.Service) *
 
 func (h *Handler) Register(r chi.Router) {
 	r.Post("/swipe", h.swipe)
+	r.Post("/swipes/batch", h.batch)
+	r.Post("/swipes/rewind", h.rewind)
+	r.Get("/quota", h.quota)
 }
 
 func (h *Handler) swipe(w http.ResponseWriter, r *http.Request) {
@@ -58,3 +63,63 @@ func (h *Handler) swipe(w http.ResponseWriter, r *http.Request) {
 
 	response.RenderResponse(w, matchResponse, http.StatusCreated)
 }
+
+// batch applies queued swipes. Each swipe succeeds or fails on its own, so the
+// batch as a whole succeeds unless the request itself is invalid.
+func (h *Handler) batch(w http.ResponseWriter, r *http.Request) {
+	userID, ok := r.Context().Value(pkg.CTXUserKey).(int)
+	if !ok {
+		response.RenderErrorResponse(w, "forbidden", entity.ErrForbidden)
+		return
+	}
+
+	var req model.BatchRequest
+
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		response.RenderErrorResponse(w, "invalid body", entity.ErrInvalidParam)
+		return
+	}
+
+	if len(req.Swipes) == 0 || len(req.Swipes) > entity.MaxSwipeBatchSize {
+		err := fmt.Errorf("batch must have 1 to %d swipes: %w", entity.MaxSwipeBatchSize, entity.ErrInvalidParam)
+		response.RenderErrorResponse(w, "invalid batch", err)
+
+		return
+	}
+
+	results := h.swipeService.SwipeBatch(r.Context(), userID, req.Swipes)
+
+	response.RenderResponse(w, model.NewBatchResponse(results), http.StatusOK)
+}
+
+func (h *Handler) rewind(w http.ResponseWriter, r *http.Request) {
+	userID, ok := r.Context().Value(pkg.CTXUserKey).(int)
+	if !ok {
+		response.RenderErrorResponse(w, "forbidden", entity.ErrForbidden)
+		return
+	}
+
+	swipe, err := h.swipeService.Rewind(r.Context(), userID)
+	if err != nil {
+		response.RenderErrorResponse(w, "failed to rewind swipe", err)
+		return
+	}
+
+	response.RenderResponse(w, swipe, http.StatusOK)
+}
+
+func (h *Handler) quota(w http.ResponseWriter, r *http.Request) {
+	userID, ok := r.Context().Value(pkg.CTXUserKey).(int)
+	if !ok {
+		response.RenderErrorResponse(w, "forbidden", entity.ErrForbidden)
+		return
+	}
+
+	quota, err := h.swipeService.Quota(r.Context(), userID)
+	if err != nil {
+		response.RenderErrorResponse(w, "failed to get quota", err)
+		return
+	}
+
+	response.RenderResponse(w, quota, http.StatusOK)
+}
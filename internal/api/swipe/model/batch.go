@@ -0,0 +1,55 @@
+package model
+
+import (
+	"net/http"
+
+	apiModel "github.com/colmmurphy91/muzz/internal/api/model"
+	"github.com/colmmurphy91/muzz/internal/api/response"
+	"github.com/colmmurphy91/muzz/internal/entity"
+	swipeService "github.com/colmmurphy91/muzz/internal/usecase/swipe"
+)
+
+type BatchRequest struct {
+	Swipes []entity.BatchSwipe `json:"swipes"`
+}
+
+// BatchResult is the outcome of one swipe. Status is the status the swipe would
+// have got on its own, and Error is set when it failed.
+type BatchResult struct {
+	Key      string                  `json:"key"`
+	TargetID int                     `json:"target_id"`
+	Status   int                     `json:"status"`
+	Matched  bool                    `json:"matched"`
+	MatchID  int                     `json:"matchID,omitempty"`
+	Replayed bool                    `json:"replayed"`
+	Error    *apiModel.ErrorResponse `json:"error,omitempty"`
+}
+
+type BatchResponse struct {
+	Results []BatchResult `json:"results"`
+}
+
+func NewBatchResponse(results []swipeService.BatchResult) BatchResponse {
+	resp := BatchResponse{Results: make([]BatchResult, len(results))}
+
+	for i, result := range results {
+		item := BatchResult{
+			Key:      result.Swipe.Key,
+			TargetID: result.Swipe.TargetID,
+			Status:   http.StatusCreated,
+			Matched:  result.Match.Matched,
+			MatchID:  result.Match.MatchID,
+			Replayed: result.Replayed,
+		}
+
+		if result.Err != nil {
+			errResp, status := response.NewErrorResponse("failed to save swipe", result.Err)
+			item.Status = status
+			item.Error = &errResp
+		}
+
+		resp.Results[i] = item
+	}
+
+	return resp
+}
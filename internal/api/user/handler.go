@@ -1,12 +1,16 @@
 package user
 
 import (
+	"encoding/json"
 	"net/http"
 
 	chi "github.com/go-chi/chi/v5"
 	"go.uber.org/zap"
 
 	"github.com/colmmurphy91/muzz/internal/api/response"
+	"github.com/colmmurphy91/muzz/internal/api/user/model"
+	"github.com/colmmurphy91/muzz/internal/entity"
+	"github.com/colmmurphy91/muzz/internal/pkg"
 	"github.com/colmmurphy91/muzz/internal/usecase/user"
 )
 
@@ -23,11 +27,103 @@ func (h *Handler) Register(r chi.Router) {
 	r.Post("/user/create", h.createUser)
 }
 
+// RegisterAuthenticated registers the routes that require a valid access token.
+func (h *Handler) RegisterAuthenticated(r chi.Router) {
+	r.Get("/me", h.profile)
+	r.Patch("/me", h.updateProfile)
+	r.Put("/me/location", h.updateLocation)
+}
+
 func (h *Handler) createUser(w http.ResponseWriter, r *http.Request) {
-	createUser, err := h.userManager.CreateUser(r.Context())
+	var createRequest model.CreateUserRequest
+
+	if err := json.NewDecoder(r.Body).Decode(&createRequest); err != nil {
+		response.RenderErrorResponse(w, "Invalid request payload", err)
+		return
+	}
+
+	if err := createRequest.Validate(); err != nil {
+		response.RenderErrorResponse(w, "Validation failed", err)
+		return
+	}
+
+	createUser, err := h.userManager.CreateUser(r.Context(), createRequest.ToRegistration())
 	if err != nil {
 		response.RenderErrorResponse(w, "error creating user", err)
+		return
+	}
+
+	response.RenderResponse(w, model.NewProfileResponse(createUser), http.StatusCreated)
+}
+
+func (h *Handler) profile(w http.ResponseWriter, r *http.Request) {
+	userID, ok := r.Context().Value(pkg.CTXUserKey).(int)
+	if !ok {
+		response.RenderErrorResponse(w, "forbidden", entity.ErrForbidden)
+		return
+	}
+
+	profile, err := h.userManager.Profile(r.Context(), userID)
+	if err != nil {
+		response.RenderErrorResponse(w, "failed to get profile", err)
+		return
+	}
+
+	response.RenderResponse(w, model.NewProfileResponse(profile), http.StatusOK)
+}
+
+func (h *Handler) updateProfile(w http.ResponseWriter, r *http.Request) {
+	userID, ok := r.Context().Value(pkg.CTXUserKey).(int)
+	if !ok {
+		response.RenderErrorResponse(w, "forbidden", entity.ErrForbidden)
+		return
+	}
+
+	var updateRequest model.UpdateProfileRequest
+
+	if err := json.NewDecoder(r.Body).Decode(&updateRequest); err != nil {
+		response.RenderErrorResponse(w, "invalid body", entity.ErrInvalidParam)
+		return
+	}
+
+	if err := updateRequest.Validate(); err != nil {
+		response.RenderErrorResponse(w, "Validation failed", err)
+		return
+	}
+
+	profile, err := h.userManager.UpdateProfile(r.Context(), userID, updateRequest.ToProfileUpdate())
+	if err != nil {
+		response.RenderErrorResponse(w, "failed to update profile", err)
+		return
+	}
+
+	response.RenderResponse(w, model.NewProfileResponse(profile), http.StatusOK)
+}
+
+func (h *Handler) updateLocation(w http.ResponseWriter, r *http.Request) {
+	userID, ok := r.Context().Value(pkg.CTXUserKey).(int)
+	if !ok {
+		response.RenderErrorResponse(w, "forbidden", entity.ErrForbidden)
+		return
+	}
+
+	var locationRequest model.UpdateLocationRequest
+
+	if err := json.NewDecoder(r.Body).Decode(&locationRequest); err != nil {
+		response.RenderErrorResponse(w, "invalid body", entity.ErrInvalidParam)
+		return
+	}
+
+	if err := locationRequest.Validate(); err != nil {
+		response.RenderErrorResponse(w, "Validation failed", err)
+		return
+	}
+
+	location, err := h.userManager.UpdateLocation(r.Context(), userID, locationRequest.ToLocation())
+	if err != nil {
+		response.RenderErrorResponse(w, "failed to update location", err)
+		return
 	}
 
-	response.RenderResponse(w, createUser, http.StatusCreated)
+	response.RenderResponse(w, location, http.StatusOK)
 }
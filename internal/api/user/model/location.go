@@ -0,0 +1,27 @@
+package model
+
+import (
+	validation "github.com/go-ozzo/ozzo-validation/v4"
+
+	"github.com/colmmurphy91/muzz/internal/entity"
+)
+
+// UpdateLocationRequest is where the user's device says they are. Both
+// coordinates are required, as zero is a valid latitude and longitude.
+type UpdateLocationRequest struct {
+	Lat *float64 `json:"lat"`
+	Lon *float64 `json:"lon"`
+}
+
+func (lr *UpdateLocationRequest) Validate() error {
+	return validation.ValidateStruct(
+		lr,
+		validation.Field(&lr.Lat, validation.NotNil, validation.Min(-90.0), validation.Max(90.0)),
+		validation.Field(&lr.Lon, validation.NotNil, validation.Min(-180.0), validation.Max(180.0)),
+	)
+}
+
+// ToLocation converts a validated request into the location the user manager expects.
+func (lr *UpdateLocationRequest) ToLocation() entity.Location {
+	return entity.Location{Lat: *lr.Lat, Lon: *lr.Lon}
+}
@@ -0,0 +1,87 @@
+package model
+
+import (
+	"time"
+
+	validation "github.com/go-ozzo/ozzo-validation/v4"
+	null "github.com/guregu/null/v5"
+
+	"github.com/colmmurphy91/muzz/internal/adapter/mysql/user/model"
+	"github.com/colmmurphy91/muzz/internal/entity"
+)
+
+// UpdateProfileRequest changes the details that are present; the rest are left
+// as they are. Version is the version of the profile the change was made to,
+// as returned by GET /me.
+type UpdateProfileRequest struct {
+	Name        null.String `json:"name"`
+	Bio         null.String `json:"bio"`
+	Gender      null.String `json:"gender"`
+	DateOfBirth null.String `json:"date_of_birth"`
+	Version     int         `json:"version"`
+}
+
+func (ur *UpdateProfileRequest) Validate() error {
+	return validation.ValidateStruct(
+		ur,
+		validation.Field(&ur.Name, validation.NilOrNotEmpty, validation.Length(1, 255)),
+		validation.Field(&ur.Bio, validation.RuneLength(0, entity.MaxBioLength)),
+		validation.Field(&ur.Gender, validation.NilOrNotEmpty, validation.In("male", "female")),
+		validation.Field(&ur.DateOfBirth, validation.NilOrNotEmpty, validation.Date(dateOfBirthLayout), validation.By(adultIfSet)),
+		validation.Field(&ur.Version, validation.Required, validation.Min(1)),
+	)
+}
+
+// ToProfileUpdate converts a validated request into the change the user manager expects.
+func (ur *UpdateProfileRequest) ToProfileUpdate() entity.ProfileUpdate {
+	update := entity.ProfileUpdate{
+		Name:    ur.Name,
+		Bio:     ur.Bio,
+		Gender:  ur.Gender,
+		Version: ur.Version,
+	}
+
+	if ur.DateOfBirth.Valid {
+		dob, _ := time.Parse(dateOfBirthLayout, ur.DateOfBirth.String)
+		update.DateOfBirth = null.TimeFrom(dob)
+	}
+
+	return update
+}
+
+// ProfileResponse is the user's own profile.
+type ProfileResponse struct {
+	ID          int             `json:"id"`
+	Email       string          `json:"email"`
+	Name        string          `json:"name"`
+	Bio         string          `json:"bio"`
+	Gender      string          `json:"gender"`
+	DateOfBirth string          `json:"date_of_birth"`
+	Age         int             `json:"age"`
+	Location    entity.Location `json:"location"`
+	Version     int             `json:"version"`
+}
+
+func NewProfileResponse(user model.User) ProfileResponse {
+	return ProfileResponse{
+		ID:          user.ID,
+		Email:       user.Email,
+		Name:        user.Name,
+		Bio:         user.Bio,
+		Gender:      user.Gender,
+		DateOfBirth: user.DateOfBirth.Format(dateOfBirthLayout),
+		Age:         user.Age,
+		Location:    entity.Location{Lat: user.Lat, Lon: user.Lon},
+		Version:     user.Version,
+	}
+}
+
+// nolint:forcetypeassert
+func adultIfSet(value interface{}) error {
+	dob := value.(null.String)
+	if !dob.Valid {
+		return nil
+	}
+
+	return adult(dob.String)
+}
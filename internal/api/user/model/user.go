@@ -0,0 +1,75 @@
+package model
+
+import (
+	"time"
+
+	validation "github.com/go-ozzo/ozzo-validation/v4"
+	"github.com/go-ozzo/ozzo-validation/v4/is"
+
+	"github.com/colmmurphy91/muzz/internal/entity"
+)
+
+const dateOfBirthLayout = "2006-01-02"
+
+// maxPasswordBytes is as much of a password as bcrypt hashes; it would ignore
+// the rest, so longer passwords are turned away rather than silently cut short.
+const maxPasswordBytes = 72
+
+type CreateUserRequest struct {
+	Email       string          `json:"email"`
+	Password    string          `json:"password"`
+	Name        string          `json:"name"`
+	Gender      string          `json:"gender"`
+	DateOfBirth string          `json:"date_of_birth"`
+	Location    entity.Location `json:"location"`
+}
+
+func (cr *CreateUserRequest) Validate() error {
+	return validation.ValidateStruct(
+		cr,
+		validation.Field(&cr.Email, validation.Required, is.Email),
+		validation.Field(&cr.Password, validation.Required, validation.Length(6, maxPasswordBytes), validation.By(hashable)),
+		validation.Field(&cr.Name, validation.Required, validation.Length(1, 255)),
+		validation.Field(&cr.Gender, validation.Required, validation.In("male", "female")),
+		validation.Field(&cr.DateOfBirth, validation.Required, validation.Date(dateOfBirthLayout), validation.By(adult)),
+		validation.Field(&cr.Location),
+	)
+}
+
+// ToRegistration converts a validated request into the details the user manager expects.
+func (cr *CreateUserRequest) ToRegistration() entity.Registration {
+	dob, _ := time.Parse(dateOfBirthLayout, cr.DateOfBirth)
+
+	return entity.Registration{
+		Email:       cr.Email,
+		Password:    cr.Password,
+		Name:        cr.Name,
+		Gender:      cr.Gender,
+		DateOfBirth: dob,
+		Location:    cr.Location,
+	}
+}
+
+// nolint:forcetypeassert
+func hashable(value interface{}) error {
+	if len(value.(string)) > maxPasswordBytes {
+		return validation.NewError("validation_password_too_long", "must be no more than 72 bytes")
+	}
+
+	return nil
+}
+
+// nolint:forcetypeassert
+func adult(value interface{}) error {
+	dob, err := time.Parse(dateOfBirthLayout, value.(string))
+	if err != nil {
+		// the format is reported by validation.Date
+		return nil //nolint:nilerr
+	}
+
+	if entity.AgeAt(dob, time.Now()) < entity.MinimumAge {
+		return validation.NewError("validation_underage", "must be at least 18 years old")
+	}
+
+	return nil
+}
@@ -0,0 +1,44 @@
+package entity
+
+import (
+	"time"
+
+	validation "github.com/go-ozzo/ozzo-validation/v4"
+)
+
+// MaxAuditReasonLength is the most characters the reason for an action can have.
+const MaxAuditReasonLength = 1000
+
+// AuditAction is something staff did through the admin API.
+type AuditAction string
+
+const (
+	AuditActionReportTransition AuditAction = "report.transition"
+	AuditActionSuspend          AuditAction = "user.suspend"
+	AuditActionBan              AuditAction = "user.ban"
+	AuditActionReinstate        AuditAction = "user.reinstate"
+	AuditActionShadowBan        AuditAction = "user.shadow_ban"
+	AuditActionUnshadowBan      AuditAction = "user.unshadow_ban"
+	AuditActionSetRole          AuditAction = "user.set_role"
+	AuditActionForceUnmatch     AuditAction = "match.force_unmatch"
+)
+
+// AuditEntry records who took an action, on what and why. TargetID is the
+// report for report actions, the match for match actions and the user
+// otherwise. Detail is what the target was changed to, such as the status of a
+// report or the role of a user, where the action alone does not say.
+type AuditEntry struct {
+	ID        int         `db:"id" json:"id"`
+	ActorID   int         `db:"actor_id" json:"actor_id"`
+	Action    AuditAction `db:"action" json:"action"`
+	TargetID  int         `db:"target_id" json:"target_id"`
+	Detail    string      `db:"detail" json:"detail"`
+	Reason    string      `db:"reason" json:"reason"`
+	CreatedAt time.Time   `db:"created_at" json:"created_at"`
+}
+
+func (e AuditEntry) Validate() error {
+	return validation.ValidateStruct(&e,
+		validation.Field(&e.Reason, validation.RuneLength(0, MaxAuditReasonLength)),
+	)
+}
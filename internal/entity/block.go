@@ -0,0 +1,11 @@
+package entity
+
+import "time"
+
+// Block stops two people seeing or reaching each other. It works both ways,
+// whichever of the pair blocked.
+type Block struct {
+	BlockerID int       `db:"blocker_id"`
+	BlockedID int       `db:"blocked_id"`
+	CreatedAt time.Time `db:"created_at"`
+}
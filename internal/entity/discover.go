@@ -0,0 +1,57 @@
+package entity
+
+import (
+	"math"
+	"time"
+)
+
+const (
+	DefaultDiscoverLimit = 20
+	MaxDiscoverLimit     = 100
+)
+
+// DiscoverCursor marks the last person returned in a discover page. Every page
+// of a session searches the same point in time of the index, ranked as of At and
+// measured from the same origin, so people's scores cannot change between pages
+// and the next page starts strictly after Sort, the last person's sort values.
+// The point in time expires when a session is left idle, after which the cursor
+// can no longer be used.
+type DiscoverCursor struct {
+	Lat  float64   `json:"lat"`
+	Lon  float64   `json:"lon"`
+	At   time.Time `json:"at"`
+	PIT  string    `json:"pit"`
+	Sort []float64 `json:"sort"`
+}
+
+// SearchResult is what a discover search found, and the point in time to search
+// for the next page, which can differ from the one searched.
+type SearchResult struct {
+	Users []User
+	PIT   string
+}
+
+// DiscoverPage is one page of the discover feed. Next is nil on the last page
+// and Strategy names the ranking strategy used.
+type DiscoverPage struct {
+	Users    []User
+	Next     *DiscoverCursor
+	Strategy string
+}
+
+// DistanceBucket rounds a distance in km up to the bucket shown to users: the
+// nearest km up to 10km, then 5km steps up to 50km and 10km steps beyond. Exact
+// distances would let someone searching from a few points work out where a
+// user is to well within the grid their location is stored on.
+func DistanceBucket(km float64) float64 {
+	switch {
+	case km <= 1:
+		return 1
+	case km <= 10:
+		return math.Ceil(km)
+	case km <= 50:
+		return math.Ceil(km/5) * 5
+	default:
+		return math.Ceil(km/10) * 10
+	}
+}
@@ -4,13 +4,47 @@ import (
 	"errors"
 )
 
-var ErrMatchAlreadyExists = errors.New("match already exists")
+var (
+	ErrMatchAlreadyExists = errors.New("match already exists")
+	ErrMatchNotFound      = errors.New("match does not exist")
+	ErrMatchEnded         = errors.New("match has ended")
+)
 
 var (
-	ErrEmailAlreadyExists = errors.New("user already exists")
-	ErrUserNotFound       = errors.New("user does not exists")
+	ErrEmailAlreadyExists   = errors.New("user already exists")
+	ErrUserNotFound         = errors.New("user does not exists")
+	ErrUserStatusTransition = errors.New("user cannot move to that status")
+	ErrVersionConflict      = errors.New("profile was changed since it was read")
+	ErrLocationThrottled    = errors.New("location was updated too recently")
 )
 
 var ErrForbidden = errors.New("forbidden")
 
+var ErrBlocked = errors.New("user is blocked")
+
+var (
+	ErrReportNotFound   = errors.New("report does not exist")
+	ErrReportTransition = errors.New("report cannot move to that status")
+)
+
+var ErrRefreshTokenNotFound = errors.New("refresh token does not exist")
+
+var ErrPreferencesNotFound = errors.New("preferences do not exist")
+
 var ErrInvalidParam = errors.New("invalid param")
+
+var ErrCursorExpired = errors.New("cursor has expired")
+
+var (
+	ErrSwipeNotFound         = errors.New("swipe does not exist")
+	ErrSwipeLocked           = errors.New("swipe can no longer be changed")
+	ErrRewindLimitReached    = errors.New("rewind limit reached")
+	ErrSuperLikeLimitReached = errors.New("super like limit reached")
+	ErrSwipeKeyNotFound      = errors.New("swipe key does not exist")
+	ErrSwipeKeyReused        = errors.New("swipe key was used for a different swipe")
+)
+
+var (
+	ErrIdempotencyKeyReused   = errors.New("idempotency key was used for a different request")
+	ErrIdempotencyKeyInFlight = errors.New("request with idempotency key is still in progress")
+)
@@ -0,0 +1,35 @@
+package entity
+
+import "time"
+
+const (
+	DefaultLikesLimit = 20
+	MaxLikesLimit     = 100
+)
+
+// ReceivedLike is a yes swipe someone gave the user.
+type ReceivedLike struct {
+	SwipeID int       `db:"id"`
+	UserID  int       `db:"user_id"`
+	Super   bool      `db:"super"`
+	LikedAt time.Time `db:"created_at"`
+}
+
+// Like is someone who swiped yes on the user, with their profile.
+type Like struct {
+	User    User      `json:"user"`
+	Super   bool      `json:"super"`
+	LikedAt time.Time `json:"liked_at"`
+}
+
+// LikesCursor marks the last like returned in a page. Likes are ordered newest
+// first, so the next page holds the likes with a lower swipe id.
+type LikesCursor struct {
+	SwipeID int `json:"id"`
+}
+
+// LikesPage is one page of received likes. Next is nil on the last page.
+type LikesPage struct {
+	Likes []Like
+	Next  *LikesCursor
+}
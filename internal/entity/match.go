@@ -4,18 +4,76 @@ import (
 	"crypto/sha256"
 	"encoding/hex"
 	"fmt"
+	"time"
+
+	null "github.com/guregu/null/v5"
+)
+
+const (
+	DefaultMatchesLimit = 20
+	MaxMatchesLimit     = 100
 )
 
 type Match struct {
-	ID      int    `db:"id" json:"id"`
-	User1ID int    `db:"user1_id" json:"user1_id"`
-	User2ID int    `db:"user2_id" json:"user2_id"`
-	MatchID string `db:"match_id" json:"match_id"`
+	ID        int       `db:"id" json:"id"`
+	User1ID   int       `db:"user1_id" json:"user1_id"`
+	User2ID   int       `db:"user2_id" json:"user2_id"`
+	MatchID   string    `db:"match_id" json:"match_id"`
+	CreatedAt time.Time `db:"created_at" json:"created_at"`
+	// UnmatchedAt is set once either of the pair unmatches, and UnmatchedBy is who did.
+	UnmatchedAt null.Time `db:"unmatched_at" json:"unmatched_at"`
+	UnmatchedBy null.Int  `db:"unmatched_by" json:"unmatched_by"`
+}
+
+// Includes reports whether userID is one of the pair.
+func (m Match) Includes(userID int) bool {
+	return m.User1ID == userID || m.User2ID == userID
+}
+
+// Other returns the id of whoever userID matched with.
+func (m Match) Other(userID int) int {
+	if m.User1ID == userID {
+		return m.User2ID
+	}
+
+	return m.User1ID
+}
+
+// MatchView is a match as seen by one of the pair, with the other person's profile.
+type MatchView struct {
+	ID        int       `json:"id"`
+	User      User      `json:"user"`
+	MatchedAt time.Time `json:"matched_at"`
+}
+
+// MatchesCursor marks the last match returned in a page. Matches are ordered
+// newest first, so the next page holds the matches with a lower id.
+type MatchesCursor struct {
+	ID int `json:"id"`
+}
+
+// MatchesPage is one page of matches. Next is nil on the last page.
+type MatchesPage struct {
+	Matches []MatchView
+	Next    *MatchesCursor
+}
+
+// NewMatch returns the match between two people. The pair is always stored
+// smaller id first, so it is the same whoever swiped last.
+func NewMatch(userID, otherID int) Match {
+	match := Match{User1ID: userID, User2ID: otherID}
+	if otherID < userID {
+		match.User1ID, match.User2ID = otherID, userID
+	}
+
+	match.GenerateMatchID()
+
+	return match
 }
 
-// GenerateMatchID generates a consistent hash for the match
+// GenerateMatchID generates a consistent hash for the match. The pair must
+// already be in order, as NewMatch does.
 func (m *Match) GenerateMatchID() {
-	// Ensure the order is consistent by always having the smaller ID first
 	matchData := fmt.Sprintf("%d:%d", m.User1ID, m.User2ID)
 
 	hash := sha256.Sum256([]byte(matchData))
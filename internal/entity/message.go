@@ -0,0 +1,63 @@
+package entity
+
+import (
+	"time"
+
+	validation "github.com/go-ozzo/ozzo-validation/v4"
+)
+
+const (
+	DefaultMessagesLimit = 20
+	MaxMessagesLimit     = 100
+	// MaxMessageLength is the most characters a message can have.
+	MaxMessageLength = 2000
+)
+
+// Message is one message in the conversation of a match. Read is set for the
+// user reading the conversation: for their own messages, whether the other
+// person has read it, otherwise whether they have read it themselves.
+type Message struct {
+	ID        int       `db:"id" json:"id"`
+	MatchID   int       `db:"match_id" json:"match_id"`
+	SenderID  int       `db:"sender_id" json:"sender_id"`
+	Body      string    `db:"body" json:"body"`
+	CreatedAt time.Time `db:"created_at" json:"created_at"`
+	Read      bool      `db:"-" json:"read"`
+}
+
+func (m Message) Validate() error {
+	return validation.ValidateStruct(&m,
+		validation.Field(&m.Body, validation.Required, validation.RuneLength(1, MaxMessageLength)),
+	)
+}
+
+// MessageRead is how far a user has read the conversation of a match: every
+// message up to and including LastReadID.
+type MessageRead struct {
+	MatchID    int       `db:"match_id"`
+	UserID     int       `db:"user_id"`
+	LastReadID int       `db:"last_read_id"`
+	ReadAt     time.Time `db:"read_at"`
+}
+
+// MessagesCursor marks the last message returned in a page. Messages are
+// ordered newest first, so the next page holds the messages with a lower id.
+type MessagesCursor struct {
+	ID int `json:"id"`
+}
+
+// MessagesPage is one page of a conversation. Next is nil on the last page.
+// Unread is how many messages from the other person the user has not read,
+// and OtherLastReadID how far the other person has read.
+type MessagesPage struct {
+	Messages        []Message
+	Next            *MessagesCursor
+	Unread          int
+	OtherLastReadID int
+}
+
+// UnreadCount is how many unread messages a user has in one match.
+type UnreadCount struct {
+	MatchID int `db:"match_id" json:"match_id"`
+	Unread  int `db:"unread" json:"unread"`
+}
@@ -0,0 +1,44 @@
+package entity
+
+import "time"
+
+const (
+	DefaultNotificationsLimit = 20
+	MaxNotificationsLimit     = 100
+)
+
+type NotificationType string
+
+const (
+	// NotificationSuperLike tells a user someone super liked them.
+	NotificationSuperLike NotificationType = "super_like.received"
+	// NotificationMatchCreated tells a user they matched with someone.
+	NotificationMatchCreated NotificationType = "match.created"
+	// NotificationMessageReceived tells a user they have a new message. It is
+	// only pushed, not kept in the inbox; unread counts cover it instead.
+	NotificationMessageReceived NotificationType = "message.received"
+)
+
+// Notification is something a user is told about. ActorID is who caused it, and
+// MatchID the match it is about, if any.
+type Notification struct {
+	ID        int              `db:"id" json:"id"`
+	UserID    int              `db:"user_id" json:"-"`
+	Type      NotificationType `db:"type" json:"type"`
+	ActorID   int              `db:"actor_id" json:"actor_id"`
+	MatchID   int              `db:"match_id" json:"match_id,omitempty"`
+	CreatedAt time.Time        `db:"created_at" json:"created_at"`
+}
+
+// NotificationsCursor marks the last notification returned in a page.
+// Notifications are ordered newest first, so the next page holds the
+// notifications with a lower id.
+type NotificationsCursor struct {
+	ID int `json:"id"`
+}
+
+// NotificationsPage is one page of notifications. Next is nil on the last page.
+type NotificationsPage struct {
+	Notifications []Notification
+	Next          *NotificationsCursor
+}
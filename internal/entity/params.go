@@ -1,17 +1,37 @@
 package entity
 
 import (
+	"time"
+
 	validation "github.com/go-ozzo/ozzo-validation/v4"
 	null "github.com/guregu/null/v5"
 )
 
 type SearchParams struct {
 	ExcludeUserIDs []int
-	MinAge         null.Int
-	MaxAge         null.Int
-	Gender         null.String
-	Lat            float64
-	Lon            float64
+	// ExcludeSwipedBy excludes everyone that user has swiped on.
+	ExcludeSwipedBy int
+	// BoostSuperLikersOf puts everyone who super liked that user first.
+	BoostSuperLikersOf int
+	MinAge             null.Int
+	MaxAge             null.Int
+	Gender             null.String
+	Genders            []string
+	MaxDistanceKm      null.Float
+	Lat                float64
+	Lon                float64
+	// SearcherAge and SearcherGender restrict results to people whose own
+	// preferences would include the searcher.
+	SearcherAge    null.Int
+	SearcherGender null.String
+	// Ranking weighs the signals results are ordered by.
+	Ranking RankingWeights
+	// Limit is the maximum number of results, and After the position to resume from.
+	Limit int
+	After *DiscoverCursor
+	// PIT is the point in time searched, and At the time people are ranked as of.
+	PIT string
+	At  time.Time
 }
 
 // IsZero determines whether the search arguments have values or not.
@@ -19,11 +39,34 @@ func (p SearchParams) IsZero() bool {
 	return !p.MinAge.Valid && !p.MaxAge.Valid && !p.Gender.Valid
 }
 
+// WithPreferences fills in any filter not given explicitly from saved preferences.
+func (p SearchParams) WithPreferences(prefs Preferences) SearchParams {
+	if !p.MinAge.Valid {
+		p.MinAge = prefs.MinAge
+	}
+
+	if !p.MaxAge.Valid {
+		p.MaxAge = prefs.MaxAge
+	}
+
+	if !p.Gender.Valid && len(p.Genders) == 0 {
+		p.Genders = prefs.Genders
+	}
+
+	if !p.MaxDistanceKm.Valid {
+		p.MaxDistanceKm = prefs.MaxDistanceKm
+	}
+
+	return p
+}
+
 func (p SearchParams) Validate() error {
 	return validation.ValidateStruct(&p,
 		validation.Field(&p.MinAge, validation.By(nonNegativeInt)),
 		validation.Field(&p.MaxAge, validation.By(nonNegativeInt)),
 		validation.Field(&p.Gender, validation.By(validGender)),
+		validation.Field(&p.MaxDistanceKm, validation.By(positiveFloat)),
+		validation.Field(&p.Limit, validation.Min(0), validation.Max(MaxDiscoverLimit)),
 	)
 }
 
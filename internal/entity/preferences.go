@@ -0,0 +1,60 @@
+package entity
+
+import (
+	validation "github.com/go-ozzo/ozzo-validation/v4"
+	null "github.com/guregu/null/v5"
+)
+
+// Preferences are who a user wants to be shown in discovery, and whether they
+// want to be shown to others at all. Unset fields do not restrict anything.
+type Preferences struct {
+	UserID        int        `json:"-"`
+	MinAge        null.Int   `json:"min_age"`
+	MaxAge        null.Int   `json:"max_age"`
+	Genders       []string   `json:"genders"`
+	MaxDistanceKm null.Float `json:"max_distance_km"`
+	ShowMe        bool       `json:"show_me"`
+}
+
+// DefaultPreferences are used for users who have not saved any.
+func DefaultPreferences(userID int) Preferences {
+	return Preferences{UserID: userID, Genders: []string{}, ShowMe: true}
+}
+
+func (p Preferences) Validate() error {
+	return validation.ValidateStruct(&p,
+		validation.Field(&p.MinAge, validation.By(minimumAge)),
+		validation.Field(&p.MaxAge, validation.By(minimumAge), validation.By(notBelow(p.MinAge))),
+		validation.Field(&p.Genders, validation.Each(validation.In("male", "female"))),
+		validation.Field(&p.MaxDistanceKm, validation.By(positiveFloat)),
+	)
+}
+
+// nolint:forcetypeassert
+func minimumAge(value interface{}) error {
+	if value.(null.Int).Valid && value.(null.Int).Int64 < MinimumAge {
+		return validation.NewError("validation_min_age", "must be at least 18")
+	}
+
+	return nil
+}
+
+func notBelow(minAge null.Int) validation.RuleFunc {
+	// nolint:forcetypeassert
+	return func(value interface{}) error {
+		if value.(null.Int).Valid && minAge.Valid && value.(null.Int).Int64 < minAge.Int64 {
+			return validation.NewError("validation_max_age", "must not be less than min_age")
+		}
+
+		return nil
+	}
+}
+
+// nolint:forcetypeassert
+func positiveFloat(value interface{}) error {
+	if value.(null.Float).Valid && value.(null.Float).Float64 <= 0 {
+		return validation.NewError("validation_positive", "must be positive")
+	}
+
+	return nil
+}
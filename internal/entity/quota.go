@@ -0,0 +1,59 @@
+package entity
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	null "github.com/guregu/null/v5"
+)
+
+var ErrQuotaExceeded = errors.New("quota exceeded")
+
+// QuotaRule allows Limit uses in any rolling Window.
+type QuotaRule struct {
+	Limit  int
+	Window time.Duration
+}
+
+// Quota is how much of a rule a user has used. ResetAt is when the next use
+// expires out of the window, and is null when nothing is used.
+type Quota struct {
+	Limit     int       `json:"limit"`
+	Used      int       `json:"used"`
+	Remaining int       `json:"remaining"`
+	ResetAt   null.Time `json:"reset_at"`
+}
+
+// NewQuota works out a quota from the times of the uses still in the window,
+// oldest first.
+func NewQuota(rule QuotaRule, uses []time.Time) Quota {
+	quota := Quota{
+		Limit:     rule.Limit,
+		Used:      len(uses),
+		Remaining: max(rule.Limit-len(uses), 0),
+	}
+
+	if len(uses) > 0 {
+		// Over the limit, e.g. after it was lowered, more than one use has to
+		// expire before there is one left.
+		next := max(len(uses)-rule.Limit, 0)
+		quota.ResetAt = null.TimeFrom(uses[next].Add(rule.Window).UTC())
+	}
+
+	return quota
+}
+
+// QuotaExceededError is returned when a quota has nothing left. It matches
+// ErrQuotaExceeded.
+type QuotaExceededError struct {
+	ResetAt time.Time
+}
+
+func (e QuotaExceededError) Error() string {
+	return fmt.Sprintf("%s until %s", ErrQuotaExceeded, e.ResetAt.Format(time.RFC3339))
+}
+
+func (e QuotaExceededError) Unwrap() error {
+	return ErrQuotaExceeded
+}
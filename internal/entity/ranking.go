@@ -0,0 +1,69 @@
+package entity
+
+import (
+	validation "github.com/go-ozzo/ozzo-validation/v4"
+)
+
+// RankingWeights weigh the signals discover results are ranked by. A zero
+// weight turns a signal off; with every weight zero results are nearest first.
+type RankingWeights struct {
+	// Distance favours people closer to the searcher.
+	Distance float64 `json:"distance"`
+	// AgeFit favours people whose age is near the middle of the searcher's preferred range.
+	AgeFit float64 `json:"age_fit"`
+	// Activity favours people who have used the app recently.
+	Activity float64 `json:"activity"`
+	// Completeness favours people who have filled in more of their profile.
+	Completeness float64 `json:"completeness"`
+	// Desirability favours people who get a high share of yes swipes.
+	Desirability float64 `json:"desirability"`
+}
+
+func (w RankingWeights) Validate() error {
+	return validation.ValidateStruct(&w,
+		validation.Field(&w.Distance, validation.Min(0.0)),
+		validation.Field(&w.AgeFit, validation.Min(0.0)),
+		validation.Field(&w.Activity, validation.Min(0.0)),
+		validation.Field(&w.Completeness, validation.Min(0.0)),
+		validation.Field(&w.Desirability, validation.Min(0.0)),
+	)
+}
+
+// RankingStrategy is a named set of weights. Several can be configured to A/B
+// test them against each other.
+type RankingStrategy struct {
+	Name    string         `json:"name"`
+	Weights RankingWeights `json:"weights"`
+}
+
+func (s RankingStrategy) Validate() error {
+	return validation.ValidateStruct(&s,
+		validation.Field(&s.Name, validation.Required),
+		validation.Field(&s.Weights),
+	)
+}
+
+// DefaultRankingStrategy is used when no strategy is configured.
+var DefaultRankingStrategy = RankingStrategy{
+	Name: "default",
+	Weights: RankingWeights{
+		Distance:     1,
+		AgeFit:       0.5,
+		Activity:     0.5,
+		Completeness: 0.25,
+		Desirability: 0.5,
+	},
+}
+
+// SwipeCounts are the swipes a user has received.
+type SwipeCounts struct {
+	TargetID int `db:"target_id"`
+	Yes      int `db:"yes"`
+	Total    int `db:"total"`
+}
+
+// Desirability is the share of yes swipes, smoothed so that people with few
+// swipes sit near the middle rather than at either extreme.
+func (c SwipeCounts) Desirability() float64 {
+	return float64(c.Yes+1) / float64(c.Total+2)
+}
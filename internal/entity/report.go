@@ -0,0 +1,111 @@
+package entity
+
+import (
+	"time"
+
+	validation "github.com/go-ozzo/ozzo-validation/v4"
+	null "github.com/guregu/null/v5"
+)
+
+// MaxReportDetailsLength is the most characters the free text of a report can have.
+const MaxReportDetailsLength = 1000
+
+const (
+	DefaultReportsLimit = 20
+	MaxReportsLimit     = 100
+)
+
+type ReportReason string
+
+const (
+	ReportReasonSpam          ReportReason = "spam"
+	ReportReasonHarassment    ReportReason = "harassment"
+	ReportReasonInappropriate ReportReason = "inappropriate_content"
+	ReportReasonFakeProfile   ReportReason = "fake_profile"
+	ReportReasonUnderage      ReportReason = "underage"
+	ReportReasonOther         ReportReason = "other"
+)
+
+// ReportStatus is where a report is in the moderation queue.
+type ReportStatus string
+
+const (
+	// ReportStatusOpen is a report waiting for a moderator.
+	ReportStatusOpen ReportStatus = "open"
+	// ReportStatusInReview is a report a moderator has picked up.
+	ReportStatusInReview ReportStatus = "in_review"
+	// ReportStatusActioned is a report that led to action against the user.
+	ReportStatusActioned ReportStatus = "actioned"
+	// ReportStatusDismissed is a report that needed no action.
+	ReportStatusDismissed ReportStatus = "dismissed"
+)
+
+// reportTransitions lists the statuses a report can move to from each status.
+// Actioned and dismissed reports are closed.
+var reportTransitions = map[ReportStatus][]ReportStatus{
+	ReportStatusOpen:     {ReportStatusInReview, ReportStatusActioned, ReportStatusDismissed},
+	ReportStatusInReview: {ReportStatusOpen, ReportStatusActioned, ReportStatusDismissed},
+}
+
+// Valid reports whether s is a known status.
+func (s ReportStatus) Valid() bool {
+	switch s {
+	case ReportStatusOpen, ReportStatusInReview, ReportStatusActioned, ReportStatusDismissed:
+		return true
+	default:
+		return false
+	}
+}
+
+// CanTransitionTo reports whether a report can move from s to status.
+func (s ReportStatus) CanTransitionTo(status ReportStatus) bool {
+	for _, next := range reportTransitions[s] {
+		if next == status {
+			return true
+		}
+	}
+
+	return false
+}
+
+// Report is a complaint about a user, queued for moderators. ReviewedBy is the
+// moderator who last changed its status.
+type Report struct {
+	ID         int          `db:"id" json:"id"`
+	ReporterID int          `db:"reporter_id" json:"reporter_id"`
+	ReportedID int          `db:"reported_id" json:"reported_id"`
+	Reason     ReportReason `db:"reason" json:"reason"`
+	Details    string       `db:"details" json:"details"`
+	Status     ReportStatus `db:"status" json:"status"`
+	ReviewedBy null.Int     `db:"reviewed_by" json:"reviewed_by"`
+	CreatedAt  time.Time    `db:"created_at" json:"created_at"`
+	UpdatedAt  time.Time    `db:"updated_at" json:"updated_at"`
+}
+
+func (r Report) Validate() error {
+	return validation.ValidateStruct(&r,
+		validation.Field(&r.ReportedID, validation.Required, validation.Min(1)),
+		validation.Field(&r.Reason, validation.Required, validation.In(
+			ReportReasonSpam,
+			ReportReasonHarassment,
+			ReportReasonInappropriate,
+			ReportReasonFakeProfile,
+			ReportReasonUnderage,
+			ReportReasonOther,
+		)),
+		validation.Field(&r.Details, validation.RuneLength(0, MaxReportDetailsLength)),
+	)
+}
+
+// ReportsCursor marks the last report returned in a page of the moderation
+// queue. Reports are ordered oldest first, so the next page holds the reports
+// with a higher id.
+type ReportsCursor struct {
+	ID int `json:"id"`
+}
+
+// ReportsPage is one page of the moderation queue. Next is nil on the last page.
+type ReportsPage struct {
+	Reports []Report
+	Next    *ReportsCursor
+}
@@ -0,0 +1,57 @@
+package entity
+
+// Role is what a user is allowed to do. Moderators and admins are staff and can
+// use the admin API; only admins can ban and change roles.
+type Role string
+
+const (
+	RoleUser      Role = "user"
+	RoleModerator Role = "moderator"
+	RoleAdmin     Role = "admin"
+)
+
+// Valid reports whether r is a known role.
+func (r Role) Valid() bool {
+	switch r {
+	case RoleUser, RoleModerator, RoleAdmin:
+		return true
+	default:
+		return false
+	}
+}
+
+// IsStaff reports whether the role can use the admin API.
+func (r Role) IsStaff() bool {
+	return r == RoleModerator || r == RoleAdmin
+}
+
+// UserStatus is whether a user can use the app.
+type UserStatus string
+
+const (
+	// UserStatusActive is a user in good standing.
+	UserStatusActive UserStatus = "active"
+	// UserStatusSuspended is a user a moderator has taken out of the app until
+	// they are reinstated.
+	UserStatusSuspended UserStatus = "suspended"
+	// UserStatusBanned is a user an admin has taken out of the app for good.
+	UserStatusBanned UserStatus = "banned"
+)
+
+// userStatusTransitions lists the statuses a user can move to from each
+// status. Bans are final.
+var userStatusTransitions = map[UserStatus][]UserStatus{
+	UserStatusActive:    {UserStatusSuspended, UserStatusBanned},
+	UserStatusSuspended: {UserStatusActive, UserStatusBanned},
+}
+
+// CanTransitionTo reports whether a user can move from s to status.
+func (s UserStatus) CanTransitionTo(status UserStatus) bool {
+	for _, next := range userStatusTransitions[s] {
+		if next == status {
+			return true
+		}
+	}
+
+	return false
+}
@@ -1,6 +1,8 @@
 package entity
 
 import (
+	"time"
+
 	validation "github.com/go-ozzo/ozzo-validation/v4"
 )
 
@@ -16,12 +18,73 @@ type Swipe struct {
 const (
 	PreferenceYes Preference = "yes"
 	PreferenceNo  Preference = "no"
+	// PreferenceSuper is a yes that the target is told about straight away.
+	PreferenceSuper Preference = "super"
 )
 
+// IsLike reports whether the preference counts as a yes for matching.
+func (p Preference) IsLike() bool {
+	return p == PreferenceYes || p == PreferenceSuper
+}
+
 func (s Swipe) Validate() error {
 	return validation.ValidateStruct(&s,
 		validation.Field(&s.UserID, validation.Required, validation.Min(1)),
 		validation.Field(&s.TargetID, validation.Required, validation.Min(1)),
-		validation.Field(&s.Preference, validation.Required, validation.In(PreferenceYes, PreferenceNo)),
+		validation.Field(&s.Preference, validation.Required, validation.In(PreferenceYes, PreferenceNo, PreferenceSuper)),
 	)
 }
+
+// SwipeAction is something that happened to a swipe.
+type SwipeAction string
+
+const (
+	SwipeActionSwipe   SwipeAction = "SWIPE"
+	SwipeActionReswipe SwipeAction = "RESWIPE"
+	SwipeActionRewind  SwipeAction = "REWIND"
+)
+
+// SwipeEvent is an entry in a user's swipe history.
+type SwipeEvent struct {
+	UserID     int         `db:"user_id"`
+	TargetID   int         `db:"target_id"`
+	Preference Preference  `db:"preference"`
+	Action     SwipeAction `db:"action"`
+	CreatedAt  time.Time   `db:"created_at"`
+}
+
+const (
+	// MaxSwipeBatchSize is the most swipes a client can send at once.
+	MaxSwipeBatchSize = 100
+	// MaxSwipeClockSkew is how far ahead of the server a client's clock can be.
+	MaxSwipeClockSkew = 5 * time.Minute
+	maxSwipeKeyLength = 64
+)
+
+// BatchSwipe is a swipe a client queued, e.g. while offline. Key is chosen by
+// the client, and sending the same key again replays the first result instead
+// of swiping again.
+type BatchSwipe struct {
+	Key        string     `json:"key"`
+	TargetID   int        `json:"target_id"`
+	Preference Preference `json:"preference"`
+	SwipedAt   time.Time  `json:"swiped_at"`
+}
+
+func (s BatchSwipe) Validate() error {
+	return validation.ValidateStruct(&s,
+		validation.Field(&s.Key, validation.Required, validation.Length(1, maxSwipeKeyLength)),
+		validation.Field(&s.TargetID, validation.Required, validation.Min(1)),
+		validation.Field(&s.Preference, validation.Required, validation.In(PreferenceYes, PreferenceNo, PreferenceSuper)),
+		validation.Field(&s.SwipedAt, validation.Required, validation.Max(time.Now().Add(MaxSwipeClockSkew))),
+	)
+}
+
+// SwipeKey is the result of a batched swipe, kept under the client's key.
+type SwipeKey struct {
+	UserID     int        `db:"user_id"`
+	Key        string     `db:"swipe_key"`
+	TargetID   int        `db:"target_id"`
+	Preference Preference `db:"preference"`
+	MatchID    int        `db:"match_id"`
+}
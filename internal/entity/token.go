@@ -0,0 +1,28 @@
+package entity
+
+import (
+	"time"
+
+	null "github.com/guregu/null/v5"
+)
+
+// RefreshToken is a stored, single-use refresh token. Tokens issued by
+// rotating one another share a FamilyID, which starts at login.
+type RefreshToken struct {
+	ID              int       `db:"id"`
+	FamilyID        string    `db:"family_id"`
+	UserID          int       `db:"user_id"`
+	TokenHash       string    `db:"token_hash"`
+	AccessJTI       string    `db:"access_jti"`
+	AccessExpiresAt time.Time `db:"access_expires_at"`
+	ExpiresAt       time.Time `db:"expires_at"`
+	RotatedAt       null.Time `db:"rotated_at"`
+	RevokedAt       null.Time `db:"revoked_at"`
+}
+
+// TokenPair is what a client receives after logging in or refreshing.
+type TokenPair struct {
+	AccessToken  string
+	RefreshToken string
+	ExpiresIn    time.Duration
+}
@@ -1,17 +1,114 @@
 package entity
 
+import (
+	"math"
+	"time"
+
+	validation "github.com/go-ozzo/ozzo-validation/v4"
+	null "github.com/guregu/null/v5"
+)
+
+// MinimumAge is the youngest a user can be to register.
+const MinimumAge = 18
+
+// User is the public profile of a user: what other people are allowed to see of
+// them. Account details such as their email and password are kept off it, so
+// they cannot end up in another user's discover results, likes or matches.
 type User struct {
 	ID             int      `json:"id"`
-	Email          string   `json:"email"`
-	Password       string   `json:"password"`
 	Name           string   `json:"name"`
+	Bio            string   `json:"bio"`
 	Gender         string   `json:"gender"`
 	Age            int      `json:"age"`
 	Location       Location `json:"location"`
 	DistanceFromMe float64  `json:"distanceFromMe,omitempty"`
+	// Score is how highly the user was ranked in discover results, and Sort
+	// their position in them.
+	Score float64   `json:"-"`
+	Sort  []float64 `json:"-"`
+}
+
+// Completeness is the share of optional profile details the user has filled in.
+// Name, gender, date of birth and location are all required, so only the bio
+// can be missing.
+func (u User) Completeness() float64 {
+	filled := 0
+	details := []bool{
+		u.Bio != "",
+	}
+
+	for _, ok := range details {
+		if ok {
+			filled++
+		}
+	}
+
+	return float64(filled) / float64(len(details))
+}
+
+// MaxBioLength is the most characters a bio can have.
+const MaxBioLength = 500
+
+// ProfileUpdate holds the profile details a user is changing. Details that are
+// not set are left as they are. Version is the version of the profile the
+// change was made to. Location is not one of them; users move through
+// Manager.UpdateLocation, which throttles it.
+type ProfileUpdate struct {
+	Name        null.String
+	Bio         null.String
+	Gender      null.String
+	DateOfBirth null.Time
+	Version     int
 }
 
 type Location struct {
 	Lat float64 `json:"lat"`
 	Lon float64 `json:"lon"`
 }
+
+// locationPrecision is how many grid lines per degree locations are snapped to
+// before they are stored. A hundredth of a degree is about 1km.
+const locationPrecision = 100
+
+// LocationUpdateInterval is how long a user has to wait between moving to a new
+// grid cell, so a client reporting its position in a tight loop cannot flood
+// MySQL and Elasticsearch with writes.
+const LocationUpdateInterval = time.Minute
+
+// Fuzzed returns the location snapped to the grid locations are stored on. It is
+// snapped rather than jittered, so repeated updates from the same place cannot
+// be averaged back to where the user really is.
+func (l Location) Fuzzed() Location {
+	return Location{
+		Lat: math.Round(l.Lat*locationPrecision) / locationPrecision,
+		Lon: math.Round(l.Lon*locationPrecision) / locationPrecision,
+	}
+}
+
+func (l Location) Validate() error {
+	return validation.ValidateStruct(&l,
+		validation.Field(&l.Lat, validation.Min(-90.0), validation.Max(90.0)),
+		validation.Field(&l.Lon, validation.Min(-180.0), validation.Max(180.0)),
+	)
+}
+
+// Registration holds the details a person signs up with.
+type Registration struct {
+	Email       string
+	Password    string
+	Name        string
+	Gender      string
+	DateOfBirth time.Time
+	Location    Location
+}
+
+// AgeAt returns the age in whole years of someone born on dob at the given time.
+func AgeAt(dob, at time.Time) int {
+	age := at.Year() - dob.Year()
+
+	if at.Month() < dob.Month() || (at.Month() == dob.Month() && at.Day() < dob.Day()) {
+		age--
+	}
+
+	return age
+}
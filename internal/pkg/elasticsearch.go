@@ -1,9 +1,12 @@
 package pkg
 
 import (
+	"bytes"
 	"context"
+	"encoding/json"
 	"fmt"
 	"github.com/colmmurphy91/muzz/internal/pkg/envvar"
+	"net/http"
 	"strings"
 
 	esv7 "github.com/elastic/go-elasticsearch/v7"
@@ -38,6 +41,15 @@ func NewElasticSearch(conf *envvar.Configuration) (es *esv7.Client, err error) {
 	return es, nil
 }
 
+// CreateIndices creates the indices the service needs, leaving any that already exist untouched.
+func CreateIndices(es *esv7.Client) error {
+	if err := CreateUsersIndex(es); err != nil {
+		return err
+	}
+
+	return CreateSwipedIndex(es)
+}
+
 func CreateUsersIndex(es *esv7.Client) error {
 	mapping := `{
 		"settings": {
@@ -47,17 +59,48 @@ func CreateUsersIndex(es *esv7.Client) error {
 		"mappings": {
 			"properties": {
 				"id": { "type": "integer" },
-				"email": { "type": "keyword" },
 				"name": { "type": "text" },
+				"bio": { "type": "text" },
 				"gender": { "type": "keyword" },
 				"age": { "type": "integer" },
-				"location": { "type": "geo_point" }
+				"location": { "type": "geo_point" },
+				"pref_min_age": { "type": "integer" },
+				"pref_max_age": { "type": "integer" },
+				"pref_genders": { "type": "keyword" },
+				"pref_max_distance_km": { "type": "double" },
+				"show_me": { "type": "boolean" },
+				"completeness": { "type": "float" },
+				"last_active_at": { "type": "date" },
+				"shadow_banned": { "type": "boolean" },
+				"super_liked": { "type": "integer" }
 			}
 		}
 	}`
 
+	return createIndex(es, "users", mapping)
+}
+
+// CreateSwipedIndex creates the index holding, per user, the ids of everyone they have swiped on.
+// The ids are only read through terms lookups, so they are stored but not indexed.
+func CreateSwipedIndex(es *esv7.Client) error {
+	mapping := `{
+		"settings": {
+			"number_of_shards": 1,
+			"number_of_replicas": 0
+		},
+		"mappings": {
+			"properties": {
+				"target_ids": { "type": "integer", "index": false }
+			}
+		}
+	}`
+
+	return createIndex(es, "swiped", mapping)
+}
+
+func createIndex(es *esv7.Client, index, mapping string) error {
 	req := esv7api.IndicesCreateRequest{
-		Index: "users",
+		Index: index,
 		Body:  strings.NewReader(mapping),
 	}
 
@@ -68,7 +111,39 @@ func CreateUsersIndex(es *esv7.Client) error {
 	defer res.Body.Close()
 
 	if res.IsError() {
-		return fmt.Errorf("failed to create index: %s", res.String())
+		if res.StatusCode == http.StatusBadRequest && strings.Contains(res.String(), "resource_already_exists_exception") {
+			return updateMapping(es, index, mapping)
+		}
+
+		return fmt.Errorf("failed to create index %s: %s", index, res.String())
+	}
+
+	return nil
+}
+
+// updateMapping adds fields introduced since an existing index was created.
+func updateMapping(es *esv7.Client, index, mapping string) error {
+	var body struct {
+		Mappings json.RawMessage `json:"mappings"`
+	}
+
+	if err := json.Unmarshal([]byte(mapping), &body); err != nil {
+		return fmt.Errorf("failed to decode mapping: %w", err)
+	}
+
+	req := esv7api.IndicesPutMappingRequest{
+		Index: []string{index},
+		Body:  bytes.NewReader(body.Mappings),
+	}
+
+	res, err := req.Do(context.Background(), es)
+	if err != nil {
+		return fmt.Errorf("failed to update mapping: %w", err)
+	}
+	defer res.Body.Close()
+
+	if res.IsError() {
+		return fmt.Errorf("failed to update mapping of %s: %s", index, res.String())
 	}
 
 	return nil
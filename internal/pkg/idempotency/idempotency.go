@@ -0,0 +1,205 @@
+// Package idempotency lets clients retry requests safely. A request sent with an
+// Idempotency-Key header is handled once; sending the key again replays the
+// first response instead of handling the request again.
+package idempotency
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+	"time"
+
+	"github.com/colmmurphy91/muzz/internal/api/response"
+	"github.com/colmmurphy91/muzz/internal/entity"
+	"github.com/colmmurphy91/muzz/internal/pkg"
+)
+
+const (
+	// Header is the request header holding the client's key.
+	Header = "Idempotency-Key"
+	// ReplayedHeader is set on responses replayed from an earlier request.
+	ReplayedHeader = "Idempotent-Replayed"
+	// DefaultTTL is how long responses are kept unless configured.
+	DefaultTTL = 24 * time.Hour
+
+	maxKeyLength = 255
+)
+
+// Response is a response kept to be replayed.
+type Response struct {
+	Status int
+	Body   []byte
+}
+
+// Record is what is kept under a key: the fingerprint of the request that
+// claimed it and, once that request has finished, its response.
+type Record struct {
+	Fingerprint string
+	Response    *Response
+}
+
+// Store keeps responses under their keys until they expire.
+type Store interface {
+	// Reserve claims key for the request with fingerprint until ttl has passed.
+	// When the key is already claimed it returns the claim instead, and false.
+	Reserve(ctx context.Context, key, fingerprint string, ttl time.Duration) (Record, bool, error)
+	// Complete keeps the response to the request that claimed key.
+	Complete(ctx context.Context, key string, resp Response) error
+	// Release gives up a claim whose request failed, so it can be retried.
+	Release(ctx context.Context, key string) error
+}
+
+// Middleware handles requests with an Idempotency-Key header once per key.
+// Replays get the first response byte for byte, a key sent again with a
+// different request fails with entity.ErrIdempotencyKeyReused, and one sent
+// while the first request is still being handled fails with
+// entity.ErrIdempotencyKeyInFlight. Only final outcomes are kept: server
+// errors and responses asking the client to try again later are not, so the
+// request can be retried.
+//
+// Keys are scoped to the user, so it goes after AuthMiddleware on routes that
+// need it. Anonymous requests have no user, so their keys are scoped to the
+// request itself: only the same request sent again replays the response.
+func Middleware(store Store, ttl time.Duration) func(next http.Handler) http.Handler {
+	if ttl <= 0 {
+		ttl = DefaultTTL
+	}
+
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			key := r.Header.Get(Header)
+			if key == "" || r.Method == http.MethodGet || r.Method == http.MethodHead {
+				next.ServeHTTP(w, r)
+				return
+			}
+
+			if len(key) > maxKeyLength {
+				err := fmt.Errorf("%s is longer than %d characters: %w", Header, maxKeyLength, entity.ErrInvalidParam)
+				response.RenderErrorResponse(w, "invalid idempotency key", err)
+
+				return
+			}
+
+			body, err := io.ReadAll(r.Body)
+			if err != nil {
+				response.RenderErrorResponse(w, "invalid body", entity.ErrInvalidParam)
+				return
+			}
+
+			r.Body = io.NopCloser(bytes.NewReader(body))
+
+			fingerprint := hash(fmt.Sprintf("%s %s\n%s", r.Method, r.URL.Path, body))
+
+			scope := "request " + fingerprint
+			if userID, ok := r.Context().Value(pkg.CTXUserKey).(int); ok {
+				scope = fmt.Sprintf("user %d", userID)
+			}
+
+			scopedKey := hash(fmt.Sprintf("%s\n%s", scope, key))
+
+			record, reserved, err := store.Reserve(r.Context(), scopedKey, fingerprint, ttl)
+			if err != nil {
+				response.RenderErrorResponse(w, "failed to check idempotency key", err)
+				return
+			}
+
+			if !reserved {
+				replay(w, record, fingerprint)
+				return
+			}
+
+			recorder := &recorder{ResponseWriter: w, status: http.StatusOK}
+
+			defer func() {
+				// Background, so the response is kept even if the client has gone.
+				ctx := context.Background()
+
+				if p := recover(); p != nil {
+					release(ctx, store, scopedKey)
+					panic(p)
+				}
+
+				if !final(recorder.status) {
+					release(ctx, store, scopedKey)
+					return
+				}
+
+				resp := Response{Status: recorder.status, Body: recorder.body.Bytes()}
+
+				if err := store.Complete(ctx, scopedKey, resp); err != nil {
+					log.Printf("Failed to keep idempotent response: %v", err)
+					release(ctx, store, scopedKey)
+				}
+			}()
+
+			next.ServeHTTP(recorder, r)
+		})
+	}
+}
+
+func replay(w http.ResponseWriter, record Record, fingerprint string) {
+	switch {
+	case record.Fingerprint != fingerprint:
+		response.RenderErrorResponse(w, "idempotency key reused", entity.ErrIdempotencyKeyReused)
+	case record.Response == nil:
+		response.RenderErrorResponse(w, "request in progress", entity.ErrIdempotencyKeyInFlight)
+	default:
+		w.Header().Set(ReplayedHeader, "true")
+		response.RenderRaw(w, record.Response.Body, record.Response.Status)
+	}
+}
+
+// final reports whether a response is the request's outcome, rather than one
+// saying it could not be handled yet, such as a conflict with a request still
+// in progress or a rate limit.
+func final(status int) bool {
+	switch status {
+	case http.StatusRequestTimeout, http.StatusConflict, http.StatusTooEarly, http.StatusTooManyRequests:
+		return false
+	default:
+		return status < http.StatusInternalServerError
+	}
+}
+
+// release gives up a claim. If that fails too, retries are refused until the
+// claim expires.
+func release(ctx context.Context, store Store, key string) {
+	if err := store.Release(ctx, key); err != nil {
+		log.Printf("Failed to release idempotency key: %v", err)
+	}
+}
+
+func hash(value string) string {
+	sum := sha256.Sum256([]byte(value))
+
+	return hex.EncodeToString(sum[:])
+}
+
+// recorder passes a response through while keeping a copy of it.
+type recorder struct {
+	http.ResponseWriter
+	status      int
+	wroteHeader bool
+	body        bytes.Buffer
+}
+
+func (r *recorder) WriteHeader(status int) {
+	if !r.wroteHeader {
+		r.status = status
+		r.wroteHeader = true
+	}
+
+	r.ResponseWriter.WriteHeader(status)
+}
+
+func (r *recorder) Write(content []byte) (int, error) {
+	r.wroteHeader = true
+	r.body.Write(content)
+
+	return r.ResponseWriter.Write(content) //nolint:wrapcheck
+}
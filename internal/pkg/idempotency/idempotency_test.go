@@ -0,0 +1,132 @@
+package idempotency_test
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	memory "github.com/colmmurphy91/muzz/internal/adapter/memory/idempotency"
+	"github.com/colmmurphy91/muzz/internal/api/response"
+	"github.com/colmmurphy91/muzz/internal/pkg"
+	"github.com/colmmurphy91/muzz/internal/pkg/idempotency"
+)
+
+func TestMiddleware(t *testing.T) {
+	calls := 0
+	handler := idempotency.Middleware(memory.NewStore(), time.Hour)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls++
+
+		if strings.Contains(r.URL.Path, "fail") {
+			response.RenderResponse(w, map[string]string{"error": "boom"}, http.StatusInternalServerError)
+			return
+		}
+
+		if strings.Contains(r.URL.Path, "busy") {
+			response.RenderResponse(w, map[string]string{"error": "slow down"}, http.StatusTooManyRequests)
+			return
+		}
+
+		response.RenderResponse(w, map[string]interface{}{"call": calls, "at": time.Now().UnixNano()}, http.StatusCreated)
+	}))
+
+	send := func(userID int, path, key, body string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(http.MethodPost, path, strings.NewReader(body))
+
+		if userID > 0 {
+			req = req.WithContext(context.WithValue(req.Context(), pkg.CTXUserKey, userID))
+		}
+
+		if key != "" {
+			req.Header.Set(idempotency.Header, key)
+		}
+
+		rec := httptest.NewRecorder()
+		handler.ServeHTTP(rec, req)
+
+		return rec
+	}
+
+	first := send(1, "/swipe", "key-1", `{"target_id":2}`)
+	assert.Equal(t, http.StatusCreated, first.Code)
+	assert.Equal(t, 1, calls)
+
+	replay := send(1, "/swipe", "key-1", `{"target_id":2}`)
+	assert.Equal(t, http.StatusCreated, replay.Code)
+	assert.Equal(t, first.Body.Bytes(), replay.Body.Bytes())
+	assert.Equal(t, "true", replay.Header().Get(idempotency.ReplayedHeader))
+	assert.Equal(t, 1, calls)
+
+	reused := send(1, "/swipe", "key-1", `{"target_id":3}`)
+	assert.Equal(t, http.StatusUnprocessableEntity, reused.Code)
+	assert.Equal(t, 1, calls)
+
+	// Keys belong to the user that sent them.
+	other := send(2, "/swipe", "key-1", `{"target_id":2}`)
+	assert.Equal(t, http.StatusCreated, other.Code)
+	assert.Equal(t, 2, calls)
+
+	// Without a key every request is handled.
+	send(1, "/swipe", "", `{"target_id":2}`)
+	assert.Equal(t, 3, calls)
+
+	// Server errors are not kept, so the request can be retried.
+	send(1, "/fail", "key-2", `{}`)
+	send(1, "/fail", "key-2", `{}`)
+	assert.Equal(t, 5, calls)
+
+	// Nor are responses asking the client to try again later.
+	send(1, "/busy", "key-3", `{}`)
+	send(1, "/busy", "key-3", `{}`)
+	assert.Equal(t, 7, calls)
+
+	// Anonymous keys are scoped to the request, so only the same request
+	// replays, and another one with the same key is handled as its own.
+	anonymous := send(0, "/user/create", "key-1", `{"email":"a@example.com"}`)
+	assert.Equal(t, http.StatusCreated, anonymous.Code)
+	assert.Equal(t, 8, calls)
+
+	anonymousReplay := send(0, "/user/create", "key-1", `{"email":"a@example.com"}`)
+	assert.Equal(t, anonymous.Body.Bytes(), anonymousReplay.Body.Bytes())
+	assert.Equal(t, 8, calls)
+
+	someoneElse := send(0, "/user/create", "key-1", `{"email":"b@example.com"}`)
+	assert.Equal(t, http.StatusCreated, someoneElse.Code)
+	assert.Equal(t, 9, calls)
+}
+
+func TestMiddleware_InFlight(t *testing.T) {
+	store := memory.NewStore()
+	started, finish := make(chan struct{}), make(chan struct{})
+
+	handler := idempotency.Middleware(store, time.Hour)(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		close(started)
+		<-finish
+		response.RenderResponse(w, map[string]bool{"ok": true}, http.StatusOK)
+	}))
+
+	send := func() *httptest.ResponseRecorder {
+		req := httptest.NewRequest(http.MethodPost, "/user/create", strings.NewReader(`{}`))
+		req.Header.Set(idempotency.Header, "key")
+
+		rec := httptest.NewRecorder()
+		handler.ServeHTTP(rec, req)
+
+		return rec
+	}
+
+	done := make(chan *httptest.ResponseRecorder)
+
+	go func() { done <- send() }()
+
+	<-started
+
+	assert.Equal(t, http.StatusConflict, send().Code)
+
+	close(finish)
+	assert.Equal(t, http.StatusOK, (<-done).Code)
+}
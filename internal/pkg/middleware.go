@@ -2,31 +2,43 @@ package pkg
 
 import (
 	"context"
-	"fmt"
 	"net/http"
 	"strings"
 
 	jwt "github.com/golang-jwt/jwt/v4"
 )
 
-var jwtSecret string
+var (
+	jwtKeyfunc  jwt.Keyfunc
+	tokenDenier TokenDenyList
+)
+
+// TokenDenyList reports whether an access token has been revoked before its expiry.
+type TokenDenyList interface {
+	IsRevoked(ctx context.Context, jti string) (bool, error)
+}
 
-// Initialize the JWT middleware with a secret key
-func InitAuth(secret string) {
-	jwtSecret = secret
+// Initialize the JWT middleware with the verification keys and the list of revoked tokens
+func InitAuth(keyfunc jwt.Keyfunc, denyList TokenDenyList) {
+	jwtKeyfunc = keyfunc
+	tokenDenier = denyList
 }
 
-// CustomClaims defines custom JWT claims
+// CustomClaims defines custom JWT claims. Role is what the user was allowed to
+// do when the token was issued; tokens from before roles existed have none.
 type CustomClaims struct {
-	UserID int `json:"user_id"`
+	UserID int    `json:"user_id"`
+	Role   string `json:"role"`
 	jwt.RegisteredClaims
 }
 
 type contextKey string
 
-const CTXUserKey contextKey = "user"
+const (
+	CTXUserKey   contextKey = "user"
+	CTXClaimsKey contextKey = "claims"
+)
 
-// AuthMiddleware verifies the JWT token
 // AuthMiddleware verifies the JWT token
 func AuthMiddleware(next http.Handler) http.Handler {
 	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
@@ -39,21 +51,52 @@ func AuthMiddleware(next http.Handler) http.Handler {
 		tokenString := strings.TrimPrefix(authHeader, "Bearer ")
 		claims := &CustomClaims{}
 
-		token, err := jwt.ParseWithClaims(tokenString, claims, func(token *jwt.Token) (interface{}, error) {
-			if _, ok := token.Method.(*jwt.SigningMethodHMAC); !ok {
-				return nil, fmt.Errorf("unexpected signing method: %v", token.Header["alg"])
-			}
-
-			return []byte(jwtSecret), nil
-		})
+		token, err := jwt.ParseWithClaims(tokenString, claims, jwtKeyfunc)
 
-		if err != nil || !token.Valid {
+		if err != nil || !token.Valid || claims.ID == "" || claims.ExpiresAt == nil {
 			http.Error(w, "Invalid token", http.StatusUnauthorized)
 			return
 		}
 
+		if tokenDenier != nil {
+			revoked, err := tokenDenier.IsRevoked(r.Context(), claims.ID)
+			if err != nil {
+				http.Error(w, "Unable to verify token", http.StatusInternalServerError)
+				return
+			}
+
+			if revoked {
+				http.Error(w, "Token revoked", http.StatusUnauthorized)
+				return
+			}
+		}
+
 		// Store the user ID from the token into the context
 		ctx := context.WithValue(r.Context(), CTXUserKey, claims.UserID)
+		ctx = context.WithValue(ctx, CTXClaimsKey, claims)
 		next.ServeHTTP(w, r.WithContext(ctx))
 	})
 }
+
+// RequireRole only lets through callers whose token carries one of roles. It
+// must run after AuthMiddleware.
+func RequireRole(roles ...string) func(next http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			claims, ok := r.Context().Value(CTXClaimsKey).(*CustomClaims)
+			if !ok {
+				http.Error(w, "Authorization required", http.StatusUnauthorized)
+				return
+			}
+
+			for _, role := range roles {
+				if claims.Role == role {
+					next.ServeHTTP(w, r)
+					return
+				}
+			}
+
+			http.Error(w, "Insufficient role", http.StatusForbidden)
+		})
+	}
+}
@@ -0,0 +1,38 @@
+package pkg_test
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/colmmurphy91/muzz/internal/pkg"
+)
+
+func TestRequireRole(t *testing.T) {
+	handler := pkg.RequireRole("moderator", "admin")(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNoContent)
+	}))
+
+	send := func(claims *pkg.CustomClaims) int {
+		req := httptest.NewRequest(http.MethodGet, "/admin/reports", nil)
+
+		if claims != nil {
+			req = req.WithContext(context.WithValue(req.Context(), pkg.CTXClaimsKey, claims))
+		}
+
+		rec := httptest.NewRecorder()
+		handler.ServeHTTP(rec, req)
+
+		return rec.Code
+	}
+
+	assert.Equal(t, http.StatusNoContent, send(&pkg.CustomClaims{UserID: 1, Role: "moderator"}))
+	assert.Equal(t, http.StatusNoContent, send(&pkg.CustomClaims{UserID: 1, Role: "admin"}))
+	assert.Equal(t, http.StatusForbidden, send(&pkg.CustomClaims{UserID: 1, Role: "user"}))
+	// Tokens issued before roles existed carry none.
+	assert.Equal(t, http.StatusForbidden, send(&pkg.CustomClaims{UserID: 1}))
+	assert.Equal(t, http.StatusUnauthorized, send(nil))
+}
@@ -0,0 +1,53 @@
+package password
+
+import (
+	"fmt"
+	"strconv"
+
+	"github.com/colmmurphy91/muzz/internal/pkg/envvar"
+)
+
+const (
+	AlgorithmBcrypt   = "bcrypt"
+	AlgorithmArgon2id = "argon2id"
+)
+
+// New builds an Upgrader from configuration. PASSWORD_HASHER selects the
+// algorithm new hashes are produced with; the other algorithm is still
+// accepted on login. PASSWORD_ALLOW_PLAINTEXT keeps rows written before hashing
+// was introduced usable until they have all been upgraded.
+func New(conf envvar.Provider) (*Upgrader, error) {
+	bcryptCost := 0
+
+	if costValue := conf.Get("BCRYPT_COST"); costValue != "" {
+		cost, err := strconv.Atoi(costValue)
+		if err != nil {
+			return nil, fmt.Errorf("invalid BCRYPT_COST: %w", err)
+		}
+
+		bcryptCost = cost
+	}
+
+	bcryptHasher := NewBcrypt(bcryptCost)
+	argon2Hasher := NewArgon2id(DefaultArgon2Params)
+
+	var (
+		current Hasher
+		legacy  []Hasher
+	)
+
+	switch algorithm := conf.Get("PASSWORD_HASHER"); algorithm {
+	case AlgorithmArgon2id, "":
+		current, legacy = argon2Hasher, []Hasher{bcryptHasher}
+	case AlgorithmBcrypt:
+		current, legacy = bcryptHasher, []Hasher{argon2Hasher}
+	default:
+		return nil, fmt.Errorf("unsupported PASSWORD_HASHER %q", algorithm)
+	}
+
+	if allow, _ := strconv.ParseBool(conf.Get("PASSWORD_ALLOW_PLAINTEXT")); allow {
+		legacy = append(legacy, Plaintext{})
+	}
+
+	return NewUpgrader(current, legacy...), nil
+}
@@ -0,0 +1,71 @@
+// Package password hashes and verifies user passwords. Hashes are stored in
+// self-describing formats so the algorithm or its cost can be changed and
+// existing users upgraded the next time they log in.
+package password
+
+import (
+	"errors"
+	"fmt"
+)
+
+// ErrUnknownHash is returned when no configured hasher recognises a stored hash.
+var ErrUnknownHash = errors.New("unknown password hash format")
+
+// Hasher hashes passwords with one algorithm and verifies hashes produced by it.
+type Hasher interface {
+	// Hash returns the encoded hash of password.
+	Hash(password string) (string, error)
+	// Verify reports whether password matches the encoded hash.
+	Verify(encoded, password string) (bool, error)
+	// NeedsRehash reports whether encoded was produced with weaker settings than the current ones.
+	NeedsRehash(encoded string) bool
+	// Identifies reports whether encoded was produced by this algorithm.
+	Identifies(encoded string) bool
+}
+
+// Upgrader hashes new passwords with the current hasher while still verifying
+// hashes produced by legacy ones, so stored hashes can be upgraded on login.
+type Upgrader struct {
+	current Hasher
+	legacy  []Hasher
+}
+
+func NewUpgrader(current Hasher, legacy ...Hasher) *Upgrader {
+	return &Upgrader{current: current, legacy: legacy}
+}
+
+func (u *Upgrader) Hash(password string) (string, error) {
+	return u.current.Hash(password) //nolint:wrapcheck
+}
+
+func (u *Upgrader) Verify(encoded, password string) (bool, error) {
+	hasher, err := u.hasherFor(encoded)
+	if err != nil {
+		return false, err
+	}
+
+	return hasher.Verify(encoded, password) //nolint:wrapcheck
+}
+
+// NeedsRehash reports whether encoded should be replaced with a fresh hash from the current hasher.
+func (u *Upgrader) NeedsRehash(encoded string) bool {
+	if u.current.Identifies(encoded) {
+		return u.current.NeedsRehash(encoded)
+	}
+
+	return true
+}
+
+func (u *Upgrader) hasherFor(encoded string) (Hasher, error) {
+	if u.current.Identifies(encoded) {
+		return u.current, nil
+	}
+
+	for _, hasher := range u.legacy {
+		if hasher.Identifies(encoded) {
+			return hasher, nil
+		}
+	}
+
+	return nil, fmt.Errorf("no hasher for stored password: %w", ErrUnknownHash)
+}
@@ -0,0 +1,141 @@
+package password
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var testArgon2Params = Argon2Params{
+	Memory:      1024,
+	Iterations:  1,
+	Parallelism: 1,
+	SaltLength:  16,
+	KeyLength:   32,
+}
+
+func TestHashers(t *testing.T) {
+	tests := []struct {
+		name   string
+		hasher Hasher
+	}{
+		{name: "bcrypt", hasher: NewBcrypt(4)},
+		{name: "argon2id", hasher: NewArgon2id(testArgon2Params)},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			hash, err := tt.hasher.Hash("Password1")
+			require.NoError(t, err)
+
+			assert.NotEqual(t, "Password1", hash)
+			assert.True(t, tt.hasher.Identifies(hash))
+			assert.False(t, tt.hasher.NeedsRehash(hash))
+
+			ok, err := tt.hasher.Verify(hash, "Password1")
+			require.NoError(t, err)
+			assert.True(t, ok)
+
+			ok, err = tt.hasher.Verify(hash, "Password2")
+			require.NoError(t, err)
+			assert.False(t, ok)
+
+			other, err := tt.hasher.Hash("Password1")
+			require.NoError(t, err)
+			assert.NotEqual(t, hash, other, "hashes must be salted")
+		})
+	}
+}
+
+func TestHashers_NeedsRehash(t *testing.T) {
+	weakBcrypt, err := NewBcrypt(4).Hash("Password1")
+	require.NoError(t, err)
+
+	assert.True(t, NewBcrypt(5).NeedsRehash(weakBcrypt))
+
+	weakArgon2, err := NewArgon2id(testArgon2Params).Hash("Password1")
+	require.NoError(t, err)
+
+	stronger := testArgon2Params
+	stronger.Iterations = 2
+
+	assert.True(t, NewArgon2id(stronger).NeedsRehash(weakArgon2))
+}
+
+func TestUpgrader(t *testing.T) {
+	bcryptHasher := NewBcrypt(4)
+	argon2Hasher := NewArgon2id(testArgon2Params)
+
+	bcryptHash, err := bcryptHasher.Hash("Password1")
+	require.NoError(t, err)
+
+	argon2Hash, err := argon2Hasher.Hash("Password1")
+	require.NoError(t, err)
+
+	tests := []struct {
+		name           string
+		upgrader       *Upgrader
+		stored         string
+		password       string
+		expectedOK     bool
+		expectedErr    error
+		expectedRehash bool
+	}{
+		{
+			name:           "current algorithm",
+			upgrader:       NewUpgrader(argon2Hasher, bcryptHasher),
+			stored:         argon2Hash,
+			password:       "Password1",
+			expectedOK:     true,
+			expectedRehash: false,
+		},
+		{
+			name:           "legacy algorithm is verified and upgraded",
+			upgrader:       NewUpgrader(argon2Hasher, bcryptHasher),
+			stored:         bcryptHash,
+			password:       "Password1",
+			expectedOK:     true,
+			expectedRehash: true,
+		},
+		{
+			name:           "plaintext is verified and upgraded when allowed",
+			upgrader:       NewUpgrader(argon2Hasher, bcryptHasher, Plaintext{}),
+			stored:         "Password1",
+			password:       "Password1",
+			expectedOK:     true,
+			expectedRehash: true,
+		},
+		{
+			name:           "plaintext mismatch",
+			upgrader:       NewUpgrader(argon2Hasher, bcryptHasher, Plaintext{}),
+			stored:         "Password1",
+			password:       "Password2",
+			expectedOK:     false,
+			expectedRehash: true,
+		},
+		{
+			name:           "plaintext is rejected when not allowed",
+			upgrader:       NewUpgrader(argon2Hasher, bcryptHasher),
+			stored:         "Password1",
+			password:       "Password1",
+			expectedErr:    ErrUnknownHash,
+			expectedRehash: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ok, err := tt.upgrader.Verify(tt.stored, tt.password)
+
+			if tt.expectedErr != nil {
+				assert.ErrorIs(t, err, tt.expectedErr)
+			} else {
+				assert.NoError(t, err)
+			}
+
+			assert.Equal(t, tt.expectedOK, ok)
+			assert.Equal(t, tt.expectedRehash, tt.upgrader.NeedsRehash(tt.stored))
+		})
+	}
+}
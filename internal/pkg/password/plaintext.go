@@ -0,0 +1,29 @@
+package password
+
+import (
+	"crypto/subtle"
+	"errors"
+)
+
+var errPlaintextHash = errors.New("refusing to store a plaintext password")
+
+// Plaintext verifies passwords stored before hashing was introduced. It only
+// exists so those users can log in and be upgraded; it never produces hashes.
+type Plaintext struct{}
+
+func (Plaintext) Hash(string) (string, error) {
+	return "", errPlaintextHash
+}
+
+func (Plaintext) Verify(encoded, password string) (bool, error) {
+	return subtle.ConstantTimeCompare([]byte(encoded), []byte(password)) == 1, nil
+}
+
+func (Plaintext) NeedsRehash(string) bool {
+	return true
+}
+
+// Identifies treats anything that is not a bcrypt or argon2id hash as plaintext.
+func (Plaintext) Identifies(encoded string) bool {
+	return !(&Bcrypt{}).Identifies(encoded) && !(&Argon2id{}).Identifies(encoded)
+}
@@ -0,0 +1,110 @@
+package signing
+
+import (
+	"crypto"
+	"crypto/ed25519"
+	"crypto/rand"
+	"crypto/x509"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/colmmurphy91/muzz/internal/pkg/envvar"
+)
+
+var errNoPEMBlock = errors.New("no PEM block found")
+
+// New loads the KeySet described by configuration:
+//
+//   - JWT_PRIVATE_KEY_FILE: PEM encoded PKCS#8 RSA or Ed25519 private key tokens are signed with.
+//   - JWT_PUBLIC_KEY_FILES: comma separated PEM encoded public keys that are still
+//     accepted, e.g. the previous signing key during a rotation.
+//
+// When no private key is configured and ENV is "local" an ephemeral Ed25519 key
+// is generated, so tokens do not survive a restart.
+func New(conf envvar.Provider) (*KeySet, error) {
+	signer, err := loadSigner(conf)
+	if err != nil {
+		return nil, err
+	}
+
+	var verifyOnly []crypto.PublicKey
+
+	for _, path := range strings.Split(conf.Get("JWT_PUBLIC_KEY_FILES"), ",") {
+		if path = strings.TrimSpace(path); path == "" {
+			continue
+		}
+
+		publicKey, err := readPublicKey(path)
+		if err != nil {
+			return nil, err
+		}
+
+		verifyOnly = append(verifyOnly, publicKey)
+	}
+
+	return NewKeySet(signer, verifyOnly...)
+}
+
+func loadSigner(conf envvar.Provider) (crypto.Signer, error) {
+	path := conf.Get("JWT_PRIVATE_KEY_FILE")
+	if path == "" {
+		if conf.Get("ENV") != "local" {
+			return nil, errors.New("JWT_PRIVATE_KEY_FILE is required")
+		}
+
+		_, privateKey, err := ed25519.GenerateKey(rand.Reader)
+		if err != nil {
+			return nil, fmt.Errorf("failed to generate signing key: %w", err)
+		}
+
+		return privateKey, nil
+	}
+
+	block, err := readPEM(path)
+	if err != nil {
+		return nil, err
+	}
+
+	key, err := x509.ParsePKCS8PrivateKey(block.Bytes)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse private key %s: %w", path, err)
+	}
+
+	signer, ok := key.(crypto.Signer)
+	if !ok {
+		return nil, fmt.Errorf("%T: %w", key, ErrUnsupportedKey)
+	}
+
+	return signer, nil
+}
+
+func readPublicKey(path string) (crypto.PublicKey, error) {
+	block, err := readPEM(path)
+	if err != nil {
+		return nil, err
+	}
+
+	publicKey, err := x509.ParsePKIXPublicKey(block.Bytes)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse public key %s: %w", path, err)
+	}
+
+	return publicKey, nil
+}
+
+func readPEM(path string) (*pem.Block, error) {
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read key file: %w", err)
+	}
+
+	block, _ := pem.Decode(content)
+	if block == nil {
+		return nil, fmt.Errorf("%s: %w", path, errNoPEMBlock)
+	}
+
+	return block, nil
+}
@@ -0,0 +1,102 @@
+package signing
+
+import (
+	"crypto"
+	"crypto/ed25519"
+	"crypto/rsa"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"math/big"
+)
+
+// JWK is the JSON Web Key (RFC 7517) representation of a public key.
+type JWK struct {
+	KeyType   string `json:"kty"`
+	KeyID     string `json:"kid"`
+	Use       string `json:"use"`
+	Algorithm string `json:"alg"`
+	// RSA
+	N string `json:"n,omitempty"`
+	E string `json:"e,omitempty"`
+	// OKP
+	Curve string `json:"crv,omitempty"`
+	X     string `json:"x,omitempty"`
+}
+
+// JWKSet is the document served from /.well-known/jwks.json.
+type JWKSet struct {
+	Keys []JWK `json:"keys"`
+}
+
+// JWKS returns the public half of every verification key.
+func (k *KeySet) JWKS() JWKSet {
+	keys := k.Keys()
+	set := JWKSet{Keys: make([]JWK, 0, len(keys))}
+
+	for _, key := range keys {
+		jwk := publicJWK(key.PublicKey)
+		jwk.KeyID = key.ID
+		jwk.Use = "sig"
+		jwk.Algorithm = key.Method.Alg()
+
+		set.Keys = append(set.Keys, jwk)
+	}
+
+	return set
+}
+
+func publicJWK(publicKey crypto.PublicKey) JWK {
+	switch key := publicKey.(type) {
+	case *rsa.PublicKey:
+		return JWK{
+			KeyType: "RSA",
+			N:       base64.RawURLEncoding.EncodeToString(key.N.Bytes()),
+			E:       base64.RawURLEncoding.EncodeToString(big.NewInt(int64(key.E)).Bytes()),
+		}
+	case ed25519.PublicKey:
+		return JWK{
+			KeyType: "OKP",
+			Curve:   "Ed25519",
+			X:       base64.RawURLEncoding.EncodeToString(key),
+		}
+	default:
+		return JWK{}
+	}
+}
+
+// thumbprint computes the RFC 7638 JWK thumbprint used as a key's kid, so the
+// same key always gets the same id without it having to be configured.
+func thumbprint(publicKey crypto.PublicKey) (string, error) {
+	jwk := publicJWK(publicKey)
+
+	// The required members, in lexicographic order.
+	var members interface{}
+
+	switch jwk.KeyType {
+	case "RSA":
+		members = struct {
+			E   string `json:"e"`
+			Kty string `json:"kty"`
+			N   string `json:"n"`
+		}{jwk.E, jwk.KeyType, jwk.N}
+	case "OKP":
+		members = struct {
+			Crv string `json:"crv"`
+			Kty string `json:"kty"`
+			X   string `json:"x"`
+		}{jwk.Curve, jwk.KeyType, jwk.X}
+	default:
+		return "", fmt.Errorf("%T: %w", publicKey, ErrUnsupportedKey)
+	}
+
+	content, err := json.Marshal(members)
+	if err != nil {
+		return "", fmt.Errorf("failed to encode key: %w", err)
+	}
+
+	hash := sha256.Sum256(content)
+
+	return base64.RawURLEncoding.EncodeToString(hash[:]), nil
+}
@@ -0,0 +1,127 @@
+// Package signing signs and verifies access tokens with asymmetric keys. Every
+// token carries the id of the key that signed it, so keys can be rotated while
+// tokens signed by the previous key are still in flight.
+package signing
+
+import (
+	"crypto"
+	"crypto/ed25519"
+	"crypto/rsa"
+	"errors"
+	"fmt"
+	"sort"
+
+	jwt "github.com/golang-jwt/jwt/v4"
+)
+
+var (
+	ErrUnsupportedKey = errors.New("unsupported key type")
+	ErrUnknownKey     = errors.New("unknown signing key")
+)
+
+// Key is a public key tokens can be verified with.
+type Key struct {
+	ID        string
+	Method    jwt.SigningMethod
+	PublicKey crypto.PublicKey
+}
+
+// KeySet signs tokens with one private key and verifies them against every key
+// that is still trusted, including the signing key itself.
+type KeySet struct {
+	signingKey crypto.Signer
+	signing    Key
+	keys       map[string]Key
+}
+
+// NewKeySet builds a KeySet that signs with signer. Retired or upcoming public
+// keys can be passed as verifyOnly so tokens they sign keep validating.
+func NewKeySet(signer crypto.Signer, verifyOnly ...crypto.PublicKey) (*KeySet, error) {
+	signing, err := newKey(signer.Public())
+	if err != nil {
+		return nil, err
+	}
+
+	set := &KeySet{
+		signingKey: signer,
+		signing:    signing,
+		keys:       map[string]Key{signing.ID: signing},
+	}
+
+	for _, publicKey := range verifyOnly {
+		key, err := newKey(publicKey)
+		if err != nil {
+			return nil, err
+		}
+
+		set.keys[key.ID] = key
+	}
+
+	return set, nil
+}
+
+// Sign signs claims with the current signing key and sets the kid header.
+func (k *KeySet) Sign(claims jwt.Claims) (string, error) {
+	token := jwt.NewWithClaims(k.signing.Method, claims)
+	token.Header["kid"] = k.signing.ID
+
+	signed, err := token.SignedString(k.signingKey)
+	if err != nil {
+		return "", fmt.Errorf("failed to sign token: %w", err)
+	}
+
+	return signed, nil
+}
+
+// Keyfunc resolves the verification key for a token from its kid header. It
+// only accepts the algorithm that key is meant for.
+func (k *KeySet) Keyfunc(token *jwt.Token) (interface{}, error) {
+	kid, _ := token.Header["kid"].(string)
+
+	key, ok := k.keys[kid]
+	if !ok {
+		return nil, fmt.Errorf("kid %q: %w", kid, ErrUnknownKey)
+	}
+
+	if token.Method.Alg() != key.Method.Alg() {
+		return nil, fmt.Errorf("unexpected signing method: %v", token.Header["alg"])
+	}
+
+	return key.PublicKey, nil
+}
+
+// Keys returns every key tokens are currently verified with.
+func (k *KeySet) Keys() []Key {
+	others := make([]Key, 0, len(k.keys)-1)
+
+	for id, key := range k.keys {
+		if id != k.signing.ID {
+			others = append(others, key)
+		}
+	}
+
+	sort.Slice(others, func(i, j int) bool { return others[i].ID < others[j].ID })
+
+	// The signing key first, so it is the first one clients try.
+	return append([]Key{k.signing}, others...)
+}
+
+func newKey(publicKey crypto.PublicKey) (Key, error) {
+	var method jwt.SigningMethod
+
+	switch publicKey.(type) {
+	case *rsa.PublicKey:
+		method = jwt.SigningMethodRS256
+	case ed25519.PublicKey:
+		method = jwt.SigningMethodEdDSA
+	default:
+		return Key{}, fmt.Errorf("%T: %w", publicKey, ErrUnsupportedKey)
+	}
+
+	id, err := thumbprint(publicKey)
+	if err != nil {
+		return Key{}, err
+	}
+
+	return Key{ID: id, Method: method, PublicKey: publicKey}, nil
+}
@@ -0,0 +1,123 @@
+package signing
+
+import (
+	"crypto/ed25519"
+	"crypto/rand"
+	"crypto/rsa"
+	"testing"
+	"time"
+
+	jwt "github.com/golang-jwt/jwt/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestKeySet_SignAndVerify(t *testing.T) {
+	rsaKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(t, err)
+
+	_, edKey, err := ed25519.GenerateKey(rand.Reader)
+	require.NoError(t, err)
+
+	tests := []struct {
+		name        string
+		keys        func() (*KeySet, error)
+		expectedAlg string
+	}{
+		{name: "RS256", keys: func() (*KeySet, error) { return NewKeySet(rsaKey) }, expectedAlg: "RS256"},
+		{name: "EdDSA", keys: func() (*KeySet, error) { return NewKeySet(edKey) }, expectedAlg: "EdDSA"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			keys, err := tt.keys()
+			require.NoError(t, err)
+
+			signed, err := keys.Sign(testClaims())
+			require.NoError(t, err)
+
+			token, err := jwt.Parse(signed, keys.Keyfunc)
+			require.NoError(t, err)
+
+			assert.True(t, token.Valid)
+			assert.Equal(t, tt.expectedAlg, token.Header["alg"])
+			assert.Equal(t, keys.Keys()[0].ID, token.Header["kid"])
+		})
+	}
+}
+
+func TestKeySet_Rotation(t *testing.T) {
+	_, oldKey, err := ed25519.GenerateKey(rand.Reader)
+	require.NoError(t, err)
+
+	_, newKey, err := ed25519.GenerateKey(rand.Reader)
+	require.NoError(t, err)
+
+	oldKeys, err := NewKeySet(oldKey)
+	require.NoError(t, err)
+
+	rotatedKeys, err := NewKeySet(newKey, oldKey.Public())
+	require.NoError(t, err)
+
+	signedByOld, err := oldKeys.Sign(testClaims())
+	require.NoError(t, err)
+
+	_, err = jwt.Parse(signedByOld, rotatedKeys.Keyfunc)
+	assert.NoError(t, err, "tokens signed by the retired key are still accepted")
+
+	signedByNew, err := rotatedKeys.Sign(testClaims())
+	require.NoError(t, err)
+
+	_, err = jwt.Parse(signedByNew, oldKeys.Keyfunc)
+	assert.ErrorIs(t, err, ErrUnknownKey, "the old key set does not know the new key")
+
+	jwks := rotatedKeys.JWKS()
+	require.Len(t, jwks.Keys, 2)
+	assert.Equal(t, rotatedKeys.Keys()[0].ID, jwks.Keys[0].KeyID)
+
+	for _, key := range jwks.Keys {
+		assert.Equal(t, "OKP", key.KeyType)
+		assert.Equal(t, "Ed25519", key.Curve)
+		assert.Equal(t, "EdDSA", key.Algorithm)
+		assert.Equal(t, "sig", key.Use)
+		assert.NotEmpty(t, key.X)
+	}
+}
+
+func TestKeySet_RejectsOtherAlgorithms(t *testing.T) {
+	_, edKey, err := ed25519.GenerateKey(rand.Reader)
+	require.NoError(t, err)
+
+	keys, err := NewKeySet(edKey)
+	require.NoError(t, err)
+
+	// A token signed with HS256 using the public key as the secret must not verify.
+	token := jwt.NewWithClaims(jwt.SigningMethodHS256, testClaims())
+	token.Header["kid"] = keys.Keys()[0].ID
+
+	signed, err := token.SignedString([]byte(edKey.Public().(ed25519.PublicKey)))
+	require.NoError(t, err)
+
+	_, err = jwt.Parse(signed, keys.Keyfunc)
+	assert.Error(t, err)
+}
+
+func TestThumbprint(t *testing.T) {
+	// RFC 8037 appendix A.3
+	x := []byte{
+		0xd7, 0x5a, 0x98, 0x01, 0x82, 0xb1, 0x0a, 0xb7, 0xd5, 0x4b, 0xfe, 0xd3, 0xc9, 0x64, 0x07, 0x3a,
+		0x0e, 0xe1, 0x72, 0xf3, 0xda, 0xa6, 0x23, 0x25, 0xaf, 0x02, 0x1a, 0x68, 0xf7, 0x07, 0x51, 0x1a,
+	}
+
+	kid, err := thumbprint(ed25519.PublicKey(x))
+	require.NoError(t, err)
+
+	assert.Equal(t, "kPrK_qmxVWaYVA9wwBF6Iuo3vVzz7TxHCTwXBygrS4k", kid)
+}
+
+func testClaims() jwt.Claims {
+	return jwt.MapClaims{
+		"user_id": 1,
+		"exp":     time.Now().Add(time.Minute).Unix(),
+	}
+}
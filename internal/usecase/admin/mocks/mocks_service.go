@@ -0,0 +1,413 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: service.go
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	context "context"
+	reflect "reflect"
+
+	model "github.com/colmmurphy91/muzz/internal/adapter/mysql/user/model"
+	entity "github.com/colmmurphy91/muzz/internal/entity"
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockuserStore is a mock of userStore interface.
+type MockuserStore struct {
+	ctrl     *gomock.Controller
+	recorder *MockuserStoreMockRecorder
+}
+
+// MockuserStoreMockRecorder is the mock recorder for MockuserStore.
+type MockuserStoreMockRecorder struct {
+	mock *MockuserStore
+}
+
+// NewMockuserStore creates a new mock instance.
+func NewMockuserStore(ctrl *gomock.Controller) *MockuserStore {
+	mock := &MockuserStore{ctrl: ctrl}
+	mock.recorder = &MockuserStoreMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockuserStore) EXPECT() *MockuserStoreMockRecorder {
+	return m.recorder
+}
+
+// FindByID mocks base method.
+func (m *MockuserStore) FindByID(ctx context.Context, userID int) (model.User, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindByID", ctx, userID)
+	ret0, _ := ret[0].(model.User)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindByID indicates an expected call of FindByID.
+func (mr *MockuserStoreMockRecorder) FindByID(ctx, userID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindByID", reflect.TypeOf((*MockuserStore)(nil).FindByID), ctx, userID)
+}
+
+// UpdateRole mocks base method.
+func (m *MockuserStore) UpdateRole(ctx context.Context, userID int, role entity.Role) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateRole", ctx, userID, role)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateRole indicates an expected call of UpdateRole.
+func (mr *MockuserStoreMockRecorder) UpdateRole(ctx, userID, role interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateRole", reflect.TypeOf((*MockuserStore)(nil).UpdateRole), ctx, userID, role)
+}
+
+// UpdateShadowBanned mocks base method.
+func (m *MockuserStore) UpdateShadowBanned(ctx context.Context, userID int, shadowBanned bool) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateShadowBanned", ctx, userID, shadowBanned)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateShadowBanned indicates an expected call of UpdateShadowBanned.
+func (mr *MockuserStoreMockRecorder) UpdateShadowBanned(ctx, userID, shadowBanned interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateShadowBanned", reflect.TypeOf((*MockuserStore)(nil).UpdateShadowBanned), ctx, userID, shadowBanned)
+}
+
+// UpdateStatus mocks base method.
+func (m *MockuserStore) UpdateStatus(ctx context.Context, userID int, status entity.UserStatus) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateStatus", ctx, userID, status)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateStatus indicates an expected call of UpdateStatus.
+func (mr *MockuserStoreMockRecorder) UpdateStatus(ctx, userID, status interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateStatus", reflect.TypeOf((*MockuserStore)(nil).UpdateStatus), ctx, userID, status)
+}
+
+// MockreportReviewer is a mock of reportReviewer interface.
+type MockreportReviewer struct {
+	ctrl     *gomock.Controller
+	recorder *MockreportReviewerMockRecorder
+}
+
+// MockreportReviewerMockRecorder is the mock recorder for MockreportReviewer.
+type MockreportReviewerMockRecorder struct {
+	mock *MockreportReviewer
+}
+
+// NewMockreportReviewer creates a new mock instance.
+func NewMockreportReviewer(ctrl *gomock.Controller) *MockreportReviewer {
+	mock := &MockreportReviewer{ctrl: ctrl}
+	mock.recorder = &MockreportReviewerMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockreportReviewer) EXPECT() *MockreportReviewerMockRecorder {
+	return m.recorder
+}
+
+// Queue mocks base method.
+func (m *MockreportReviewer) Queue(ctx context.Context, status entity.ReportStatus, limit int, after *entity.ReportsCursor) (entity.ReportsPage, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Queue", ctx, status, limit, after)
+	ret0, _ := ret[0].(entity.ReportsPage)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Queue indicates an expected call of Queue.
+func (mr *MockreportReviewerMockRecorder) Queue(ctx, status, limit, after interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Queue", reflect.TypeOf((*MockreportReviewer)(nil).Queue), ctx, status, limit, after)
+}
+
+// Transition mocks base method.
+func (m *MockreportReviewer) Transition(ctx context.Context, moderatorID, reportID int, status entity.ReportStatus) (entity.Report, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Transition", ctx, moderatorID, reportID, status)
+	ret0, _ := ret[0].(entity.Report)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Transition indicates an expected call of Transition.
+func (mr *MockreportReviewerMockRecorder) Transition(ctx, moderatorID, reportID, status interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Transition", reflect.TypeOf((*MockreportReviewer)(nil).Transition), ctx, moderatorID, reportID, status)
+}
+
+// MockmatchStore is a mock of matchStore interface.
+type MockmatchStore struct {
+	ctrl     *gomock.Controller
+	recorder *MockmatchStoreMockRecorder
+}
+
+// MockmatchStoreMockRecorder is the mock recorder for MockmatchStore.
+type MockmatchStoreMockRecorder struct {
+	mock *MockmatchStore
+}
+
+// NewMockmatchStore creates a new mock instance.
+func NewMockmatchStore(ctrl *gomock.Controller) *MockmatchStore {
+	mock := &MockmatchStore{ctrl: ctrl}
+	mock.recorder = &MockmatchStoreMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockmatchStore) EXPECT() *MockmatchStoreMockRecorder {
+	return m.recorder
+}
+
+// FindMatch mocks base method.
+func (m *MockmatchStore) FindMatch(ctx context.Context, id int) (entity.Match, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindMatch", ctx, id)
+	ret0, _ := ret[0].(entity.Match)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindMatch indicates an expected call of FindMatch.
+func (mr *MockmatchStoreMockRecorder) FindMatch(ctx, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindMatch", reflect.TypeOf((*MockmatchStore)(nil).FindMatch), ctx, id)
+}
+
+// Unmatch mocks base method.
+func (m *MockmatchStore) Unmatch(ctx context.Context, id, userID int) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Unmatch", ctx, id, userID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Unmatch indicates an expected call of Unmatch.
+func (mr *MockmatchStoreMockRecorder) Unmatch(ctx, id, userID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Unmatch", reflect.TypeOf((*MockmatchStore)(nil).Unmatch), ctx, id, userID)
+}
+
+// MockpreferenceFinder is a mock of preferenceFinder interface.
+type MockpreferenceFinder struct {
+	ctrl     *gomock.Controller
+	recorder *MockpreferenceFinderMockRecorder
+}
+
+// MockpreferenceFinderMockRecorder is the mock recorder for MockpreferenceFinder.
+type MockpreferenceFinderMockRecorder struct {
+	mock *MockpreferenceFinder
+}
+
+// NewMockpreferenceFinder creates a new mock instance.
+func NewMockpreferenceFinder(ctrl *gomock.Controller) *MockpreferenceFinder {
+	mock := &MockpreferenceFinder{ctrl: ctrl}
+	mock.recorder = &MockpreferenceFinderMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockpreferenceFinder) EXPECT() *MockpreferenceFinderMockRecorder {
+	return m.recorder
+}
+
+// GetPreferences mocks base method.
+func (m *MockpreferenceFinder) GetPreferences(ctx context.Context, userID int) (entity.Preferences, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetPreferences", ctx, userID)
+	ret0, _ := ret[0].(entity.Preferences)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetPreferences indicates an expected call of GetPreferences.
+func (mr *MockpreferenceFinderMockRecorder) GetPreferences(ctx, userID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPreferences", reflect.TypeOf((*MockpreferenceFinder)(nil).GetPreferences), ctx, userID)
+}
+
+// MockuserIndex is a mock of userIndex interface.
+type MockuserIndex struct {
+	ctrl     *gomock.Controller
+	recorder *MockuserIndexMockRecorder
+}
+
+// MockuserIndexMockRecorder is the mock recorder for MockuserIndex.
+type MockuserIndexMockRecorder struct {
+	mock *MockuserIndex
+}
+
+// NewMockuserIndex creates a new mock instance.
+func NewMockuserIndex(ctrl *gomock.Controller) *MockuserIndex {
+	mock := &MockuserIndex{ctrl: ctrl}
+	mock.recorder = &MockuserIndexMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockuserIndex) EXPECT() *MockuserIndexMockRecorder {
+	return m.recorder
+}
+
+// Delete mocks base method.
+func (m *MockuserIndex) Delete(ctx context.Context, userID int) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Delete", ctx, userID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Delete indicates an expected call of Delete.
+func (mr *MockuserIndexMockRecorder) Delete(ctx, userID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockuserIndex)(nil).Delete), ctx, userID)
+}
+
+// Reindex mocks base method.
+func (m *MockuserIndex) Reindex(ctx context.Context, user entity.User, prefs entity.Preferences, shadowBanned bool) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Reindex", ctx, user, prefs, shadowBanned)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Reindex indicates an expected call of Reindex.
+func (mr *MockuserIndexMockRecorder) Reindex(ctx, user, prefs, shadowBanned interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Reindex", reflect.TypeOf((*MockuserIndex)(nil).Reindex), ctx, user, prefs, shadowBanned)
+}
+
+// SetShadowBanned mocks base method.
+func (m *MockuserIndex) SetShadowBanned(ctx context.Context, userID int, shadowBanned bool) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetShadowBanned", ctx, userID, shadowBanned)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SetShadowBanned indicates an expected call of SetShadowBanned.
+func (mr *MockuserIndexMockRecorder) SetShadowBanned(ctx, userID, shadowBanned interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetShadowBanned", reflect.TypeOf((*MockuserIndex)(nil).SetShadowBanned), ctx, userID, shadowBanned)
+}
+
+// MocktokenRevoker is a mock of tokenRevoker interface.
+type MocktokenRevoker struct {
+	ctrl     *gomock.Controller
+	recorder *MocktokenRevokerMockRecorder
+}
+
+// MocktokenRevokerMockRecorder is the mock recorder for MocktokenRevoker.
+type MocktokenRevokerMockRecorder struct {
+	mock *MocktokenRevoker
+}
+
+// NewMocktokenRevoker creates a new mock instance.
+func NewMocktokenRevoker(ctrl *gomock.Controller) *MocktokenRevoker {
+	mock := &MocktokenRevoker{ctrl: ctrl}
+	mock.recorder = &MocktokenRevokerMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MocktokenRevoker) EXPECT() *MocktokenRevokerMockRecorder {
+	return m.recorder
+}
+
+// RevokeUserTokens mocks base method.
+func (m *MocktokenRevoker) RevokeUserTokens(ctx context.Context, userID int) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RevokeUserTokens", ctx, userID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RevokeUserTokens indicates an expected call of RevokeUserTokens.
+func (mr *MocktokenRevokerMockRecorder) RevokeUserTokens(ctx, userID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RevokeUserTokens", reflect.TypeOf((*MocktokenRevoker)(nil).RevokeUserTokens), ctx, userID)
+}
+
+// MockauditLog is a mock of auditLog interface.
+type MockauditLog struct {
+	ctrl     *gomock.Controller
+	recorder *MockauditLogMockRecorder
+}
+
+// MockauditLogMockRecorder is the mock recorder for MockauditLog.
+type MockauditLogMockRecorder struct {
+	mock *MockauditLog
+}
+
+// NewMockauditLog creates a new mock instance.
+func NewMockauditLog(ctrl *gomock.Controller) *MockauditLog {
+	mock := &MockauditLog{ctrl: ctrl}
+	mock.recorder = &MockauditLogMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockauditLog) EXPECT() *MockauditLogMockRecorder {
+	return m.recorder
+}
+
+// CreateAuditEntry mocks base method.
+func (m *MockauditLog) CreateAuditEntry(ctx context.Context, entry entity.AuditEntry) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateAuditEntry", ctx, entry)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CreateAuditEntry indicates an expected call of CreateAuditEntry.
+func (mr *MockauditLogMockRecorder) CreateAuditEntry(ctx, entry interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateAuditEntry", reflect.TypeOf((*MockauditLog)(nil).CreateAuditEntry), ctx, entry)
+}
+
+// MockunitOfWork is a mock of unitOfWork interface.
+type MockunitOfWork struct {
+	ctrl     *gomock.Controller
+	recorder *MockunitOfWorkMockRecorder
+}
+
+// MockunitOfWorkMockRecorder is the mock recorder for MockunitOfWork.
+type MockunitOfWorkMockRecorder struct {
+	mock *MockunitOfWork
+}
+
+// NewMockunitOfWork creates a new mock instance.
+func NewMockunitOfWork(ctrl *gomock.Controller) *MockunitOfWork {
+	mock := &MockunitOfWork{ctrl: ctrl}
+	mock.recorder = &MockunitOfWorkMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockunitOfWork) EXPECT() *MockunitOfWorkMockRecorder {
+	return m.recorder
+}
+
+// Do mocks base method.
+func (m *MockunitOfWork) Do(ctx context.Context, fn func(context.Context) error) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Do", ctx, fn)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Do indicates an expected call of Do.
+func (mr *MockunitOfWorkMockRecorder) Do(ctx, fn interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Do", reflect.TypeOf((*MockunitOfWork)(nil).Do), ctx, fn)
+}
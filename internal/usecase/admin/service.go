@@ -0,0 +1,375 @@
+package admin
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/colmmurphy91/muzz/internal/adapter/mysql/user/model"
+	"github.com/colmmurphy91/muzz/internal/entity"
+)
+
+//go:generate mockgen -source $GOFILE -destination mocks/mocks_${GOFILE} -package mocks
+
+type userStore interface {
+	FindByID(ctx context.Context, userID int) (model.User, error)
+	UpdateStatus(ctx context.Context, userID int, status entity.UserStatus) error
+	UpdateShadowBanned(ctx context.Context, userID int, shadowBanned bool) error
+	UpdateRole(ctx context.Context, userID int, role entity.Role) error
+}
+
+// reportReviewer is the moderation queue.
+type reportReviewer interface {
+	Queue(ctx context.Context, status entity.ReportStatus, limit int, after *entity.ReportsCursor) (entity.ReportsPage, error)
+	Transition(ctx context.Context, moderatorID, reportID int, status entity.ReportStatus) (entity.Report, error)
+}
+
+type matchStore interface {
+	FindMatch(ctx context.Context, id int) (entity.Match, error)
+	Unmatch(ctx context.Context, id, userID int) error
+}
+
+type preferenceFinder interface {
+	GetPreferences(ctx context.Context, userID int) (entity.Preferences, error)
+}
+
+// userIndex is where users are discovered from.
+type userIndex interface {
+	Reindex(ctx context.Context, user entity.User, prefs entity.Preferences, shadowBanned bool) error
+	SetShadowBanned(ctx context.Context, userID int, shadowBanned bool) error
+	Delete(ctx context.Context, userID int) error
+}
+
+type tokenRevoker interface {
+	RevokeUserTokens(ctx context.Context, userID int) error
+}
+
+type auditLog interface {
+	CreateAuditEntry(ctx context.Context, entry entity.AuditEntry) error
+}
+
+type unitOfWork interface {
+	Do(ctx context.Context, fn func(ctx context.Context) error) error
+}
+
+// Service carries out the actions staff take through the admin API. Each is
+// written to the audit log in the same unit of work, and anything outside
+// MySQL is changed last, so a failure there rolls the action back and it can
+// be retried.
+type Service struct {
+	userStore        userStore
+	reportReviewer   reportReviewer
+	matchStore       matchStore
+	preferenceFinder preferenceFinder
+	userIndex        userIndex
+	tokenRevoker     tokenRevoker
+	auditLog         auditLog
+	unitOfWork       unitOfWork
+	now              func() time.Time
+}
+
+func NewService(
+	users userStore,
+	reports reportReviewer,
+	matches matchStore,
+	preferences preferenceFinder,
+	index userIndex,
+	tokens tokenRevoker,
+	audit auditLog,
+	unitOfWork unitOfWork,
+) *Service {
+	return &Service{
+		userStore:        users,
+		reportReviewer:   reports,
+		matchStore:       matches,
+		preferenceFinder: preferences,
+		userIndex:        index,
+		tokenRevoker:     tokens,
+		auditLog:         audit,
+		unitOfWork:       unitOfWork,
+		now:              time.Now,
+	}
+}
+
+// User returns everything about a user that staff are allowed to see.
+func (s *Service) User(ctx context.Context, userID int) (model.User, error) {
+	user, err := s.userStore.FindByID(ctx, userID)
+	if err != nil {
+		return model.User{}, fmt.Errorf("failed to find user: %w", err)
+	}
+
+	return user, nil
+}
+
+// Reports returns a page of the reports with the status, oldest first.
+func (s *Service) Reports(ctx context.Context, status entity.ReportStatus, limit int, after *entity.ReportsCursor) (entity.ReportsPage, error) {
+	return s.reportReviewer.Queue(ctx, status, limit, after)
+}
+
+// ReviewReport moves a report to status on behalf of a moderator.
+func (s *Service) ReviewReport(ctx context.Context, actorID, reportID int, status entity.ReportStatus, reason string) (entity.Report, error) {
+	entry := entity.AuditEntry{
+		ActorID:  actorID,
+		Action:   entity.AuditActionReportTransition,
+		TargetID: reportID,
+		Detail:   string(status),
+		Reason:   reason,
+	}
+
+	if err := entry.Validate(); err != nil {
+		return entity.Report{}, err
+	}
+
+	var report entity.Report
+
+	err := s.unitOfWork.Do(ctx, func(ctx context.Context) error {
+		var err error
+
+		report, err = s.reportReviewer.Transition(ctx, actorID, reportID, status)
+		if err != nil {
+			return err
+		}
+
+		return s.audit(ctx, entry)
+	})
+	if err != nil {
+		return entity.Report{}, err
+	}
+
+	return report, nil
+}
+
+// Suspend takes a user out of the app until they are reinstated: they are
+// signed out everywhere, cannot log in and are no longer discovered.
+func (s *Service) Suspend(ctx context.Context, actorID, userID int, reason string) error {
+	return s.changeStatus(ctx, actorID, userID, entity.UserStatusSuspended, entity.AuditActionSuspend, reason)
+}
+
+// Ban takes a user out of the app for good.
+func (s *Service) Ban(ctx context.Context, actorID, userID int, reason string) error {
+	return s.changeStatus(ctx, actorID, userID, entity.UserStatusBanned, entity.AuditActionBan, reason)
+}
+
+// Reinstate lets a suspended user back into the app and makes them
+// discoverable again.
+func (s *Service) Reinstate(ctx context.Context, actorID, userID int, reason string) error {
+	return s.changeStatus(ctx, actorID, userID, entity.UserStatusActive, entity.AuditActionReinstate, reason)
+}
+
+func (s *Service) changeStatus(
+	ctx context.Context,
+	actorID, userID int,
+	status entity.UserStatus,
+	action entity.AuditAction,
+	reason string,
+) error {
+	entry := entity.AuditEntry{ActorID: actorID, Action: action, TargetID: userID, Reason: reason}
+
+	if err := entry.Validate(); err != nil {
+		return err
+	}
+
+	user, err := s.target(ctx, actorID, userID)
+	if err != nil {
+		return err
+	}
+
+	if !user.Status.CanTransitionTo(status) {
+		return fmt.Errorf("%s to %s: %w", user.Status, status, entity.ErrUserStatusTransition)
+	}
+
+	return s.unitOfWork.Do(ctx, func(ctx context.Context) error {
+		if err := s.userStore.UpdateStatus(ctx, userID, status); err != nil {
+			return fmt.Errorf("failed to update status: %w", err)
+		}
+
+		if err := s.audit(ctx, entry); err != nil {
+			return err
+		}
+
+		if status == entity.UserStatusActive {
+			return s.reindex(ctx, user)
+		}
+
+		if err := s.tokenRevoker.RevokeUserTokens(ctx, userID); err != nil {
+			return fmt.Errorf("failed to revoke tokens: %w", err)
+		}
+
+		if err := s.userIndex.Delete(ctx, userID); err != nil {
+			return fmt.Errorf("failed to remove from index: %w", err)
+		}
+
+		return nil
+	})
+}
+
+// ShadowBan hides a user from everyone else's discover results without them
+// knowing, or shows them again. They can otherwise use the app as before.
+func (s *Service) ShadowBan(ctx context.Context, actorID, userID int, shadowBanned bool, reason string) error {
+	action := entity.AuditActionShadowBan
+	if !shadowBanned {
+		action = entity.AuditActionUnshadowBan
+	}
+
+	entry := entity.AuditEntry{ActorID: actorID, Action: action, TargetID: userID, Reason: reason}
+
+	if err := entry.Validate(); err != nil {
+		return err
+	}
+
+	user, err := s.target(ctx, actorID, userID)
+	if err != nil {
+		return err
+	}
+
+	if user.ShadowBanned == shadowBanned {
+		return nil
+	}
+
+	return s.unitOfWork.Do(ctx, func(ctx context.Context) error {
+		if err := s.userStore.UpdateShadowBanned(ctx, userID, shadowBanned); err != nil {
+			return fmt.Errorf("failed to update shadow ban: %w", err)
+		}
+
+		if err := s.audit(ctx, entry); err != nil {
+			return err
+		}
+
+		// Users out of the app have no document; reinstating them applies it.
+		if user.Status != entity.UserStatusActive {
+			return nil
+		}
+
+		if err := s.userIndex.SetShadowBanned(ctx, userID, shadowBanned); err != nil {
+			return fmt.Errorf("failed to index shadow ban: %w", err)
+		}
+
+		return nil
+	})
+}
+
+// ForceUnmatch ends a match on behalf of a moderator, which also closes its
+// conversation. The moderator is recorded as who unmatched it.
+func (s *Service) ForceUnmatch(ctx context.Context, actorID, matchID int, reason string) error {
+	entry := entity.AuditEntry{ActorID: actorID, Action: entity.AuditActionForceUnmatch, TargetID: matchID, Reason: reason}
+
+	if err := entry.Validate(); err != nil {
+		return err
+	}
+
+	match, err := s.matchStore.FindMatch(ctx, matchID)
+	if err != nil {
+		return fmt.Errorf("failed to find match: %w", err)
+	}
+
+	if match.UnmatchedAt.Valid {
+		return entity.ErrMatchEnded
+	}
+
+	return s.unitOfWork.Do(ctx, func(ctx context.Context) error {
+		if err := s.matchStore.Unmatch(ctx, matchID, actorID); err != nil {
+			return fmt.Errorf("failed to unmatch: %w", err)
+		}
+
+		return s.audit(ctx, entry)
+	})
+}
+
+// SetRole changes what a user is allowed to do. They are signed out
+// everywhere, so the role in their tokens is never out of date for long.
+func (s *Service) SetRole(ctx context.Context, actorID, userID int, role entity.Role, reason string) error {
+	if !role.Valid() {
+		return fmt.Errorf("unknown role %q: %w", role, entity.ErrInvalidParam)
+	}
+
+	entry := entity.AuditEntry{
+		ActorID:  actorID,
+		Action:   entity.AuditActionSetRole,
+		TargetID: userID,
+		Detail:   string(role),
+		Reason:   reason,
+	}
+
+	if err := entry.Validate(); err != nil {
+		return err
+	}
+
+	if actorID == userID {
+		return fmt.Errorf("cannot change your own role: %w", entity.ErrForbidden)
+	}
+
+	user, err := s.userStore.FindByID(ctx, userID)
+	if err != nil {
+		return fmt.Errorf("failed to find user: %w", err)
+	}
+
+	if user.Role == role {
+		return nil
+	}
+
+	return s.unitOfWork.Do(ctx, func(ctx context.Context) error {
+		if err := s.userStore.UpdateRole(ctx, userID, role); err != nil {
+			return fmt.Errorf("failed to update role: %w", err)
+		}
+
+		if err := s.audit(ctx, entry); err != nil {
+			return err
+		}
+
+		if err := s.tokenRevoker.RevokeUserTokens(ctx, userID); err != nil {
+			return fmt.Errorf("failed to revoke tokens: %w", err)
+		}
+
+		return nil
+	})
+}
+
+// target returns the user an action is taken against. Staff cannot act against
+// themselves or each other; an admin has to take away a role first.
+func (s *Service) target(ctx context.Context, actorID, userID int) (model.User, error) {
+	if actorID == userID {
+		return model.User{}, fmt.Errorf("cannot act on yourself: %w", entity.ErrForbidden)
+	}
+
+	user, err := s.userStore.FindByID(ctx, userID)
+	if err != nil {
+		return model.User{}, fmt.Errorf("failed to find user: %w", err)
+	}
+
+	if user.Role.IsStaff() {
+		return model.User{}, fmt.Errorf("cannot act on staff: %w", entity.ErrForbidden)
+	}
+
+	return user, nil
+}
+
+// reindex makes a user discoverable again, with their preferences and any
+// shadow ban they had. A user who never saved preferences gets the defaults, so
+// they are shown to others.
+func (s *Service) reindex(ctx context.Context, user model.User) error {
+	prefs, err := s.preferenceFinder.GetPreferences(ctx, user.ID)
+
+	switch {
+	case errors.Is(err, entity.ErrPreferencesNotFound):
+		prefs = entity.DefaultPreferences(user.ID)
+	case err != nil:
+		return fmt.Errorf("failed to get preferences: %w", err)
+	}
+
+	if err := s.userIndex.Reindex(ctx, user.Profile(), prefs, user.ShadowBanned); err != nil {
+		return fmt.Errorf("failed to index: %w", err)
+	}
+
+	return nil
+}
+
+func (s *Service) audit(ctx context.Context, entry entity.AuditEntry) error {
+	entry.CreatedAt = s.now().UTC().Truncate(time.Second)
+
+	if err := s.auditLog.CreateAuditEntry(ctx, entry); err != nil {
+		return fmt.Errorf("failed to write audit log: %w", err)
+	}
+
+	return nil
+}
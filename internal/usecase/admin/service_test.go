@@ -0,0 +1,483 @@
+package admin
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	null "github.com/guregu/null/v5"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/colmmurphy91/muzz/internal/adapter/mysql/user/model"
+	"github.com/colmmurphy91/muzz/internal/entity"
+	"github.com/colmmurphy91/muzz/internal/usecase/admin/mocks"
+)
+
+type testMocks struct {
+	users       *mocks.MockuserStore
+	reports     *mocks.MockreportReviewer
+	matches     *mocks.MockmatchStore
+	preferences *mocks.MockpreferenceFinder
+	index       *mocks.MockuserIndex
+	tokens      *mocks.MocktokenRevoker
+	audit       *mocks.MockauditLog
+}
+
+func newTestService(t *testing.T, now time.Time) (*Service, testMocks) {
+	ctrl := gomock.NewController(t)
+
+	m := testMocks{
+		users:       mocks.NewMockuserStore(ctrl),
+		reports:     mocks.NewMockreportReviewer(ctrl),
+		matches:     mocks.NewMockmatchStore(ctrl),
+		preferences: mocks.NewMockpreferenceFinder(ctrl),
+		index:       mocks.NewMockuserIndex(ctrl),
+		tokens:      mocks.NewMocktokenRevoker(ctrl),
+		audit:       mocks.NewMockauditLog(ctrl),
+	}
+
+	unitOfWork := mocks.NewMockunitOfWork(ctrl)
+	unitOfWork.EXPECT().Do(gomock.Any(), gomock.Any()).DoAndReturn(
+		func(ctx context.Context, fn func(ctx context.Context) error) error {
+			return fn(ctx)
+		}).AnyTimes()
+
+	service := NewService(m.users, m.reports, m.matches, m.preferences, m.index, m.tokens, m.audit, unitOfWork)
+	service.now = func() time.Time {
+		return now
+	}
+
+	return service, m
+}
+
+func TestService_ReviewReport(t *testing.T) {
+	now := time.Date(2024, 7, 6, 9, 0, 0, 0, time.UTC)
+	ctx := context.Background()
+	reviewed := entity.Report{ID: 4, ReportedID: 2, Status: entity.ReportStatusActioned, ReviewedBy: null.IntFrom(9)}
+
+	tests := []struct {
+		name          string
+		reason        string
+		setupMocks    func(m testMocks)
+		expected      entity.Report
+		expectedError error
+	}{
+		{
+			name:   "moves the report and audits it",
+			reason: "confirmed spam",
+			setupMocks: func(m testMocks) {
+				m.reports.EXPECT().Transition(ctx, 9, 4, entity.ReportStatusActioned).Return(reviewed, nil)
+				m.audit.EXPECT().CreateAuditEntry(ctx, entity.AuditEntry{
+					ActorID:   9,
+					Action:    entity.AuditActionReportTransition,
+					TargetID:  4,
+					Detail:    "actioned",
+					Reason:    "confirmed spam",
+					CreatedAt: now,
+				}).Return(nil)
+			},
+			expected: reviewed,
+		},
+		{
+			name: "report cannot move",
+			setupMocks: func(m testMocks) {
+				m.reports.EXPECT().Transition(ctx, 9, 4, entity.ReportStatusActioned).
+					Return(entity.Report{}, errors.New("dismissed to actioned: report cannot move to that status"))
+			},
+			expectedError: errors.New("dismissed to actioned: report cannot move to that status"),
+		},
+		{
+			name:          "reason too long",
+			reason:        strings.Repeat("a", entity.MaxAuditReasonLength+1),
+			setupMocks:    func(m testMocks) {},
+			expectedError: errors.New("reason: the length must be no more than 1000."),
+		},
+		{
+			name: "audit failure",
+			setupMocks: func(m testMocks) {
+				m.reports.EXPECT().Transition(ctx, 9, 4, entity.ReportStatusActioned).Return(reviewed, nil)
+				m.audit.EXPECT().CreateAuditEntry(ctx, gomock.Any()).Return(errors.New("db error"))
+			},
+			expectedError: errors.New("failed to write audit log: db error"),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			service, m := newTestService(t, now)
+			tt.setupMocks(m)
+
+			report, err := service.ReviewReport(ctx, 9, 4, entity.ReportStatusActioned, tt.reason)
+
+			if tt.expectedError != nil {
+				assert.EqualError(t, err, tt.expectedError.Error())
+				return
+			}
+
+			assert.NoError(t, err)
+			assert.Equal(t, tt.expected, report)
+		})
+	}
+}
+
+func TestService_Suspend(t *testing.T) {
+	now := time.Date(2024, 7, 6, 9, 0, 0, 0, time.UTC)
+	ctx := context.Background()
+	active := model.User{ID: 2, Role: entity.RoleUser, Status: entity.UserStatusActive}
+
+	tests := []struct {
+		name          string
+		actorID       int
+		setupMocks    func(m testMocks)
+		expectedError error
+	}{
+		{
+			name:    "suspends, signs out and removes from discovery",
+			actorID: 9,
+			setupMocks: func(m testMocks) {
+				m.users.EXPECT().FindByID(ctx, 2).Return(active, nil)
+				m.users.EXPECT().UpdateStatus(ctx, 2, entity.UserStatusSuspended).Return(nil)
+				m.audit.EXPECT().CreateAuditEntry(ctx, entity.AuditEntry{
+					ActorID:   9,
+					Action:    entity.AuditActionSuspend,
+					TargetID:  2,
+					Reason:    "harassment",
+					CreatedAt: now,
+				}).Return(nil)
+				m.tokens.EXPECT().RevokeUserTokens(ctx, 2).Return(nil)
+				m.index.EXPECT().Delete(ctx, 2).Return(nil)
+			},
+		},
+		{
+			name:          "cannot suspend yourself",
+			actorID:       2,
+			setupMocks:    func(m testMocks) {},
+			expectedError: errors.New("cannot act on yourself: forbidden"),
+		},
+		{
+			name:    "cannot suspend staff",
+			actorID: 9,
+			setupMocks: func(m testMocks) {
+				m.users.EXPECT().FindByID(ctx, 2).Return(model.User{ID: 2, Role: entity.RoleModerator, Status: entity.UserStatusActive}, nil)
+			},
+			expectedError: errors.New("cannot act on staff: forbidden"),
+		},
+		{
+			name:    "already banned",
+			actorID: 9,
+			setupMocks: func(m testMocks) {
+				m.users.EXPECT().FindByID(ctx, 2).Return(model.User{ID: 2, Role: entity.RoleUser, Status: entity.UserStatusBanned}, nil)
+			},
+			expectedError: errors.New("banned to suspended: user cannot move to that status"),
+		},
+		{
+			name:    "user not found",
+			actorID: 9,
+			setupMocks: func(m testMocks) {
+				m.users.EXPECT().FindByID(ctx, 2).Return(model.User{}, entity.ErrUserNotFound)
+			},
+			expectedError: errors.New("failed to find user: user does not exists"),
+		},
+		{
+			name:    "index failure rolls back",
+			actorID: 9,
+			setupMocks: func(m testMocks) {
+				m.users.EXPECT().FindByID(ctx, 2).Return(active, nil)
+				m.users.EXPECT().UpdateStatus(ctx, 2, entity.UserStatusSuspended).Return(nil)
+				m.audit.EXPECT().CreateAuditEntry(ctx, gomock.Any()).Return(nil)
+				m.tokens.EXPECT().RevokeUserTokens(ctx, 2).Return(nil)
+				m.index.EXPECT().Delete(ctx, 2).Return(errors.New("es error"))
+			},
+			expectedError: errors.New("failed to remove from index: es error"),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			service, m := newTestService(t, now)
+			tt.setupMocks(m)
+
+			err := service.Suspend(ctx, tt.actorID, 2, "harassment")
+
+			if tt.expectedError != nil {
+				assert.EqualError(t, err, tt.expectedError.Error())
+				return
+			}
+
+			assert.NoError(t, err)
+		})
+	}
+}
+
+func TestService_Reinstate(t *testing.T) {
+	now := time.Date(2024, 7, 6, 9, 0, 0, 0, time.UTC)
+	ctx := context.Background()
+	suspended := model.User{
+		ID:           2,
+		Email:        "two@muzz.com",
+		Name:         "Two",
+		Gender:       "female",
+		Age:          30,
+		Lat:          51.5,
+		Lon:          -0.12,
+		Role:         entity.RoleUser,
+		Status:       entity.UserStatusSuspended,
+		ShadowBanned: true,
+	}
+	prefs := entity.Preferences{UserID: 2, ShowMe: true}
+
+	tests := []struct {
+		name          string
+		user          model.User
+		setupMocks    func(m testMocks)
+		expectedError error
+	}{
+		{
+			name: "indexes the user again with their preferences and shadow ban",
+			user: suspended,
+			setupMocks: func(m testMocks) {
+				m.users.EXPECT().UpdateStatus(ctx, 2, entity.UserStatusActive).Return(nil)
+				m.audit.EXPECT().CreateAuditEntry(ctx, entity.AuditEntry{
+					ActorID:   9,
+					Action:    entity.AuditActionReinstate,
+					TargetID:  2,
+					CreatedAt: now,
+				}).Return(nil)
+				m.preferences.EXPECT().GetPreferences(ctx, 2).Return(prefs, nil)
+				m.index.EXPECT().Reindex(ctx, entity.User{
+					ID:       2,
+					Name:     "Two",
+					Gender:   "female",
+					Age:      30,
+					Location: entity.Location{Lat: 51.5, Lon: -0.12},
+				}, prefs, true).Return(nil)
+			},
+		},
+		{
+			name: "user without preferences",
+			user: model.User{ID: 2, Role: entity.RoleUser, Status: entity.UserStatusSuspended},
+			setupMocks: func(m testMocks) {
+				m.users.EXPECT().UpdateStatus(ctx, 2, entity.UserStatusActive).Return(nil)
+				m.audit.EXPECT().CreateAuditEntry(ctx, gomock.Any()).Return(nil)
+				m.preferences.EXPECT().GetPreferences(ctx, 2).Return(entity.Preferences{}, entity.ErrPreferencesNotFound)
+				m.index.EXPECT().Reindex(ctx, entity.User{ID: 2}, entity.DefaultPreferences(2), false).Return(nil)
+			},
+		},
+		{
+			name:          "bans are final",
+			user:          model.User{ID: 2, Role: entity.RoleUser, Status: entity.UserStatusBanned},
+			setupMocks:    func(m testMocks) {},
+			expectedError: errors.New("banned to active: user cannot move to that status"),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			service, m := newTestService(t, now)
+			m.users.EXPECT().FindByID(ctx, 2).Return(tt.user, nil)
+			tt.setupMocks(m)
+
+			err := service.Reinstate(ctx, 9, 2, "")
+
+			if tt.expectedError != nil {
+				assert.EqualError(t, err, tt.expectedError.Error())
+				return
+			}
+
+			assert.NoError(t, err)
+		})
+	}
+}
+
+func TestService_ShadowBan(t *testing.T) {
+	now := time.Date(2024, 7, 6, 9, 0, 0, 0, time.UTC)
+	ctx := context.Background()
+
+	tests := []struct {
+		name          string
+		user          model.User
+		shadowBanned  bool
+		setupMocks    func(m testMocks)
+		expectedError error
+	}{
+		{
+			name:         "hides the user from discovery",
+			user:         model.User{ID: 2, Role: entity.RoleUser, Status: entity.UserStatusActive},
+			shadowBanned: true,
+			setupMocks: func(m testMocks) {
+				m.users.EXPECT().UpdateShadowBanned(ctx, 2, true).Return(nil)
+				m.audit.EXPECT().CreateAuditEntry(ctx, entity.AuditEntry{
+					ActorID:   9,
+					Action:    entity.AuditActionShadowBan,
+					TargetID:  2,
+					CreatedAt: now,
+				}).Return(nil)
+				m.index.EXPECT().SetShadowBanned(ctx, 2, true).Return(nil)
+			},
+		},
+		{
+			name: "lifting it from a suspended user waits for reinstatement",
+			user: model.User{ID: 2, Role: entity.RoleUser, Status: entity.UserStatusSuspended, ShadowBanned: true},
+			setupMocks: func(m testMocks) {
+				m.users.EXPECT().UpdateShadowBanned(ctx, 2, false).Return(nil)
+				m.audit.EXPECT().CreateAuditEntry(ctx, entity.AuditEntry{
+					ActorID:   9,
+					Action:    entity.AuditActionUnshadowBan,
+					TargetID:  2,
+					CreatedAt: now,
+				}).Return(nil)
+			},
+		},
+		{
+			name:         "already shadow banned",
+			user:         model.User{ID: 2, Role: entity.RoleUser, Status: entity.UserStatusActive, ShadowBanned: true},
+			shadowBanned: true,
+			setupMocks:   func(m testMocks) {},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			service, m := newTestService(t, now)
+			m.users.EXPECT().FindByID(ctx, 2).Return(tt.user, nil)
+			tt.setupMocks(m)
+
+			err := service.ShadowBan(ctx, 9, 2, tt.shadowBanned, "")
+
+			if tt.expectedError != nil {
+				assert.EqualError(t, err, tt.expectedError.Error())
+				return
+			}
+
+			assert.NoError(t, err)
+		})
+	}
+}
+
+func TestService_ForceUnmatch(t *testing.T) {
+	now := time.Date(2024, 7, 6, 9, 0, 0, 0, time.UTC)
+	ctx := context.Background()
+
+	tests := []struct {
+		name          string
+		setupMocks    func(m testMocks)
+		expectedError error
+	}{
+		{
+			name: "ends the match as the moderator",
+			setupMocks: func(m testMocks) {
+				m.matches.EXPECT().FindMatch(ctx, 7).Return(entity.Match{ID: 7, User1ID: 1, User2ID: 2}, nil)
+				m.matches.EXPECT().Unmatch(ctx, 7, 9).Return(nil)
+				m.audit.EXPECT().CreateAuditEntry(ctx, entity.AuditEntry{
+					ActorID:   9,
+					Action:    entity.AuditActionForceUnmatch,
+					TargetID:  7,
+					Reason:    "underage",
+					CreatedAt: now,
+				}).Return(nil)
+			},
+		},
+		{
+			name: "match has already ended",
+			setupMocks: func(m testMocks) {
+				m.matches.EXPECT().FindMatch(ctx, 7).Return(entity.Match{ID: 7, UnmatchedAt: null.TimeFrom(now)}, nil)
+			},
+			expectedError: entity.ErrMatchEnded,
+		},
+		{
+			name: "match not found",
+			setupMocks: func(m testMocks) {
+				m.matches.EXPECT().FindMatch(ctx, 7).Return(entity.Match{}, entity.ErrMatchNotFound)
+			},
+			expectedError: errors.New("failed to find match: match does not exist"),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			service, m := newTestService(t, now)
+			tt.setupMocks(m)
+
+			err := service.ForceUnmatch(ctx, 9, 7, "underage")
+
+			if tt.expectedError != nil {
+				assert.EqualError(t, err, tt.expectedError.Error())
+				return
+			}
+
+			assert.NoError(t, err)
+		})
+	}
+}
+
+func TestService_SetRole(t *testing.T) {
+	now := time.Date(2024, 7, 6, 9, 0, 0, 0, time.UTC)
+	ctx := context.Background()
+
+	tests := []struct {
+		name          string
+		actorID       int
+		role          entity.Role
+		setupMocks    func(m testMocks)
+		expectedError error
+	}{
+		{
+			name:    "promotes and signs out",
+			actorID: 9,
+			role:    entity.RoleModerator,
+			setupMocks: func(m testMocks) {
+				m.users.EXPECT().FindByID(ctx, 2).Return(model.User{ID: 2, Role: entity.RoleUser}, nil)
+				m.users.EXPECT().UpdateRole(ctx, 2, entity.RoleModerator).Return(nil)
+				m.audit.EXPECT().CreateAuditEntry(ctx, entity.AuditEntry{
+					ActorID:   9,
+					Action:    entity.AuditActionSetRole,
+					TargetID:  2,
+					Detail:    "moderator",
+					CreatedAt: now,
+				}).Return(nil)
+				m.tokens.EXPECT().RevokeUserTokens(ctx, 2).Return(nil)
+			},
+		},
+		{
+			name:    "role unchanged",
+			actorID: 9,
+			role:    entity.RoleUser,
+			setupMocks: func(m testMocks) {
+				m.users.EXPECT().FindByID(ctx, 2).Return(model.User{ID: 2, Role: entity.RoleUser}, nil)
+			},
+		},
+		{
+			name:          "unknown role",
+			actorID:       9,
+			role:          "owner",
+			setupMocks:    func(m testMocks) {},
+			expectedError: errors.New(`unknown role "owner": invalid param`),
+		},
+		{
+			name:          "cannot change your own role",
+			actorID:       2,
+			role:          entity.RoleUser,
+			setupMocks:    func(m testMocks) {},
+			expectedError: errors.New("cannot change your own role: forbidden"),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			service, m := newTestService(t, now)
+			tt.setupMocks(m)
+
+			err := service.SetRole(ctx, tt.actorID, 2, tt.role, "")
+
+			if tt.expectedError != nil {
+				assert.EqualError(t, err, tt.expectedError.Error())
+				return
+			}
+
+			assert.NoError(t, err)
+		})
+	}
+}
@@ -2,57 +2,356 @@ package auth
 
 import (
 	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
 	"errors"
 	"fmt"
+	"sync"
 	"time"
 
 	jwt "github.com/golang-jwt/jwt/v4"
+	"github.com/google/uuid"
+	"go.uber.org/zap"
 
 	"github.com/colmmurphy91/muzz/internal/adapter/mysql/user/model"
+	"github.com/colmmurphy91/muzz/internal/entity"
 )
 
 //go:generate mockgen -source $GOFILE -destination mocks/mocks_${GOFILE} -package mocks
 
-var ErrPasswordDoesNotMatch = errors.New("password does not match")
+var (
+	ErrPasswordDoesNotMatch = errors.New("password does not match")
+	ErrInvalidRefreshToken  = errors.New("invalid refresh token")
+	ErrRefreshTokenReused   = errors.New("refresh token reused")
+	// ErrAccountSuspended is returned to suspended and banned users, who can
+	// neither log in nor refresh their tokens.
+	ErrAccountSuspended = errors.New("account is suspended")
+)
+
+const (
+	DefaultAccessTokenTTL  = 15 * time.Minute
+	DefaultRefreshTokenTTL = 30 * 24 * time.Hour
+)
 
 type userFetcher interface {
 	FindByEmail(ctx context.Context, email string) (model.User, error)
+	FindByID(ctx context.Context, userID int) (model.User, error)
+}
+
+type passwordUpdater interface {
+	UpdatePassword(ctx context.Context, userID int, passwordHash string) error
+}
+
+type passwordHasher interface {
+	Hash(password string) (string, error)
+	Verify(encoded, password string) (bool, error)
+	NeedsRehash(encoded string) bool
+}
+
+type tokenStore interface {
+	CreateRefreshToken(ctx context.Context, token entity.RefreshToken) error
+	FindRefreshToken(ctx context.Context, tokenHash string) (entity.RefreshToken, error)
+	MarkRotated(ctx context.Context, id int) (bool, error)
+	RevokeFamily(ctx context.Context, familyID string) error
+	RevokeAccessToken(ctx context.Context, jti string, expiresAt time.Time) error
+}
+
+type unitOfWork interface {
+	Do(ctx context.Context, fn func(ctx context.Context) error) error
+}
+
+type tokenSigner interface {
+	Sign(claims jwt.Claims) (string, error)
+}
+
+// Config holds the token lifetimes.
+type Config struct {
+	AccessTokenTTL  time.Duration
+	RefreshTokenTTL time.Duration
 }
 
 type Service struct {
-	secretKey   string
-	userFetcher userFetcher
+	logger          *zap.SugaredLogger
+	config          Config
+	tokenSigner     tokenSigner
+	userFetcher     userFetcher
+	passwordUpdater passwordUpdater
+	passwordHasher  passwordHasher
+	tokenStore      tokenStore
+	unitOfWork      unitOfWork
+
+	dummyOnce sync.Once
+	dummy     string
 }
 
-func NewAuthService(secretKey string, fetcher userFetcher) *Service {
-	return &Service{secretKey: secretKey, userFetcher: fetcher}
+func NewAuthService(
+	logger *zap.SugaredLogger,
+	config Config,
+	signer tokenSigner,
+	fetcher userFetcher,
+	updater passwordUpdater,
+	hasher passwordHasher,
+	tokens tokenStore,
+	unitOfWork unitOfWork,
+) *Service {
+	if config.AccessTokenTTL <= 0 {
+		config.AccessTokenTTL = DefaultAccessTokenTTL
+	}
+
+	if config.RefreshTokenTTL <= 0 {
+		config.RefreshTokenTTL = DefaultRefreshTokenTTL
+	}
+
+	return &Service{
+		logger:          logger,
+		config:          config,
+		tokenSigner:     signer,
+		userFetcher:     fetcher,
+		passwordUpdater: updater,
+		passwordHasher:  hasher,
+		tokenStore:      tokens,
+		unitOfWork:      unitOfWork,
+	}
 }
 
-func (s *Service) Authenticate(ctx context.Context, email, password string) (string, error) {
+// Authenticate logs a user in. An unknown email fails exactly like a wrong
+// password, after as long, so neither tells whether an account exists.
+func (s *Service) Authenticate(ctx context.Context, email, password string) (entity.TokenPair, error) {
 	user, err := s.userFetcher.FindByEmail(ctx, email)
+	if errors.Is(err, entity.ErrUserNotFound) {
+		s.passwordHasher.Verify(s.dummyHash(), password) //nolint:errcheck
+
+		return entity.TokenPair{}, ErrPasswordDoesNotMatch
+	}
+
+	if err != nil {
+		return entity.TokenPair{}, fmt.Errorf("failed to retrieve user: %w", err)
+	}
+
+	matches, err := s.passwordHasher.Verify(user.Password, password)
+	if err != nil {
+		return entity.TokenPair{}, fmt.Errorf("failed to verify password: %w", err)
+	}
+
+	if !matches {
+		return entity.TokenPair{}, ErrPasswordDoesNotMatch
+	}
+
+	if user.Status != entity.UserStatusActive {
+		return entity.TokenPair{}, ErrAccountSuspended
+	}
+
+	if s.passwordHasher.NeedsRehash(user.Password) {
+		// Best effort: the stored hash still verifies, so a failure here is
+		// retried on the next successful login.
+		if err := s.rehash(ctx, user.ID, password); err != nil {
+			s.logger.Errorw("failed to rehash password", "user_id", user.ID, "error", err)
+		}
+	}
+
+	return s.issueTokens(ctx, user, uuid.NewString())
+}
+
+// Refresh exchanges a refresh token for a new token pair. Each refresh token can
+// be used once; presenting one that has already been rotated means it was
+// copied, so the whole family is revoked.
+func (s *Service) Refresh(ctx context.Context, refreshToken string) (entity.TokenPair, error) {
+	stored, err := s.findRefreshToken(ctx, refreshToken)
+	if err != nil {
+		return entity.TokenPair{}, err
+	}
+
+	if stored.RotatedAt.Valid {
+		return entity.TokenPair{}, s.revokeReused(ctx, stored.FamilyID)
+	}
+
+	var (
+		pair   entity.TokenPair
+		reused bool
+	)
+
+	// The token is only used up if the new pair is stored with it, so a failure
+	// part way leaves it valid and the client can retry.
+	err = s.unitOfWork.Do(ctx, func(ctx context.Context) error {
+		rotated, err := s.tokenStore.MarkRotated(ctx, stored.ID)
+		if err != nil {
+			return fmt.Errorf("failed to rotate refresh token: %w", err)
+		}
+
+		reused = !rotated
+		if reused {
+			return nil
+		}
+
+		user, err := s.userFetcher.FindByID(ctx, stored.UserID)
+		if err != nil {
+			return fmt.Errorf("failed to retrieve user: %w", err)
+		}
+
+		if user.Status != entity.UserStatusActive {
+			return ErrAccountSuspended
+		}
+
+		pair, err = s.issueTokens(ctx, user, stored.FamilyID)
+
+		return err
+	})
+	if err != nil {
+		return entity.TokenPair{}, err
+	}
+
+	if reused {
+		return entity.TokenPair{}, s.revokeReused(ctx, stored.FamilyID)
+	}
+
+	return pair, nil
+}
+
+// Logout revokes the refresh token's family and the access token used to call it.
+func (s *Service) Logout(ctx context.Context, userID int, refreshToken, accessJTI string, accessExpiresAt time.Time) error {
+	stored, err := s.findRefreshToken(ctx, refreshToken)
+	if err != nil && !errors.Is(err, ErrInvalidRefreshToken) {
+		return err
+	}
+
+	if err == nil {
+		if stored.UserID != userID {
+			return entity.ErrForbidden
+		}
+
+		if err := s.tokenStore.RevokeFamily(ctx, stored.FamilyID); err != nil {
+			return fmt.Errorf("failed to revoke refresh tokens: %w", err)
+		}
+	}
+
+	if err := s.tokenStore.RevokeAccessToken(ctx, accessJTI, accessExpiresAt); err != nil {
+		return fmt.Errorf("failed to revoke access token: %w", err)
+	}
+
+	return nil
+}
+
+func (s *Service) findRefreshToken(ctx context.Context, refreshToken string) (entity.RefreshToken, error) {
+	stored, err := s.tokenStore.FindRefreshToken(ctx, hashToken(refreshToken))
+	if err != nil {
+		if errors.Is(err, entity.ErrRefreshTokenNotFound) {
+			return entity.RefreshToken{}, ErrInvalidRefreshToken
+		}
+
+		return entity.RefreshToken{}, fmt.Errorf("failed to find refresh token: %w", err)
+	}
+
+	if stored.RevokedAt.Valid || time.Now().After(stored.ExpiresAt) {
+		return entity.RefreshToken{}, ErrInvalidRefreshToken
+	}
+
+	return stored, nil
+}
+
+func (s *Service) revokeReused(ctx context.Context, familyID string) error {
+	if err := s.tokenStore.RevokeFamily(ctx, familyID); err != nil {
+		return fmt.Errorf("failed to revoke reused token family: %w", err)
+	}
+
+	return ErrRefreshTokenReused
+}
+
+func (s *Service) issueTokens(ctx context.Context, user model.User, familyID string) (entity.TokenPair, error) {
+	now := time.Now()
+	jti := uuid.NewString()
+	accessExpiresAt := now.Add(s.config.AccessTokenTTL)
+
+	accessToken, err := s.generateJWT(user, jti, now, accessExpiresAt)
 	if err != nil {
-		return "", fmt.Errorf("failed to retrieve user: %w", err)
+		return entity.TokenPair{}, err
 	}
 
-	if user.Password != password {
-		return "", ErrPasswordDoesNotMatch
+	refreshToken, err := generateRefreshToken()
+	if err != nil {
+		return entity.TokenPair{}, err
+	}
+
+	err = s.tokenStore.CreateRefreshToken(ctx, entity.RefreshToken{
+		FamilyID:        familyID,
+		UserID:          user.ID,
+		TokenHash:       hashToken(refreshToken),
+		AccessJTI:       jti,
+		AccessExpiresAt: accessExpiresAt,
+		ExpiresAt:       now.Add(s.config.RefreshTokenTTL),
+	})
+	if err != nil {
+		return entity.TokenPair{}, fmt.Errorf("failed to store refresh token: %w", err)
 	}
 
-	return s.generateJWT(email, user.ID)
+	return entity.TokenPair{
+		AccessToken:  accessToken,
+		RefreshToken: refreshToken,
+		ExpiresIn:    s.config.AccessTokenTTL,
+	}, nil
 }
 
-func (s *Service) generateJWT(email string, id int) (string, error) {
+// dummyHash is a hash from the current hasher that no password is checked
+// against for real, verified when there is no user so a miss costs the same as
+// a wrong password. It is made on first use.
+func (s *Service) dummyHash() string {
+	s.dummyOnce.Do(func() {
+		hash, err := s.passwordHasher.Hash("dummy password")
+		if err != nil {
+			s.logger.Errorw("failed to hash dummy password", "error", err)
+			return
+		}
+
+		s.dummy = hash
+	})
+
+	return s.dummy
+}
+
+func (s *Service) rehash(ctx context.Context, userID int, password string) error {
+	hash, err := s.passwordHasher.Hash(password)
+	if err != nil {
+		return fmt.Errorf("failed to hash password: %w", err)
+	}
+
+	if err := s.passwordUpdater.UpdatePassword(ctx, userID, hash); err != nil {
+		return fmt.Errorf("failed to update password: %w", err)
+	}
+
+	return nil
+}
+
+func (s *Service) generateJWT(user model.User, jti string, issuedAt, expiresAt time.Time) (string, error) {
 	claims := jwt.MapClaims{
-		"user_id": id,
-		"email":   email,
-		"exp":     time.Now().Add(time.Hour * 72).Unix(), // Token expiration time
+		"user_id": user.ID,
+		"email":   user.Email,
+		"role":    user.Role,
+		"jti":     jti,
+		"iat":     issuedAt.Unix(),
+		"exp":     expiresAt.Unix(), // Token expiration time
 	}
-	token := jwt.NewWithClaims(jwt.SigningMethodHS256, claims)
 
-	signedString, err := token.SignedString([]byte(s.secretKey))
+	signedString, err := s.tokenSigner.Sign(claims)
 	if err != nil {
 		return "", fmt.Errorf("failed to sign string: %w", err)
 	}
 
 	return signedString, nil
 }
+
+// generateRefreshToken returns an opaque random token. Only its hash is stored.
+func generateRefreshToken() (string, error) {
+	b := make([]byte, 32)
+	if _, err := rand.Read(b); err != nil {
+		return "", fmt.Errorf("failed to generate refresh token: %w", err)
+	}
+
+	return base64.RawURLEncoding.EncodeToString(b), nil
+}
+
+func hashToken(token string) string {
+	hash := sha256.Sum256([]byte(token))
+
+	return hex.EncodeToString(hash[:])
+}
@@ -3,15 +3,22 @@ package auth
 
 import (
 	"context"
+	"crypto/ed25519"
+	"crypto/rand"
+	"errors"
 	"fmt"
 	"testing"
 	"time"
 
 	jwt "github.com/golang-jwt/jwt/v4"
 	"github.com/golang/mock/gomock"
+	null "github.com/guregu/null/v5"
 	"github.com/stretchr/testify/assert"
+	"go.uber.org/zap"
 
 	"github.com/colmmurphy91/muzz/internal/adapter/mysql/user/model"
+	"github.com/colmmurphy91/muzz/internal/entity"
+	"github.com/colmmurphy91/muzz/internal/pkg/signing"
 	"github.com/colmmurphy91/muzz/internal/usecase/auth/mocks"
 )
 
@@ -20,8 +27,11 @@ func TestAuthService_Authenticate(t *testing.T) {
 	defer ctrl.Finish()
 
 	mockUserFetcher := mocks.NewMockuserFetcher(ctrl)
-	secretKey := "testsecretkey"
-	authService := NewAuthService(secretKey, mockUserFetcher)
+	mockPasswordUpdater := mocks.NewMockpasswordUpdater(ctrl)
+	mockPasswordHasher := mocks.NewMockpasswordHasher(ctrl)
+	mockTokenStore := mocks.NewMocktokenStore(ctrl)
+	keys := newTestKeySet(t)
+	authService := NewAuthService(zap.NewNop().Sugar(), Config{}, keys, mockUserFetcher, mockPasswordUpdater, mockPasswordHasher, mockTokenStore, nil)
 
 	tests := []struct {
 		name          string
@@ -39,24 +49,109 @@ func TestAuthService_Authenticate(t *testing.T) {
 				mockUserFetcher.EXPECT().
 					FindByEmail(gomock.Any(), "test@example.com").
 					Return(model.User{
+						ID:       1,
 						Email:    "test@example.com",
-						Password: "password123",
+						Password: "hashed-password123",
+						Role:     entity.RoleUser,
+						Status:   entity.UserStatusActive,
 					}, nil)
+				mockPasswordHasher.EXPECT().Verify("hashed-password123", "password123").Return(true, nil)
+				mockPasswordHasher.EXPECT().NeedsRehash("hashed-password123").Return(false)
+				mockTokenStore.EXPECT().CreateRefreshToken(gomock.Any(), gomock.Any()).Return(nil)
 			},
 			expectedToken: "", // We will verify the token format instead of exact match
 			expectedErr:   nil,
 		},
 		{
-			name:     "user not found",
+			name:     "successful authentication upgrades outdated hash",
+			email:    "test@example.com",
+			password: "password123",
+			setupMock: func() {
+				mockUserFetcher.EXPECT().
+					FindByEmail(gomock.Any(), "test@example.com").
+					Return(model.User{
+						ID:       1,
+						Email:    "test@example.com",
+						Password: "password123",
+						Role:     entity.RoleUser,
+						Status:   entity.UserStatusActive,
+					}, nil)
+				mockPasswordHasher.EXPECT().Verify("password123", "password123").Return(true, nil)
+				mockPasswordHasher.EXPECT().NeedsRehash("password123").Return(true)
+				mockPasswordHasher.EXPECT().Hash("password123").Return("hashed-password123", nil)
+				mockPasswordUpdater.EXPECT().UpdatePassword(gomock.Any(), 1, "hashed-password123").Return(nil)
+				mockTokenStore.EXPECT().CreateRefreshToken(gomock.Any(), gomock.Any()).Return(nil)
+			},
+			expectedToken: "",
+			expectedErr:   nil,
+		},
+		{
+			name:     "failed rehash does not block login",
+			email:    "test@example.com",
+			password: "password123",
+			setupMock: func() {
+				mockUserFetcher.EXPECT().
+					FindByEmail(gomock.Any(), "test@example.com").
+					Return(model.User{
+						ID:       1,
+						Email:    "test@example.com",
+						Password: "password123",
+						Role:     entity.RoleUser,
+						Status:   entity.UserStatusActive,
+					}, nil)
+				mockPasswordHasher.EXPECT().Verify("password123", "password123").Return(true, nil)
+				mockPasswordHasher.EXPECT().NeedsRehash("password123").Return(true)
+				mockPasswordHasher.EXPECT().Hash("password123").Return("hashed-password123", nil)
+				mockPasswordUpdater.EXPECT().UpdatePassword(gomock.Any(), 1, "hashed-password123").Return(fmt.Errorf("db error"))
+				mockTokenStore.EXPECT().CreateRefreshToken(gomock.Any(), gomock.Any()).Return(nil)
+			},
+			expectedToken: "",
+			expectedErr:   nil,
+		},
+		{
+			name:     "suspended account",
+			email:    "test@example.com",
+			password: "password123",
+			setupMock: func() {
+				mockUserFetcher.EXPECT().
+					FindByEmail(gomock.Any(), "test@example.com").
+					Return(model.User{
+						ID:       1,
+						Email:    "test@example.com",
+						Password: "hashed-password123",
+						Role:     entity.RoleUser,
+						Status:   entity.UserStatusSuspended,
+					}, nil)
+				mockPasswordHasher.EXPECT().Verify("hashed-password123", "password123").Return(true, nil)
+			},
+			expectedToken: "",
+			expectedErr:   ErrAccountSuspended,
+		},
+		{
+			name:     "user not found fails like a wrong password",
 			email:    "notfound@example.com",
 			password: "password123",
 			setupMock: func() {
 				mockUserFetcher.EXPECT().
 					FindByEmail(gomock.Any(), "notfound@example.com").
-					Return(model.User{}, fmt.Errorf("user not found"))
+					Return(model.User{}, entity.ErrUserNotFound)
+				mockPasswordHasher.EXPECT().Hash("dummy password").Return("hashed-dummy", nil)
+				mockPasswordHasher.EXPECT().Verify("hashed-dummy", "password123").Return(false, nil)
 			},
 			expectedToken: "",
-			expectedErr:   fmt.Errorf("failed to retrieve user: user not found"),
+			expectedErr:   ErrPasswordDoesNotMatch,
+		},
+		{
+			name:     "user lookup failure",
+			email:    "test@example.com",
+			password: "password123",
+			setupMock: func() {
+				mockUserFetcher.EXPECT().
+					FindByEmail(gomock.Any(), "test@example.com").
+					Return(model.User{}, fmt.Errorf("db error"))
+			},
+			expectedToken: "",
+			expectedErr:   fmt.Errorf("failed to retrieve user: db error"),
 		},
 		{
 			name:     "incorrect password",
@@ -67,39 +162,258 @@ func TestAuthService_Authenticate(t *testing.T) {
 					FindByEmail(gomock.Any(), "test@example.com").
 					Return(model.User{
 						Email:    "test@example.com",
-						Password: "password123",
+						Password: "hashed-password123",
 					}, nil)
+				mockPasswordHasher.EXPECT().Verify("hashed-password123", "wrongpassword").Return(false, nil)
 			},
 			expectedToken: "",
 			expectedErr:   ErrPasswordDoesNotMatch,
 		},
+		{
+			name:     "unrecognised hash",
+			email:    "test@example.com",
+			password: "password123",
+			setupMock: func() {
+				mockUserFetcher.EXPECT().
+					FindByEmail(gomock.Any(), "test@example.com").
+					Return(model.User{
+						Email:    "test@example.com",
+						Password: "$unknown$hash",
+					}, nil)
+				mockPasswordHasher.EXPECT().Verify("$unknown$hash", "password123").Return(false, fmt.Errorf("unknown password hash format"))
+			},
+			expectedToken: "",
+			expectedErr:   fmt.Errorf("failed to verify password: unknown password hash format"),
+		},
 	}
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			tt.setupMock()
 
-			token, err := authService.Authenticate(context.Background(), tt.email, tt.password)
+			tokens, err := authService.Authenticate(context.Background(), tt.email, tt.password)
+			token := tokens.AccessToken
 
 			if tt.expectedErr != nil {
 				assert.EqualError(t, err, tt.expectedErr.Error())
 			} else {
 				assert.NoError(t, err)
 				// Verify the token format instead of exact match
-				parsedToken, err := jwt.Parse(token, func(token *jwt.Token) (interface{}, error) {
-					if _, ok := token.Method.(*jwt.SigningMethodHMAC); !ok {
-						return nil, fmt.Errorf("unexpected signing method: %v", token.Header["alg"])
-					}
-
-					return []byte(secretKey), nil
-				})
+				parsedToken, err := jwt.Parse(token, keys.Keyfunc)
 				assert.NoError(t, err)
 				assert.True(t, parsedToken.Valid)
+				assert.Equal(t, keys.Keys()[0].ID, parsedToken.Header["kid"])
 				claims, ok := parsedToken.Claims.(jwt.MapClaims)
 				assert.True(t, ok)
 				assert.Equal(t, tt.email, claims["email"])
-				assert.WithinDuration(t, time.Unix(int64(claims["exp"].(float64)), 0), time.Now().Add(time.Hour*72), time.Minute)
+				assert.Equal(t, "user", claims["role"])
+				assert.NotEmpty(t, claims["jti"])
+				assert.WithinDuration(t, time.Unix(int64(claims["exp"].(float64)), 0), time.Now().Add(DefaultAccessTokenTTL), time.Minute)
+				assert.NotEmpty(t, tokens.RefreshToken)
+				assert.Equal(t, DefaultAccessTokenTTL, tokens.ExpiresIn)
 			}
 		})
 	}
 }
+
+func TestAuthService_Refresh(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockUserFetcher := mocks.NewMockuserFetcher(ctrl)
+	mockTokenStore := mocks.NewMocktokenStore(ctrl)
+	mockUnitOfWork := mocks.NewMockunitOfWork(ctrl)
+	authService := NewAuthService(zap.NewNop().Sugar(), Config{}, newTestKeySet(t), mockUserFetcher, nil, nil, mockTokenStore, mockUnitOfWork)
+
+	mockUnitOfWork.EXPECT().Do(gomock.Any(), gomock.Any()).DoAndReturn(
+		func(ctx context.Context, fn func(ctx context.Context) error) error {
+			return fn(ctx)
+		}).AnyTimes()
+
+	refreshToken := "refresh-token"
+	errDB := errors.New("db error")
+	active := entity.RefreshToken{
+		ID:        7,
+		FamilyID:  "family-1",
+		UserID:    1,
+		TokenHash: hashToken(refreshToken),
+		ExpiresAt: time.Now().Add(time.Hour),
+	}
+
+	tests := []struct {
+		name        string
+		setupMock   func()
+		expectedErr error
+	}{
+		{
+			name: "rotates a valid token within its family",
+			setupMock: func() {
+				mockTokenStore.EXPECT().FindRefreshToken(gomock.Any(), hashToken(refreshToken)).Return(active, nil)
+				mockTokenStore.EXPECT().MarkRotated(gomock.Any(), 7).Return(true, nil)
+				mockUserFetcher.EXPECT().FindByID(gomock.Any(), 1).Return(model.User{ID: 1, Email: "test@example.com", Status: entity.UserStatusActive}, nil)
+				mockTokenStore.EXPECT().
+					CreateRefreshToken(gomock.Any(), gomock.Any()).
+					DoAndReturn(func(_ context.Context, token entity.RefreshToken) error {
+						assert.Equal(t, "family-1", token.FamilyID)
+						assert.Equal(t, 1, token.UserID)
+						assert.NotEqual(t, hashToken(refreshToken), token.TokenHash)
+
+						return nil
+					})
+			},
+		},
+		{
+			name: "failing to store the new token does not revoke the family",
+			setupMock: func() {
+				mockTokenStore.EXPECT().FindRefreshToken(gomock.Any(), hashToken(refreshToken)).Return(active, nil)
+				mockTokenStore.EXPECT().MarkRotated(gomock.Any(), 7).Return(true, nil)
+				mockUserFetcher.EXPECT().FindByID(gomock.Any(), 1).Return(model.User{ID: 1, Status: entity.UserStatusActive}, nil)
+				mockTokenStore.EXPECT().CreateRefreshToken(gomock.Any(), gomock.Any()).Return(errDB)
+			},
+			expectedErr: errDB,
+		},
+		{
+			name: "banned user",
+			setupMock: func() {
+				mockTokenStore.EXPECT().FindRefreshToken(gomock.Any(), hashToken(refreshToken)).Return(active, nil)
+				mockTokenStore.EXPECT().MarkRotated(gomock.Any(), 7).Return(true, nil)
+				mockUserFetcher.EXPECT().FindByID(gomock.Any(), 1).Return(model.User{ID: 1, Status: entity.UserStatusBanned}, nil)
+			},
+			expectedErr: ErrAccountSuspended,
+		},
+		{
+			name: "unknown token",
+			setupMock: func() {
+				mockTokenStore.EXPECT().FindRefreshToken(gomock.Any(), gomock.Any()).Return(entity.RefreshToken{}, entity.ErrRefreshTokenNotFound)
+			},
+			expectedErr: ErrInvalidRefreshToken,
+		},
+		{
+			name: "expired token",
+			setupMock: func() {
+				expired := active
+				expired.ExpiresAt = time.Now().Add(-time.Minute)
+				mockTokenStore.EXPECT().FindRefreshToken(gomock.Any(), gomock.Any()).Return(expired, nil)
+			},
+			expectedErr: ErrInvalidRefreshToken,
+		},
+		{
+			name: "revoked token",
+			setupMock: func() {
+				revoked := active
+				revoked.RevokedAt = null.TimeFrom(time.Now())
+				mockTokenStore.EXPECT().FindRefreshToken(gomock.Any(), gomock.Any()).Return(revoked, nil)
+			},
+			expectedErr: ErrInvalidRefreshToken,
+		},
+		{
+			name: "replayed token revokes the family",
+			setupMock: func() {
+				rotated := active
+				rotated.RotatedAt = null.TimeFrom(time.Now())
+				mockTokenStore.EXPECT().FindRefreshToken(gomock.Any(), gomock.Any()).Return(rotated, nil)
+				mockTokenStore.EXPECT().RevokeFamily(gomock.Any(), "family-1").Return(nil)
+			},
+			expectedErr: ErrRefreshTokenReused,
+		},
+		{
+			name: "concurrent rotation revokes the family",
+			setupMock: func() {
+				mockTokenStore.EXPECT().FindRefreshToken(gomock.Any(), gomock.Any()).Return(active, nil)
+				mockTokenStore.EXPECT().MarkRotated(gomock.Any(), 7).Return(false, nil)
+				mockTokenStore.EXPECT().RevokeFamily(gomock.Any(), "family-1").Return(nil)
+			},
+			expectedErr: ErrRefreshTokenReused,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.setupMock()
+
+			tokens, err := authService.Refresh(context.Background(), refreshToken)
+
+			if tt.expectedErr != nil {
+				assert.ErrorIs(t, err, tt.expectedErr)
+				assert.Empty(t, tokens.AccessToken)
+			} else {
+				assert.NoError(t, err)
+				assert.NotEmpty(t, tokens.AccessToken)
+				assert.NotEmpty(t, tokens.RefreshToken)
+				assert.NotEqual(t, refreshToken, tokens.RefreshToken)
+			}
+		})
+	}
+}
+
+func TestAuthService_Logout(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockTokenStore := mocks.NewMocktokenStore(ctrl)
+	authService := NewAuthService(zap.NewNop().Sugar(), Config{}, newTestKeySet(t), nil, nil, nil, mockTokenStore, nil)
+
+	accessExpiry := time.Now().Add(time.Minute)
+	stored := entity.RefreshToken{ID: 7, FamilyID: "family-1", UserID: 1, ExpiresAt: time.Now().Add(time.Hour)}
+
+	tests := []struct {
+		name        string
+		userID      int
+		setupMock   func()
+		expectedErr error
+	}{
+		{
+			name:   "revokes refresh family and access token",
+			userID: 1,
+			setupMock: func() {
+				mockTokenStore.EXPECT().FindRefreshToken(gomock.Any(), gomock.Any()).Return(stored, nil)
+				mockTokenStore.EXPECT().RevokeFamily(gomock.Any(), "family-1").Return(nil)
+				mockTokenStore.EXPECT().RevokeAccessToken(gomock.Any(), "jti-1", accessExpiry).Return(nil)
+			},
+		},
+		{
+			name:   "already revoked refresh token still revokes access token",
+			userID: 1,
+			setupMock: func() {
+				mockTokenStore.EXPECT().FindRefreshToken(gomock.Any(), gomock.Any()).Return(entity.RefreshToken{}, entity.ErrRefreshTokenNotFound)
+				mockTokenStore.EXPECT().RevokeAccessToken(gomock.Any(), "jti-1", accessExpiry).Return(nil)
+			},
+		},
+		{
+			name:   "refresh token belonging to someone else",
+			userID: 2,
+			setupMock: func() {
+				mockTokenStore.EXPECT().FindRefreshToken(gomock.Any(), gomock.Any()).Return(stored, nil)
+			},
+			expectedErr: entity.ErrForbidden,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.setupMock()
+
+			err := authService.Logout(context.Background(), tt.userID, "refresh-token", "jti-1", accessExpiry)
+
+			if tt.expectedErr != nil {
+				assert.ErrorIs(t, err, tt.expectedErr)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
+
+func newTestKeySet(t *testing.T) *signing.KeySet {
+	_, privateKey, err := ed25519.GenerateKey(rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	keys, err := signing.NewKeySet(privateKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return keys
+}
@@ -7,8 +7,11 @@ package mocks
 import (
 	context "context"
 	reflect "reflect"
+	time "time"
 
 	model "github.com/colmmurphy91/muzz/internal/adapter/mysql/user/model"
+	entity "github.com/colmmurphy91/muzz/internal/entity"
+	jwt "github.com/golang-jwt/jwt/v4"
 	gomock "github.com/golang/mock/gomock"
 )
 
@@ -49,3 +52,292 @@ func (mr *MockuserFetcherMockRecorder) FindByEmail(ctx, email interface{}) *gomo
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindByEmail", reflect.TypeOf((*MockuserFetcher)(nil).FindByEmail), ctx, email)
 }
+
+// FindByID mocks base method.
+func (m *MockuserFetcher) FindByID(ctx context.Context, userID int) (model.User, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindByID", ctx, userID)
+	ret0, _ := ret[0].(model.User)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindByID indicates an expected call of FindByID.
+func (mr *MockuserFetcherMockRecorder) FindByID(ctx, userID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindByID", reflect.TypeOf((*MockuserFetcher)(nil).FindByID), ctx, userID)
+}
+
+// MockpasswordUpdater is a mock of passwordUpdater interface.
+type MockpasswordUpdater struct {
+	ctrl     *gomock.Controller
+	recorder *MockpasswordUpdaterMockRecorder
+}
+
+// MockpasswordUpdaterMockRecorder is the mock recorder for MockpasswordUpdater.
+type MockpasswordUpdaterMockRecorder struct {
+	mock *MockpasswordUpdater
+}
+
+// NewMockpasswordUpdater creates a new mock instance.
+func NewMockpasswordUpdater(ctrl *gomock.Controller) *MockpasswordUpdater {
+	mock := &MockpasswordUpdater{ctrl: ctrl}
+	mock.recorder = &MockpasswordUpdaterMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockpasswordUpdater) EXPECT() *MockpasswordUpdaterMockRecorder {
+	return m.recorder
+}
+
+// UpdatePassword mocks base method.
+func (m *MockpasswordUpdater) UpdatePassword(ctx context.Context, userID int, passwordHash string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdatePassword", ctx, userID, passwordHash)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdatePassword indicates an expected call of UpdatePassword.
+func (mr *MockpasswordUpdaterMockRecorder) UpdatePassword(ctx, userID, passwordHash interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdatePassword", reflect.TypeOf((*MockpasswordUpdater)(nil).UpdatePassword), ctx, userID, passwordHash)
+}
+
+// MockpasswordHasher is a mock of passwordHasher interface.
+type MockpasswordHasher struct {
+	ctrl     *gomock.Controller
+	recorder *MockpasswordHasherMockRecorder
+}
+
+// MockpasswordHasherMockRecorder is the mock recorder for MockpasswordHasher.
+type MockpasswordHasherMockRecorder struct {
+	mock *MockpasswordHasher
+}
+
+// NewMockpasswordHasher creates a new mock instance.
+func NewMockpasswordHasher(ctrl *gomock.Controller) *MockpasswordHasher {
+	mock := &MockpasswordHasher{ctrl: ctrl}
+	mock.recorder = &MockpasswordHasherMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockpasswordHasher) EXPECT() *MockpasswordHasherMockRecorder {
+	return m.recorder
+}
+
+// Hash mocks base method.
+func (m *MockpasswordHasher) Hash(password string) (string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Hash", password)
+	ret0, _ := ret[0].(string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Hash indicates an expected call of Hash.
+func (mr *MockpasswordHasherMockRecorder) Hash(password interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Hash", reflect.TypeOf((*MockpasswordHasher)(nil).Hash), password)
+}
+
+// NeedsRehash mocks base method.
+func (m *MockpasswordHasher) NeedsRehash(encoded string) bool {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "NeedsRehash", encoded)
+	ret0, _ := ret[0].(bool)
+	return ret0
+}
+
+// NeedsRehash indicates an expected call of NeedsRehash.
+func (mr *MockpasswordHasherMockRecorder) NeedsRehash(encoded interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "NeedsRehash", reflect.TypeOf((*MockpasswordHasher)(nil).NeedsRehash), encoded)
+}
+
+// Verify mocks base method.
+func (m *MockpasswordHasher) Verify(encoded, password string) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Verify", encoded, password)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Verify indicates an expected call of Verify.
+func (mr *MockpasswordHasherMockRecorder) Verify(encoded, password interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Verify", reflect.TypeOf((*MockpasswordHasher)(nil).Verify), encoded, password)
+}
+
+// MocktokenStore is a mock of tokenStore interface.
+type MocktokenStore struct {
+	ctrl     *gomock.Controller
+	recorder *MocktokenStoreMockRecorder
+}
+
+// MocktokenStoreMockRecorder is the mock recorder for MocktokenStore.
+type MocktokenStoreMockRecorder struct {
+	mock *MocktokenStore
+}
+
+// NewMocktokenStore creates a new mock instance.
+func NewMocktokenStore(ctrl *gomock.Controller) *MocktokenStore {
+	mock := &MocktokenStore{ctrl: ctrl}
+	mock.recorder = &MocktokenStoreMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MocktokenStore) EXPECT() *MocktokenStoreMockRecorder {
+	return m.recorder
+}
+
+// CreateRefreshToken mocks base method.
+func (m *MocktokenStore) CreateRefreshToken(ctx context.Context, token entity.RefreshToken) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateRefreshToken", ctx, token)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CreateRefreshToken indicates an expected call of CreateRefreshToken.
+func (mr *MocktokenStoreMockRecorder) CreateRefreshToken(ctx, token interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateRefreshToken", reflect.TypeOf((*MocktokenStore)(nil).CreateRefreshToken), ctx, token)
+}
+
+// FindRefreshToken mocks base method.
+func (m *MocktokenStore) FindRefreshToken(ctx context.Context, tokenHash string) (entity.RefreshToken, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindRefreshToken", ctx, tokenHash)
+	ret0, _ := ret[0].(entity.RefreshToken)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindRefreshToken indicates an expected call of FindRefreshToken.
+func (mr *MocktokenStoreMockRecorder) FindRefreshToken(ctx, tokenHash interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindRefreshToken", reflect.TypeOf((*MocktokenStore)(nil).FindRefreshToken), ctx, tokenHash)
+}
+
+// MarkRotated mocks base method.
+func (m *MocktokenStore) MarkRotated(ctx context.Context, id int) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "MarkRotated", ctx, id)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// MarkRotated indicates an expected call of MarkRotated.
+func (mr *MocktokenStoreMockRecorder) MarkRotated(ctx, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MarkRotated", reflect.TypeOf((*MocktokenStore)(nil).MarkRotated), ctx, id)
+}
+
+// RevokeAccessToken mocks base method.
+func (m *MocktokenStore) RevokeAccessToken(ctx context.Context, jti string, expiresAt time.Time) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RevokeAccessToken", ctx, jti, expiresAt)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RevokeAccessToken indicates an expected call of RevokeAccessToken.
+func (mr *MocktokenStoreMockRecorder) RevokeAccessToken(ctx, jti, expiresAt interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RevokeAccessToken", reflect.TypeOf((*MocktokenStore)(nil).RevokeAccessToken), ctx, jti, expiresAt)
+}
+
+// RevokeFamily mocks base method.
+func (m *MocktokenStore) RevokeFamily(ctx context.Context, familyID string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RevokeFamily", ctx, familyID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RevokeFamily indicates an expected call of RevokeFamily.
+func (mr *MocktokenStoreMockRecorder) RevokeFamily(ctx, familyID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RevokeFamily", reflect.TypeOf((*MocktokenStore)(nil).RevokeFamily), ctx, familyID)
+}
+
+// MockunitOfWork is a mock of unitOfWork interface.
+type MockunitOfWork struct {
+	ctrl     *gomock.Controller
+	recorder *MockunitOfWorkMockRecorder
+}
+
+// MockunitOfWorkMockRecorder is the mock recorder for MockunitOfWork.
+type MockunitOfWorkMockRecorder struct {
+	mock *MockunitOfWork
+}
+
+// NewMockunitOfWork creates a new mock instance.
+func NewMockunitOfWork(ctrl *gomock.Controller) *MockunitOfWork {
+	mock := &MockunitOfWork{ctrl: ctrl}
+	mock.recorder = &MockunitOfWorkMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockunitOfWork) EXPECT() *MockunitOfWorkMockRecorder {
+	return m.recorder
+}
+
+// Do mocks base method.
+func (m *MockunitOfWork) Do(ctx context.Context, fn func(context.Context) error) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Do", ctx, fn)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Do indicates an expected call of Do.
+func (mr *MockunitOfWorkMockRecorder) Do(ctx, fn interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Do", reflect.TypeOf((*MockunitOfWork)(nil).Do), ctx, fn)
+}
+
+// MocktokenSigner is a mock of tokenSigner interface.
+type MocktokenSigner struct {
+	ctrl     *gomock.Controller
+	recorder *MocktokenSignerMockRecorder
+}
+
+// MocktokenSignerMockRecorder is the mock recorder for MocktokenSigner.
+type MocktokenSignerMockRecorder struct {
+	mock *MocktokenSigner
+}
+
+// NewMocktokenSigner creates a new mock instance.
+func NewMocktokenSigner(ctrl *gomock.Controller) *MocktokenSigner {
+	mock := &MocktokenSigner{ctrl: ctrl}
+	mock.recorder = &MocktokenSignerMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MocktokenSigner) EXPECT() *MocktokenSignerMockRecorder {
+	return m.recorder
+}
+
+// Sign mocks base method.
+func (m *MocktokenSigner) Sign(claims jwt.Claims) (string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Sign", claims)
+	ret0, _ := ret[0].(string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Sign indicates an expected call of Sign.
+func (mr *MocktokenSignerMockRecorder) Sign(claims interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Sign", reflect.TypeOf((*MocktokenSigner)(nil).Sign), claims)
+}
@@ -0,0 +1,178 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: service.go
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	context "context"
+	reflect "reflect"
+
+	model "github.com/colmmurphy91/muzz/internal/adapter/mysql/user/model"
+	entity "github.com/colmmurphy91/muzz/internal/entity"
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockblockStore is a mock of blockStore interface.
+type MockblockStore struct {
+	ctrl     *gomock.Controller
+	recorder *MockblockStoreMockRecorder
+}
+
+// MockblockStoreMockRecorder is the mock recorder for MockblockStore.
+type MockblockStoreMockRecorder struct {
+	mock *MockblockStore
+}
+
+// NewMockblockStore creates a new mock instance.
+func NewMockblockStore(ctrl *gomock.Controller) *MockblockStore {
+	mock := &MockblockStore{ctrl: ctrl}
+	mock.recorder = &MockblockStoreMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockblockStore) EXPECT() *MockblockStoreMockRecorder {
+	return m.recorder
+}
+
+// Block mocks base method.
+func (m *MockblockStore) Block(ctx context.Context, blockerID, blockedID int) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Block", ctx, blockerID, blockedID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Block indicates an expected call of Block.
+func (mr *MockblockStoreMockRecorder) Block(ctx, blockerID, blockedID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Block", reflect.TypeOf((*MockblockStore)(nil).Block), ctx, blockerID, blockedID)
+}
+
+// MockuserFetcher is a mock of userFetcher interface.
+type MockuserFetcher struct {
+	ctrl     *gomock.Controller
+	recorder *MockuserFetcherMockRecorder
+}
+
+// MockuserFetcherMockRecorder is the mock recorder for MockuserFetcher.
+type MockuserFetcherMockRecorder struct {
+	mock *MockuserFetcher
+}
+
+// NewMockuserFetcher creates a new mock instance.
+func NewMockuserFetcher(ctrl *gomock.Controller) *MockuserFetcher {
+	mock := &MockuserFetcher{ctrl: ctrl}
+	mock.recorder = &MockuserFetcherMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockuserFetcher) EXPECT() *MockuserFetcherMockRecorder {
+	return m.recorder
+}
+
+// FindByID mocks base method.
+func (m *MockuserFetcher) FindByID(ctx context.Context, userID int) (model.User, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindByID", ctx, userID)
+	ret0, _ := ret[0].(model.User)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindByID indicates an expected call of FindByID.
+func (mr *MockuserFetcherMockRecorder) FindByID(ctx, userID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindByID", reflect.TypeOf((*MockuserFetcher)(nil).FindByID), ctx, userID)
+}
+
+// MockmatchStore is a mock of matchStore interface.
+type MockmatchStore struct {
+	ctrl     *gomock.Controller
+	recorder *MockmatchStoreMockRecorder
+}
+
+// MockmatchStoreMockRecorder is the mock recorder for MockmatchStore.
+type MockmatchStoreMockRecorder struct {
+	mock *MockmatchStore
+}
+
+// NewMockmatchStore creates a new mock instance.
+func NewMockmatchStore(ctrl *gomock.Controller) *MockmatchStore {
+	mock := &MockmatchStore{ctrl: ctrl}
+	mock.recorder = &MockmatchStoreMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockmatchStore) EXPECT() *MockmatchStoreMockRecorder {
+	return m.recorder
+}
+
+// FindMatchByPair mocks base method.
+func (m *MockmatchStore) FindMatchByPair(ctx context.Context, user1ID, user2ID int) (entity.Match, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindMatchByPair", ctx, user1ID, user2ID)
+	ret0, _ := ret[0].(entity.Match)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindMatchByPair indicates an expected call of FindMatchByPair.
+func (mr *MockmatchStoreMockRecorder) FindMatchByPair(ctx, user1ID, user2ID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindMatchByPair", reflect.TypeOf((*MockmatchStore)(nil).FindMatchByPair), ctx, user1ID, user2ID)
+}
+
+// Unmatch mocks base method.
+func (m *MockmatchStore) Unmatch(ctx context.Context, id, userID int) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Unmatch", ctx, id, userID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Unmatch indicates an expected call of Unmatch.
+func (mr *MockmatchStoreMockRecorder) Unmatch(ctx, id, userID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Unmatch", reflect.TypeOf((*MockmatchStore)(nil).Unmatch), ctx, id, userID)
+}
+
+// MockunitOfWork is a mock of unitOfWork interface.
+type MockunitOfWork struct {
+	ctrl     *gomock.Controller
+	recorder *MockunitOfWorkMockRecorder
+}
+
+// MockunitOfWorkMockRecorder is the mock recorder for MockunitOfWork.
+type MockunitOfWorkMockRecorder struct {
+	mock *MockunitOfWork
+}
+
+// NewMockunitOfWork creates a new mock instance.
+func NewMockunitOfWork(ctrl *gomock.Controller) *MockunitOfWork {
+	mock := &MockunitOfWork{ctrl: ctrl}
+	mock.recorder = &MockunitOfWorkMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockunitOfWork) EXPECT() *MockunitOfWorkMockRecorder {
+	return m.recorder
+}
+
+// Do mocks base method.
+func (m *MockunitOfWork) Do(ctx context.Context, fn func(context.Context) error) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Do", ctx, fn)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Do indicates an expected call of Do.
+func (mr *MockunitOfWorkMockRecorder) Do(ctx, fn interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Do", reflect.TypeOf((*MockunitOfWork)(nil).Do), ctx, fn)
+}
@@ -0,0 +1,83 @@
+package block
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/colmmurphy91/muzz/internal/adapter/mysql/user/model"
+	"github.com/colmmurphy91/muzz/internal/entity"
+)
+
+//go:generate mockgen -source $GOFILE -destination mocks/mocks_${GOFILE} -package mocks
+
+type blockStore interface {
+	Block(ctx context.Context, blockerID, blockedID int) error
+}
+
+type userFetcher interface {
+	FindByID(ctx context.Context, userID int) (model.User, error)
+}
+
+type matchStore interface {
+	FindMatchByPair(ctx context.Context, user1ID, user2ID int) (entity.Match, error)
+	Unmatch(ctx context.Context, id, userID int) error
+}
+
+type unitOfWork interface {
+	Do(ctx context.Context, fn func(ctx context.Context) error) error
+}
+
+type Service struct {
+	blockStore  blockStore
+	userFetcher userFetcher
+	matchStore  matchStore
+	unitOfWork  unitOfWork
+}
+
+func NewService(blocks blockStore, users userFetcher, matches matchStore, unitOfWork unitOfWork) *Service {
+	return &Service{
+		blockStore:  blocks,
+		userFetcher: users,
+		matchStore:  matches,
+		unitOfWork:  unitOfWork,
+	}
+}
+
+// Block keeps the user and target apart both ways: neither is discovered by,
+// can swipe on, or is shown as a like to the other. A match between them is
+// ended, which also closes their conversation. Blocking again does nothing.
+func (s *Service) Block(ctx context.Context, userID, targetID int) error {
+	if userID == targetID {
+		return fmt.Errorf("cannot block yourself: %w", entity.ErrInvalidParam)
+	}
+
+	if _, err := s.userFetcher.FindByID(ctx, targetID); err != nil {
+		return fmt.Errorf("failed to find user: %w", err)
+	}
+
+	return s.unitOfWork.Do(ctx, func(ctx context.Context) error {
+		if err := s.blockStore.Block(ctx, userID, targetID); err != nil {
+			return fmt.Errorf("failed to block: %w", err)
+		}
+
+		pair := entity.NewMatch(userID, targetID)
+
+		match, err := s.matchStore.FindMatchByPair(ctx, pair.User1ID, pair.User2ID)
+
+		switch {
+		case errors.Is(err, entity.ErrMatchNotFound):
+			return nil
+		case err != nil:
+			return fmt.Errorf("failed to find match: %w", err)
+		case match.UnmatchedAt.Valid:
+			return nil
+		}
+
+		if err := s.matchStore.Unmatch(ctx, match.ID, userID); err != nil {
+			return fmt.Errorf("failed to unmatch: %w", err)
+		}
+
+		return nil
+	})
+}
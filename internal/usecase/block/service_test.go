@@ -0,0 +1,111 @@
+package block
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	null "github.com/guregu/null/v5"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/colmmurphy91/muzz/internal/adapter/mysql/user/model"
+	"github.com/colmmurphy91/muzz/internal/entity"
+	"github.com/colmmurphy91/muzz/internal/usecase/block/mocks"
+)
+
+func TestService_Block(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockBlocks := mocks.NewMockblockStore(ctrl)
+	mockUsers := mocks.NewMockuserFetcher(ctrl)
+	mockMatches := mocks.NewMockmatchStore(ctrl)
+	mockUnitOfWork := mocks.NewMockunitOfWork(ctrl)
+	service := NewService(mockBlocks, mockUsers, mockMatches, mockUnitOfWork)
+
+	ctx := context.Background()
+
+	mockUnitOfWork.EXPECT().Do(ctx, gomock.Any()).DoAndReturn(
+		func(ctx context.Context, fn func(ctx context.Context) error) error {
+			return fn(ctx)
+		}).AnyTimes()
+
+	tests := []struct {
+		name          string
+		targetID      int
+		setupMocks    func()
+		expectedError error
+	}{
+		{
+			name:     "ends the match between the pair",
+			targetID: 2,
+			setupMocks: func() {
+				mockUsers.EXPECT().FindByID(ctx, 2).Return(model.User{ID: 2}, nil)
+				mockBlocks.EXPECT().Block(ctx, 3, 2).Return(nil)
+				mockMatches.EXPECT().FindMatchByPair(ctx, 2, 3).Return(entity.Match{ID: 5, User1ID: 2, User2ID: 3}, nil)
+				mockMatches.EXPECT().Unmatch(ctx, 5, 3).Return(nil)
+			},
+		},
+		{
+			name:     "not matched",
+			targetID: 2,
+			setupMocks: func() {
+				mockUsers.EXPECT().FindByID(ctx, 2).Return(model.User{ID: 2}, nil)
+				mockBlocks.EXPECT().Block(ctx, 3, 2).Return(nil)
+				mockMatches.EXPECT().FindMatchByPair(ctx, 2, 3).Return(entity.Match{}, entity.ErrMatchNotFound)
+			},
+		},
+		{
+			name:     "match already ended",
+			targetID: 2,
+			setupMocks: func() {
+				mockUsers.EXPECT().FindByID(ctx, 2).Return(model.User{ID: 2}, nil)
+				mockBlocks.EXPECT().Block(ctx, 3, 2).Return(nil)
+				mockMatches.EXPECT().FindMatchByPair(ctx, 2, 3).Return(entity.Match{
+					ID:          5,
+					UnmatchedAt: null.TimeFrom(time.Now()),
+				}, nil)
+			},
+		},
+		{
+			name:          "cannot block yourself",
+			targetID:      3,
+			setupMocks:    func() {},
+			expectedError: errors.New("cannot block yourself: invalid param"),
+		},
+		{
+			name:     "user not found",
+			targetID: 2,
+			setupMocks: func() {
+				mockUsers.EXPECT().FindByID(ctx, 2).Return(model.User{}, entity.ErrUserNotFound)
+			},
+			expectedError: errors.New("failed to find user: user does not exists"),
+		},
+		{
+			name:     "store failure",
+			targetID: 2,
+			setupMocks: func() {
+				mockUsers.EXPECT().FindByID(ctx, 2).Return(model.User{ID: 2}, nil)
+				mockBlocks.EXPECT().Block(ctx, 3, 2).Return(errors.New("db error"))
+			},
+			expectedError: errors.New("failed to block: db error"),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.setupMocks()
+
+			err := service.Block(ctx, 3, tt.targetID)
+
+			if tt.expectedError != nil {
+				assert.EqualError(t, err, tt.expectedError.Error())
+				return
+			}
+
+			assert.NoError(t, err)
+		})
+	}
+}
@@ -0,0 +1,51 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: ranker.go
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	context "context"
+	reflect "reflect"
+
+	entity "github.com/colmmurphy91/muzz/internal/entity"
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockswipeCounter is a mock of swipeCounter interface.
+type MockswipeCounter struct {
+	ctrl     *gomock.Controller
+	recorder *MockswipeCounterMockRecorder
+}
+
+// MockswipeCounterMockRecorder is the mock recorder for MockswipeCounter.
+type MockswipeCounterMockRecorder struct {
+	mock *MockswipeCounter
+}
+
+// NewMockswipeCounter creates a new mock instance.
+func NewMockswipeCounter(ctrl *gomock.Controller) *MockswipeCounter {
+	mock := &MockswipeCounter{ctrl: ctrl}
+	mock.recorder = &MockswipeCounterMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockswipeCounter) EXPECT() *MockswipeCounterMockRecorder {
+	return m.recorder
+}
+
+// GetReceivedSwipeCounts mocks base method.
+func (m *MockswipeCounter) GetReceivedSwipeCounts(ctx context.Context, targetIDs []int) (map[int]entity.SwipeCounts, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetReceivedSwipeCounts", ctx, targetIDs)
+	ret0, _ := ret[0].(map[int]entity.SwipeCounts)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetReceivedSwipeCounts indicates an expected call of GetReceivedSwipeCounts.
+func (mr *MockswipeCounterMockRecorder) GetReceivedSwipeCounts(ctx, targetIDs interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetReceivedSwipeCounts", reflect.TypeOf((*MockswipeCounter)(nil).GetReceivedSwipeCounts), ctx, targetIDs)
+}
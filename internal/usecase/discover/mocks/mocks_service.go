@@ -0,0 +1,285 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: service.go
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	context "context"
+	reflect "reflect"
+	time "time"
+
+	model "github.com/colmmurphy91/muzz/internal/adapter/mysql/user/model"
+	entity "github.com/colmmurphy91/muzz/internal/entity"
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockuserDiscover is a mock of userDiscover interface.
+type MockuserDiscover struct {
+	ctrl     *gomock.Controller
+	recorder *MockuserDiscoverMockRecorder
+}
+
+// MockuserDiscoverMockRecorder is the mock recorder for MockuserDiscover.
+type MockuserDiscoverMockRecorder struct {
+	mock *MockuserDiscover
+}
+
+// NewMockuserDiscover creates a new mock instance.
+func NewMockuserDiscover(ctrl *gomock.Controller) *MockuserDiscover {
+	mock := &MockuserDiscover{ctrl: ctrl}
+	mock.recorder = &MockuserDiscoverMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockuserDiscover) EXPECT() *MockuserDiscoverMockRecorder {
+	return m.recorder
+}
+
+// ClosePointInTime mocks base method.
+func (m *MockuserDiscover) ClosePointInTime(ctx context.Context, pit string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ClosePointInTime", ctx, pit)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ClosePointInTime indicates an expected call of ClosePointInTime.
+func (mr *MockuserDiscoverMockRecorder) ClosePointInTime(ctx, pit interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ClosePointInTime", reflect.TypeOf((*MockuserDiscover)(nil).ClosePointInTime), ctx, pit)
+}
+
+// OpenPointInTime mocks base method.
+func (m *MockuserDiscover) OpenPointInTime(ctx context.Context) (string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "OpenPointInTime", ctx)
+	ret0, _ := ret[0].(string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// OpenPointInTime indicates an expected call of OpenPointInTime.
+func (mr *MockuserDiscoverMockRecorder) OpenPointInTime(ctx interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "OpenPointInTime", reflect.TypeOf((*MockuserDiscover)(nil).OpenPointInTime), ctx)
+}
+
+// SearchOthers mocks base method.
+func (m *MockuserDiscover) SearchOthers(ctx context.Context, params entity.SearchParams) (entity.SearchResult, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SearchOthers", ctx, params)
+	ret0, _ := ret[0].(entity.SearchResult)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SearchOthers indicates an expected call of SearchOthers.
+func (mr *MockuserDiscoverMockRecorder) SearchOthers(ctx, params interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SearchOthers", reflect.TypeOf((*MockuserDiscover)(nil).SearchOthers), ctx, params)
+}
+
+// MockuserFetcher is a mock of userFetcher interface.
+type MockuserFetcher struct {
+	ctrl     *gomock.Controller
+	recorder *MockuserFetcherMockRecorder
+}
+
+// MockuserFetcherMockRecorder is the mock recorder for MockuserFetcher.
+type MockuserFetcherMockRecorder struct {
+	mock *MockuserFetcher
+}
+
+// NewMockuserFetcher creates a new mock instance.
+func NewMockuserFetcher(ctrl *gomock.Controller) *MockuserFetcher {
+	mock := &MockuserFetcher{ctrl: ctrl}
+	mock.recorder = &MockuserFetcherMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockuserFetcher) EXPECT() *MockuserFetcherMockRecorder {
+	return m.recorder
+}
+
+// FindByID mocks base method.
+func (m *MockuserFetcher) FindByID(ctx context.Context, userID int) (model.User, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindByID", ctx, userID)
+	ret0, _ := ret[0].(model.User)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindByID indicates an expected call of FindByID.
+func (mr *MockuserFetcherMockRecorder) FindByID(ctx, userID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindByID", reflect.TypeOf((*MockuserFetcher)(nil).FindByID), ctx, userID)
+}
+
+// MockpreferenceFetcher is a mock of preferenceFetcher interface.
+type MockpreferenceFetcher struct {
+	ctrl     *gomock.Controller
+	recorder *MockpreferenceFetcherMockRecorder
+}
+
+// MockpreferenceFetcherMockRecorder is the mock recorder for MockpreferenceFetcher.
+type MockpreferenceFetcherMockRecorder struct {
+	mock *MockpreferenceFetcher
+}
+
+// NewMockpreferenceFetcher creates a new mock instance.
+func NewMockpreferenceFetcher(ctrl *gomock.Controller) *MockpreferenceFetcher {
+	mock := &MockpreferenceFetcher{ctrl: ctrl}
+	mock.recorder = &MockpreferenceFetcherMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockpreferenceFetcher) EXPECT() *MockpreferenceFetcherMockRecorder {
+	return m.recorder
+}
+
+// GetPreferences mocks base method.
+func (m *MockpreferenceFetcher) GetPreferences(ctx context.Context, userID int) (entity.Preferences, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetPreferences", ctx, userID)
+	ret0, _ := ret[0].(entity.Preferences)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetPreferences indicates an expected call of GetPreferences.
+func (mr *MockpreferenceFetcherMockRecorder) GetPreferences(ctx, userID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPreferences", reflect.TypeOf((*MockpreferenceFetcher)(nil).GetPreferences), ctx, userID)
+}
+
+// MockblockLister is a mock of blockLister interface.
+type MockblockLister struct {
+	ctrl     *gomock.Controller
+	recorder *MockblockListerMockRecorder
+}
+
+// MockblockListerMockRecorder is the mock recorder for MockblockLister.
+type MockblockListerMockRecorder struct {
+	mock *MockblockLister
+}
+
+// NewMockblockLister creates a new mock instance.
+func NewMockblockLister(ctrl *gomock.Controller) *MockblockLister {
+	mock := &MockblockLister{ctrl: ctrl}
+	mock.recorder = &MockblockListerMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockblockLister) EXPECT() *MockblockListerMockRecorder {
+	return m.recorder
+}
+
+// BlockedIDs mocks base method.
+func (m *MockblockLister) BlockedIDs(ctx context.Context, userID int) ([]int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "BlockedIDs", ctx, userID)
+	ret0, _ := ret[0].([]int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// BlockedIDs indicates an expected call of BlockedIDs.
+func (mr *MockblockListerMockRecorder) BlockedIDs(ctx, userID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "BlockedIDs", reflect.TypeOf((*MockblockLister)(nil).BlockedIDs), ctx, userID)
+}
+
+// MockactivityRecorder is a mock of activityRecorder interface.
+type MockactivityRecorder struct {
+	ctrl     *gomock.Controller
+	recorder *MockactivityRecorderMockRecorder
+}
+
+// MockactivityRecorderMockRecorder is the mock recorder for MockactivityRecorder.
+type MockactivityRecorderMockRecorder struct {
+	mock *MockactivityRecorder
+}
+
+// NewMockactivityRecorder creates a new mock instance.
+func NewMockactivityRecorder(ctrl *gomock.Controller) *MockactivityRecorder {
+	mock := &MockactivityRecorder{ctrl: ctrl}
+	mock.recorder = &MockactivityRecorderMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockactivityRecorder) EXPECT() *MockactivityRecorderMockRecorder {
+	return m.recorder
+}
+
+// TouchActivity mocks base method.
+func (m *MockactivityRecorder) TouchActivity(ctx context.Context, userID int, at time.Time) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "TouchActivity", ctx, userID, at)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// TouchActivity indicates an expected call of TouchActivity.
+func (mr *MockactivityRecorderMockRecorder) TouchActivity(ctx, userID, at interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "TouchActivity", reflect.TypeOf((*MockactivityRecorder)(nil).TouchActivity), ctx, userID, at)
+}
+
+// Mockranker is a mock of ranker interface.
+type Mockranker struct {
+	ctrl     *gomock.Controller
+	recorder *MockrankerMockRecorder
+}
+
+// MockrankerMockRecorder is the mock recorder for Mockranker.
+type MockrankerMockRecorder struct {
+	mock *Mockranker
+}
+
+// NewMockranker creates a new mock instance.
+func NewMockranker(ctrl *gomock.Controller) *Mockranker {
+	mock := &Mockranker{ctrl: ctrl}
+	mock.recorder = &MockrankerMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *Mockranker) EXPECT() *MockrankerMockRecorder {
+	return m.recorder
+}
+
+// Rank mocks base method.
+func (m *Mockranker) Rank(ctx context.Context, strategy entity.RankingStrategy, users []entity.User) ([]entity.User, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Rank", ctx, strategy, users)
+	ret0, _ := ret[0].([]entity.User)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Rank indicates an expected call of Rank.
+func (mr *MockrankerMockRecorder) Rank(ctx, strategy, users interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Rank", reflect.TypeOf((*Mockranker)(nil).Rank), ctx, strategy, users)
+}
+
+// Strategy mocks base method.
+func (m *Mockranker) Strategy(userID int) entity.RankingStrategy {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Strategy", userID)
+	ret0, _ := ret[0].(entity.RankingStrategy)
+	return ret0
+}
+
+// Strategy indicates an expected call of Strategy.
+func (mr *MockrankerMockRecorder) Strategy(userID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Strategy", reflect.TypeOf((*Mockranker)(nil).Strategy), userID)
+}
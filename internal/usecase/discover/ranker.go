@@ -0,0 +1,72 @@
+package discover
+
+import (
+	"context"
+	"fmt"
+	"sort"
+
+	"github.com/colmmurphy91/muzz/internal/entity"
+)
+
+//go:generate mockgen -source $GOFILE -destination mocks/mocks_${GOFILE} -package mocks
+
+type swipeCounter interface {
+	GetReceivedSwipeCounts(ctx context.Context, targetIDs []int) (map[int]entity.SwipeCounts, error)
+}
+
+// Ranker assigns each user a ranking strategy and re-ranks their results on the
+// signals Elasticsearch cannot score.
+type Ranker struct {
+	swipeCounter swipeCounter
+	strategies   []entity.RankingStrategy
+}
+
+// NewRanker returns a ranker splitting users between strategies, or using the
+// default strategy when none are given.
+func NewRanker(counter swipeCounter, strategies ...entity.RankingStrategy) *Ranker {
+	if len(strategies) == 0 {
+		strategies = []entity.RankingStrategy{entity.DefaultRankingStrategy}
+	}
+
+	return &Ranker{
+		swipeCounter: counter,
+		strategies:   strategies,
+	}
+}
+
+// Strategy returns the strategy a user's results are ranked with. A user always
+// gets the same strategy, so their experience is consistent while it is tested.
+func (r *Ranker) Strategy(userID int) entity.RankingStrategy {
+	return r.strategies[userID%len(r.strategies)]
+}
+
+// Rank adds each user's desirability to their score and orders them by it.
+// Only the given users are reordered, so pages are ranked one at a time.
+func (r *Ranker) Rank(ctx context.Context, strategy entity.RankingStrategy, users []entity.User) ([]entity.User, error) {
+	weight := strategy.Weights.Desirability
+	if weight == 0 || len(users) == 0 {
+		return users, nil
+	}
+
+	ids := make([]int, len(users))
+	for i, user := range users {
+		ids[i] = user.ID
+	}
+
+	counts, err := r.swipeCounter.GetReceivedSwipeCounts(ctx, ids)
+	if err != nil {
+		return nil, fmt.Errorf("failed to count swipes: %w", err)
+	}
+
+	ranked := make([]entity.User, len(users))
+	for i, user := range users {
+		user.Score += weight * counts[user.ID].Desirability()
+		ranked[i] = user
+	}
+
+	sort.SliceStable(ranked, func(i, j int) bool {
+		return ranked[i].Score > ranked[j].Score
+	})
+
+	return ranked, nil
+}
@@ -0,0 +1,99 @@
+package discover
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/colmmurphy91/muzz/internal/entity"
+	"github.com/colmmurphy91/muzz/internal/usecase/discover/mocks"
+)
+
+func TestRanker_Strategy(t *testing.T) {
+	control := entity.RankingStrategy{Name: "control"}
+	variant := entity.RankingStrategy{Name: "variant"}
+
+	assert.Equal(t, entity.DefaultRankingStrategy, NewRanker(nil).Strategy(7))
+
+	ranker := NewRanker(nil, control, variant)
+	assert.Equal(t, control, ranker.Strategy(2))
+	assert.Equal(t, variant, ranker.Strategy(3))
+	assert.Equal(t, ranker.Strategy(3), ranker.Strategy(3))
+}
+
+func TestRanker_Rank(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockCounter := mocks.NewMockswipeCounter(ctrl)
+	ranker := NewRanker(mockCounter)
+
+	ctx := context.Background()
+	users := []entity.User{
+		{ID: 1, Score: 1.0},
+		{ID: 2, Score: 0.9},
+		{ID: 3, Score: 0.8},
+	}
+	desirable := entity.RankingStrategy{Name: "desirable", Weights: entity.RankingWeights{Desirability: 1}}
+
+	tests := []struct {
+		name          string
+		strategy      entity.RankingStrategy
+		setupMocks    func()
+		expectedIDs   []int
+		expectedError error
+	}{
+		{
+			name:        "keeps order without a desirability weight",
+			strategy:    entity.RankingStrategy{Name: "distance", Weights: entity.RankingWeights{Distance: 1}},
+			setupMocks:  func() {},
+			expectedIDs: []int{1, 2, 3},
+		},
+		{
+			name:     "favours people swiped yes on",
+			strategy: desirable,
+			setupMocks: func() {
+				mockCounter.EXPECT().GetReceivedSwipeCounts(ctx, []int{1, 2, 3}).Return(map[int]entity.SwipeCounts{
+					1: {TargetID: 1, Yes: 0, Total: 10},
+					3: {TargetID: 3, Yes: 10, Total: 10},
+				}, nil)
+			},
+			// 1: 1.0 + 1/12, 2: 0.9 + 1/2, 3: 0.8 + 11/12
+			expectedIDs: []int{3, 2, 1},
+		},
+		{
+			name:     "count failure",
+			strategy: desirable,
+			setupMocks: func() {
+				mockCounter.EXPECT().GetReceivedSwipeCounts(ctx, []int{1, 2, 3}).Return(nil, errors.New("db error"))
+			},
+			expectedError: errors.New("failed to count swipes: db error"),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.setupMocks()
+
+			ranked, err := ranker.Rank(ctx, tt.strategy, users)
+
+			if tt.expectedError != nil {
+				assert.EqualError(t, err, tt.expectedError.Error())
+				return
+			}
+
+			assert.NoError(t, err)
+
+			ids := make([]int, 0, len(ranked))
+			for _, user := range ranked {
+				ids = append(ids, user.ID)
+			}
+
+			assert.Equal(t, tt.expectedIDs, ids)
+			assert.Equal(t, 1.0, users[0].Score)
+		})
+	}
+}
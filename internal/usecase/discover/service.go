@@ -2,61 +2,192 @@ package discover
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"time"
 
-	"github.com/asmarques/geodist"
+	null "github.com/guregu/null/v5"
+	"go.uber.org/zap"
 
+	"github.com/colmmurphy91/muzz/internal/adapter/mysql/user/model"
 	"github.com/colmmurphy91/muzz/internal/entity"
 )
 
+//go:generate mockgen -source $GOFILE -destination mocks/mocks_${GOFILE} -package mocks
+
+// userDiscover searches for people. A discover session searches one point in
+// time throughout, so people's scores cannot change between its pages.
 type userDiscover interface {
-	SearchOthers(ctx context.Context, params entity.SearchParams) ([]entity.User, error)
+	OpenPointInTime(ctx context.Context) (string, error)
+	SearchOthers(ctx context.Context, params entity.SearchParams) (entity.SearchResult, error)
+	ClosePointInTime(ctx context.Context, pit string) error
+}
+
+type userFetcher interface {
+	FindByID(ctx context.Context, userID int) (model.User, error)
+}
+
+type preferenceFetcher interface {
+	GetPreferences(ctx context.Context, userID int) (entity.Preferences, error)
+}
+
+// blockLister lists everyone a user has blocked or been blocked by.
+type blockLister interface {
+	BlockedIDs(ctx context.Context, userID int) ([]int, error)
+}
+
+type activityRecorder interface {
+	TouchActivity(ctx context.Context, userID int, at time.Time) error
 }
 
-type swiper interface {
-	GetUserSwipes(ctx context.Context, currentUserID int) ([]entity.Swipe, error)
+type ranker interface {
+	Strategy(userID int) entity.RankingStrategy
+	Rank(ctx context.Context, strategy entity.RankingStrategy, users []entity.User) ([]entity.User, error)
 }
 
 type Service struct {
-	userDiscover userDiscover
-	swiper       swiper
+	logger            *zap.SugaredLogger
+	userDiscover      userDiscover
+	userFetcher       userFetcher
+	preferenceFetcher preferenceFetcher
+	blockLister       blockLister
+	activityRecorder  activityRecorder
+	ranker            ranker
+	now               func() time.Time
 }
 
-func NewService(discover userDiscover, swipe swiper) *Service {
+func NewService(
+	logger *zap.SugaredLogger,
+	discover userDiscover,
+	fetcher userFetcher,
+	preferences preferenceFetcher,
+	blocks blockLister,
+	activity activityRecorder,
+	ranker ranker,
+) *Service {
 	return &Service{
-		userDiscover: discover,
-		swiper:       swipe,
+		logger:            logger,
+		userDiscover:      discover,
+		userFetcher:       fetcher,
+		preferenceFetcher: preferences,
+		blockLister:       blocks,
+		activityRecorder:  activity,
+		ranker:            ranker,
+		now:               time.Now,
 	}
 }
 
-func (s *Service) DiscoverPeople(ctx context.Context, userID int, params entity.SearchParams) ([]entity.User, error) {
-	swipes, err := s.swiper.GetUserSwipes(ctx, userID)
+// DiscoverPeople returns a page of people the user has not swiped on yet,
+// ranked by the user's ranking strategy. Filters missing from params fall back
+// to the user's saved preferences, and only people whose own preferences
+// include the user are returned. People either way blocked are never returned.
+func (s *Service) DiscoverPeople(ctx context.Context, userID int, params entity.SearchParams) (entity.DiscoverPage, error) {
+	me, err := s.userFetcher.FindByID(ctx, userID)
+	if err != nil {
+		return entity.DiscoverPage{}, fmt.Errorf("failed to get user: %w", err)
+	}
+
+	prefs, err := s.preferenceFetcher.GetPreferences(ctx, userID)
+	if err != nil {
+		if !errors.Is(err, entity.ErrPreferencesNotFound) {
+			return entity.DiscoverPage{}, fmt.Errorf("failed to get preferences: %w", err)
+		}
+
+		prefs = entity.DefaultPreferences(userID)
+	}
+
+	params = params.WithPreferences(prefs)
+
+	// Searching from the grid locations are stored on, with radii on the edges of
+	// the distance buckets, means nudging either tells nothing finer than the
+	// grid about where anyone is.
+	origin := entity.Location{Lat: params.Lat, Lon: params.Lon}.Fuzzed()
+	params.Lat, params.Lon = origin.Lat, origin.Lon
+
+	if params.MaxDistanceKm.Valid {
+		params.MaxDistanceKm = null.FloatFrom(entity.DistanceBucket(params.MaxDistanceKm.Float64))
+	}
+
+	params.SearcherAge = null.IntFrom(int64(me.Age))
+	params.SearcherGender = null.NewString(me.Gender, me.Gender != "")
+
+	blocked, err := s.blockLister.BlockedIDs(ctx, userID)
 	if err != nil {
-		return nil, fmt.Errorf("failed to get user swipes: %w", err)
+		return entity.DiscoverPage{}, fmt.Errorf("failed to get blocks: %w", err)
 	}
 
-	excludedIDs := []int{userID}
+	params.ExcludeUserIDs = append([]int{userID}, blocked...)
+	params.ExcludeSwipedBy = userID
+	params.BoostSuperLikersOf = userID
 
-	for _, swipe := range swipes {
-		excludedIDs = append(excludedIDs, swipe.TargetID)
+	limit := params.Limit
+	if limit <= 0 {
+		limit = entity.DefaultDiscoverLimit
 	}
 
-	params.ExcludeUserIDs = excludedIDs
+	// A session is ranked as of when it started, from where it started.
+	if params.After != nil {
+		params.Lat, params.Lon = params.After.Lat, params.After.Lon
+		params.At, params.PIT = params.After.At, params.After.PIT
+	} else {
+		params.At = s.now().UTC().Truncate(time.Second)
 
-	users, err := s.userDiscover.SearchOthers(ctx, params)
+		params.PIT, err = s.userDiscover.OpenPointInTime(ctx)
+		if err != nil {
+			return entity.DiscoverPage{}, fmt.Errorf("failed to open point in time: %w", err)
+		}
+	}
+
+	// Ask for one extra to know whether there is another page.
+	params.Limit = limit + 1
+
+	strategy := s.ranker.Strategy(userID)
+	params.Ranking = strategy.Weights
+
+	// Results come back highest scoring first, within MaxDistanceKm and with
+	// Score and DistanceFromMe set.
+	result, err := s.userDiscover.SearchOthers(ctx, params)
 	if err != nil {
-		return nil, fmt.Errorf("failed to search for others %w", err)
+		return entity.DiscoverPage{}, fmt.Errorf("failed to search for others %w", err)
 	}
 
-	userPoint := geodist.Point{Lat: params.Lat, Long: params.Lon}
+	users := result.Users
+
+	// Best effort: discovering still works if activity is not recorded.
+	if err := s.activityRecorder.TouchActivity(ctx, userID, time.Now()); err != nil {
+		s.logger.Errorw("failed to record activity", "user_id", userID, "error", err)
+	}
+
+	page := entity.DiscoverPage{Strategy: strategy.Name}
+
+	if len(users) > limit {
+		users = users[:limit]
+		// The cursor is taken before re-ranking, as it marks the position in
+		// Elasticsearch's order.
+		last := users[limit-1]
+
+		page.Next = &entity.DiscoverCursor{
+			Lat:  params.Lat,
+			Lon:  params.Lon,
+			At:   params.At,
+			PIT:  result.PIT,
+			Sort: last.Sort,
+		}
+	} else if err := s.userDiscover.ClosePointInTime(ctx, result.PIT); err != nil {
+		// Best effort: it expires on its own otherwise.
+		s.logger.Errorw("failed to close point in time", "user_id", userID, "error", err)
+	}
+
+	page.Users, err = s.ranker.Rank(ctx, strategy, users)
+	if err != nil {
+		return entity.DiscoverPage{}, fmt.Errorf("failed to rank: %w", err)
+	}
 
-	for i, user := range users {
-		km := geodist.HaversineDistance(userPoint, geodist.Point{
-			Lat:  user.Location.Lat,
-			Long: user.Location.Lon,
-		})
-		users[i].DistanceFromMe = km
+	// Only the cursor keeps exact distances, and those are to where people's
+	// locations were snapped to.
+	for i := range page.Users {
+		page.Users[i].DistanceFromMe = entity.DistanceBucket(page.Users[i].DistanceFromMe)
 	}
 
-	return users, nil
+	return page, nil
 }
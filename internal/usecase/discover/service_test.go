@@ -0,0 +1,343 @@
+package discover
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	null "github.com/guregu/null/v5"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/zap"
+
+	"github.com/colmmurphy91/muzz/internal/adapter/mysql/user/model"
+	"github.com/colmmurphy91/muzz/internal/entity"
+	"github.com/colmmurphy91/muzz/internal/usecase/discover/mocks"
+)
+
+func TestService_DiscoverPeople(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockDiscover := mocks.NewMockuserDiscover(ctrl)
+	mockFetcher := mocks.NewMockuserFetcher(ctrl)
+	mockPreferences := mocks.NewMockpreferenceFetcher(ctrl)
+	mockBlocks := mocks.NewMockblockLister(ctrl)
+	mockActivity := mocks.NewMockactivityRecorder(ctrl)
+	mockRanker := mocks.NewMockranker(ctrl)
+	service := NewService(zap.NewNop().Sugar(), mockDiscover, mockFetcher, mockPreferences, mockBlocks, mockActivity, mockRanker)
+	at := time.Date(2024, 7, 8, 12, 0, 0, 0, time.UTC)
+	service.now = func() time.Time { return at.Add(300 * time.Millisecond) }
+
+	ctx := context.Background()
+	me := model.User{ID: 1, Age: 30, Gender: "male"}
+	saved := entity.Preferences{
+		UserID:        1,
+		MinAge:        null.IntFrom(25),
+		MaxAge:        null.IntFrom(35),
+		Genders:       []string{"female"},
+		MaxDistanceKm: null.FloatFrom(100),
+		ShowMe:        true,
+	}
+	near := entity.User{
+		ID:             3,
+		Location:       entity.Location{Lat: 51.51, Lon: -0.13},
+		DistanceFromMe: 1.3,
+		Sort:           []float64{0, 1.3, 3, 11},
+	}
+	far := entity.User{
+		ID:             4,
+		Location:       entity.Location{Lat: 53.48, Lon: -2.24},
+		DistanceFromMe: 262.8,
+		Sort:           []float64{0, 262.8, 4, 12},
+	}
+	found := func(users ...entity.User) entity.SearchResult {
+		return entity.SearchResult{Users: users, PIT: "pit-2"}
+	}
+	strategy := entity.RankingStrategy{Name: "test", Weights: entity.RankingWeights{Distance: 1, Desirability: 0.5}}
+	unchanged := func(_ context.Context, _ entity.RankingStrategy, users []entity.User) ([]entity.User, error) {
+		return users, nil
+	}
+
+	tests := []struct {
+		name              string
+		params            entity.SearchParams
+		setupMocks        func()
+		expectedIDs       []int
+		expectedDistances []float64
+		expectedNext      *entity.DiscoverCursor
+		expectedError     error
+	}{
+		{
+			name:   "falls back to saved preferences and leaves out blocks",
+			params: entity.SearchParams{Lat: 51.5, Lon: -0.12},
+			setupMocks: func() {
+				mockFetcher.EXPECT().FindByID(ctx, 1).Return(me, nil)
+				mockPreferences.EXPECT().GetPreferences(ctx, 1).Return(saved, nil)
+				mockBlocks.EXPECT().BlockedIDs(ctx, 1).Return([]int{7, 9}, nil)
+				mockRanker.EXPECT().Strategy(1).Return(strategy)
+				mockDiscover.EXPECT().OpenPointInTime(ctx).Return("pit-1", nil)
+				mockDiscover.EXPECT().SearchOthers(ctx, entity.SearchParams{
+					ExcludeUserIDs:     []int{1, 7, 9},
+					ExcludeSwipedBy:    1,
+					BoostSuperLikersOf: 1,
+					MinAge:             null.IntFrom(25),
+					MaxAge:             null.IntFrom(35),
+					Genders:            []string{"female"},
+					MaxDistanceKm:      null.FloatFrom(100),
+					Lat:                51.5,
+					Lon:                -0.12,
+					SearcherAge:        null.IntFrom(30),
+					SearcherGender:     null.StringFrom("male"),
+					Ranking:            strategy.Weights,
+					Limit:              entity.DefaultDiscoverLimit + 1,
+					PIT:                "pit-1",
+					At:                 at,
+				}).Return(found(near), nil)
+				mockActivity.EXPECT().TouchActivity(ctx, 1, gomock.Any()).Return(nil)
+				mockDiscover.EXPECT().ClosePointInTime(ctx, "pit-2").Return(nil)
+				mockRanker.EXPECT().Rank(ctx, strategy, gomock.Any()).DoAndReturn(unchanged)
+			},
+			expectedIDs: []int{3},
+		},
+		{
+			name: "query params take precedence",
+			params: entity.SearchParams{
+				Lat:    51.5,
+				Lon:    -0.12,
+				MinAge: null.IntFrom(40),
+				Gender: null.StringFrom("male"),
+			},
+			setupMocks: func() {
+				mockFetcher.EXPECT().FindByID(ctx, 1).Return(me, nil)
+				mockPreferences.EXPECT().GetPreferences(ctx, 1).Return(saved, nil)
+				mockBlocks.EXPECT().BlockedIDs(ctx, 1).Return([]int{}, nil)
+				mockRanker.EXPECT().Strategy(1).Return(strategy)
+				mockDiscover.EXPECT().OpenPointInTime(ctx).Return("pit-1", nil)
+				mockDiscover.EXPECT().SearchOthers(ctx, gomock.Any()).DoAndReturn(
+					func(_ context.Context, params entity.SearchParams) (entity.SearchResult, error) {
+						assert.Equal(t, null.IntFrom(40), params.MinAge)
+						assert.Equal(t, null.IntFrom(35), params.MaxAge)
+						assert.Equal(t, null.StringFrom("male"), params.Gender)
+						assert.Empty(t, params.Genders)
+
+						return found(near), nil
+					})
+				mockActivity.EXPECT().TouchActivity(ctx, 1, gomock.Any()).Return(nil)
+				mockDiscover.EXPECT().ClosePointInTime(ctx, "pit-2").Return(nil)
+				mockRanker.EXPECT().Rank(ctx, strategy, gomock.Any()).DoAndReturn(unchanged)
+			},
+			expectedIDs: []int{3},
+		},
+		{
+			name: "searches from the grid with radii on the bucket edges",
+			params: entity.SearchParams{
+				Lat:           51.50724,
+				Lon:           -0.12758,
+				MaxDistanceKm: null.FloatFrom(7.3),
+			},
+			setupMocks: func() {
+				mockFetcher.EXPECT().FindByID(ctx, 1).Return(me, nil)
+				mockPreferences.EXPECT().GetPreferences(ctx, 1).Return(saved, nil)
+				mockBlocks.EXPECT().BlockedIDs(ctx, 1).Return([]int{}, nil)
+				mockRanker.EXPECT().Strategy(1).Return(strategy)
+				mockDiscover.EXPECT().OpenPointInTime(ctx).Return("pit-1", nil)
+				mockDiscover.EXPECT().SearchOthers(ctx, gomock.Any()).DoAndReturn(
+					func(_ context.Context, params entity.SearchParams) (entity.SearchResult, error) {
+						assert.Equal(t, 51.51, params.Lat)
+						assert.Equal(t, -0.13, params.Lon)
+						assert.Equal(t, null.FloatFrom(8), params.MaxDistanceKm)
+
+						return found(near), nil
+					})
+				mockActivity.EXPECT().TouchActivity(ctx, 1, gomock.Any()).Return(nil)
+				mockDiscover.EXPECT().ClosePointInTime(ctx, "pit-2").Return(nil)
+				mockRanker.EXPECT().Rank(ctx, strategy, gomock.Any()).DoAndReturn(unchanged)
+			},
+			expectedIDs: []int{3},
+		},
+		{
+			name:   "defaults when nothing saved",
+			params: entity.SearchParams{Lat: 51.5, Lon: -0.12},
+			setupMocks: func() {
+				mockFetcher.EXPECT().FindByID(ctx, 1).Return(me, nil)
+				mockPreferences.EXPECT().GetPreferences(ctx, 1).Return(entity.Preferences{}, entity.ErrPreferencesNotFound)
+				mockBlocks.EXPECT().BlockedIDs(ctx, 1).Return([]int{}, nil)
+				mockRanker.EXPECT().Strategy(1).Return(strategy)
+				mockDiscover.EXPECT().OpenPointInTime(ctx).Return("pit-1", nil)
+				mockDiscover.EXPECT().SearchOthers(ctx, gomock.Any()).Return(found(near, far), nil)
+				mockActivity.EXPECT().TouchActivity(ctx, 1, gomock.Any()).Return(nil)
+				mockDiscover.EXPECT().ClosePointInTime(ctx, "pit-2").Return(errors.New("es error"))
+				mockRanker.EXPECT().Rank(ctx, strategy, gomock.Any()).DoAndReturn(unchanged)
+			},
+			expectedIDs: []int{3, 4},
+		},
+		{
+			name:   "returns a cursor when there are more results",
+			params: entity.SearchParams{Lat: 51.5, Lon: -0.12, Limit: 1},
+			setupMocks: func() {
+				mockFetcher.EXPECT().FindByID(ctx, 1).Return(me, nil)
+				mockPreferences.EXPECT().GetPreferences(ctx, 1).Return(saved, nil)
+				mockBlocks.EXPECT().BlockedIDs(ctx, 1).Return([]int{}, nil)
+				mockRanker.EXPECT().Strategy(1).Return(strategy)
+				mockDiscover.EXPECT().OpenPointInTime(ctx).Return("pit-1", nil)
+				mockDiscover.EXPECT().SearchOthers(ctx, gomock.Any()).DoAndReturn(
+					func(_ context.Context, params entity.SearchParams) (entity.SearchResult, error) {
+						assert.Equal(t, 2, params.Limit)
+
+						return found(near, far), nil
+					})
+				mockActivity.EXPECT().TouchActivity(ctx, 1, gomock.Any()).Return(nil)
+				mockRanker.EXPECT().Rank(ctx, strategy, gomock.Any()).DoAndReturn(unchanged)
+			},
+			expectedIDs:  []int{3},
+			expectedNext: &entity.DiscoverCursor{Lat: 51.5, Lon: -0.12, At: at, PIT: "pit-2", Sort: near.Sort},
+		},
+		{
+			name: "resumes the cursor's session",
+			params: entity.SearchParams{
+				Lat:   40.7,
+				Lon:   -74.0,
+				Limit: 1,
+				After: &entity.DiscoverCursor{
+					Lat:  51.5,
+					Lon:  -0.12,
+					At:   at.Add(-time.Minute),
+					PIT:  "pit-1",
+					Sort: near.Sort,
+				},
+			},
+			setupMocks: func() {
+				mockFetcher.EXPECT().FindByID(ctx, 1).Return(me, nil)
+				mockPreferences.EXPECT().GetPreferences(ctx, 1).Return(saved, nil)
+				mockBlocks.EXPECT().BlockedIDs(ctx, 1).Return([]int{}, nil)
+				mockRanker.EXPECT().Strategy(1).Return(strategy)
+				mockDiscover.EXPECT().SearchOthers(ctx, gomock.Any()).DoAndReturn(
+					func(_ context.Context, params entity.SearchParams) (entity.SearchResult, error) {
+						assert.Equal(t, 51.5, params.Lat)
+						assert.Equal(t, -0.12, params.Lon)
+						assert.Equal(t, at.Add(-time.Minute), params.At)
+						assert.Equal(t, "pit-1", params.PIT)
+						assert.Equal(t, near.Sort, params.After.Sort)
+
+						return found(far), nil
+					})
+				mockActivity.EXPECT().TouchActivity(ctx, 1, gomock.Any()).Return(nil)
+				mockDiscover.EXPECT().ClosePointInTime(ctx, "pit-2").Return(nil)
+				mockRanker.EXPECT().Rank(ctx, strategy, gomock.Any()).DoAndReturn(unchanged)
+			},
+			expectedIDs: []int{4},
+		},
+		{
+			name:   "block lookup failure",
+			params: entity.SearchParams{Lat: 51.5, Lon: -0.12},
+			setupMocks: func() {
+				mockFetcher.EXPECT().FindByID(ctx, 1).Return(me, nil)
+				mockPreferences.EXPECT().GetPreferences(ctx, 1).Return(saved, nil)
+				mockBlocks.EXPECT().BlockedIDs(ctx, 1).Return(nil, errors.New("db error"))
+			},
+			expectedError: errors.New("failed to get blocks: db error"),
+		},
+		{
+			name:   "search failure",
+			params: entity.SearchParams{Lat: 51.5, Lon: -0.12},
+			setupMocks: func() {
+				mockFetcher.EXPECT().FindByID(ctx, 1).Return(me, nil)
+				mockPreferences.EXPECT().GetPreferences(ctx, 1).Return(saved, nil)
+				mockBlocks.EXPECT().BlockedIDs(ctx, 1).Return([]int{}, nil)
+				mockRanker.EXPECT().Strategy(1).Return(strategy)
+				mockDiscover.EXPECT().OpenPointInTime(ctx).Return("pit-1", nil)
+				mockDiscover.EXPECT().SearchOthers(ctx, gomock.Any()).Return(entity.SearchResult{}, errors.New("es error"))
+			},
+			expectedError: errors.New("failed to search for others es error"),
+		},
+		{
+			name:   "point in time failure",
+			params: entity.SearchParams{Lat: 51.5, Lon: -0.12},
+			setupMocks: func() {
+				mockFetcher.EXPECT().FindByID(ctx, 1).Return(me, nil)
+				mockPreferences.EXPECT().GetPreferences(ctx, 1).Return(saved, nil)
+				mockBlocks.EXPECT().BlockedIDs(ctx, 1).Return([]int{}, nil)
+				mockDiscover.EXPECT().OpenPointInTime(ctx).Return("", errors.New("es error"))
+			},
+			expectedError: errors.New("failed to open point in time: es error"),
+		},
+		{
+			name:   "ranks the page after taking the cursor",
+			params: entity.SearchParams{Lat: 51.5, Lon: -0.12, Limit: 2},
+			setupMocks: func() {
+				mockFetcher.EXPECT().FindByID(ctx, 1).Return(me, nil)
+				mockPreferences.EXPECT().GetPreferences(ctx, 1).Return(saved, nil)
+				mockBlocks.EXPECT().BlockedIDs(ctx, 1).Return([]int{}, nil)
+				mockRanker.EXPECT().Strategy(1).Return(strategy)
+				mockDiscover.EXPECT().OpenPointInTime(ctx).Return("pit-1", nil)
+				mockDiscover.EXPECT().SearchOthers(ctx, gomock.Any()).Return(found(
+					entity.User{ID: 3, Score: 0.9, DistanceFromMe: 1.3, Sort: []float64{0.9, 1.3, 3, 11}},
+					entity.User{ID: 4, Score: 0.8, DistanceFromMe: 262.8, Sort: []float64{0.8, 262.8, 4, 12}},
+					entity.User{ID: 5, Score: 0.1, DistanceFromMe: 300, Sort: []float64{0.1, 300, 5, 13}},
+				), nil)
+				mockActivity.EXPECT().TouchActivity(ctx, 1, gomock.Any()).Return(errors.New("es error"))
+				mockRanker.EXPECT().Rank(ctx, strategy, gomock.Any()).DoAndReturn(
+					func(_ context.Context, _ entity.RankingStrategy, users []entity.User) ([]entity.User, error) {
+						return []entity.User{users[1], users[0]}, nil
+					})
+			},
+			expectedIDs:       []int{4, 3},
+			expectedDistances: []float64{270, 2},
+			expectedNext: &entity.DiscoverCursor{
+				Lat:  51.5,
+				Lon:  -0.12,
+				At:   at,
+				PIT:  "pit-2",
+				Sort: []float64{0.8, 262.8, 4, 12},
+			},
+		},
+		{
+			name:   "rank failure",
+			params: entity.SearchParams{Lat: 51.5, Lon: -0.12},
+			setupMocks: func() {
+				mockFetcher.EXPECT().FindByID(ctx, 1).Return(me, nil)
+				mockPreferences.EXPECT().GetPreferences(ctx, 1).Return(saved, nil)
+				mockBlocks.EXPECT().BlockedIDs(ctx, 1).Return([]int{}, nil)
+				mockRanker.EXPECT().Strategy(1).Return(strategy)
+				mockDiscover.EXPECT().OpenPointInTime(ctx).Return("pit-1", nil)
+				mockDiscover.EXPECT().SearchOthers(ctx, gomock.Any()).Return(found(near), nil)
+				mockActivity.EXPECT().TouchActivity(ctx, 1, gomock.Any()).Return(nil)
+				mockDiscover.EXPECT().ClosePointInTime(ctx, "pit-2").Return(nil)
+				mockRanker.EXPECT().Rank(ctx, strategy, gomock.Any()).Return(nil, errors.New("db error"))
+			},
+			expectedError: errors.New("failed to rank: db error"),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.setupMocks()
+
+			page, err := service.DiscoverPeople(ctx, 1, tt.params)
+
+			if tt.expectedError != nil {
+				assert.EqualError(t, err, tt.expectedError.Error())
+				return
+			}
+
+			assert.NoError(t, err)
+
+			ids := make([]int, 0, len(page.Users))
+			distances := make([]float64, 0, len(page.Users))
+			for _, user := range page.Users {
+				ids = append(ids, user.ID)
+				distances = append(distances, user.DistanceFromMe)
+			}
+
+			assert.Equal(t, tt.expectedIDs, ids)
+
+			if tt.expectedDistances != nil {
+				assert.Equal(t, tt.expectedDistances, distances)
+			}
+			assert.Equal(t, tt.expectedNext, page.Next)
+			assert.Equal(t, strategy.Name, page.Strategy)
+		})
+	}
+}
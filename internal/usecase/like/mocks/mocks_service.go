@@ -0,0 +1,128 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: service.go
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	context "context"
+	reflect "reflect"
+
+	model "github.com/colmmurphy91/muzz/internal/adapter/mysql/user/model"
+	entity "github.com/colmmurphy91/muzz/internal/entity"
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MocklikeFinder is a mock of likeFinder interface.
+type MocklikeFinder struct {
+	ctrl     *gomock.Controller
+	recorder *MocklikeFinderMockRecorder
+}
+
+// MocklikeFinderMockRecorder is the mock recorder for MocklikeFinder.
+type MocklikeFinderMockRecorder struct {
+	mock *MocklikeFinder
+}
+
+// NewMocklikeFinder creates a new mock instance.
+func NewMocklikeFinder(ctrl *gomock.Controller) *MocklikeFinder {
+	mock := &MocklikeFinder{ctrl: ctrl}
+	mock.recorder = &MocklikeFinderMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MocklikeFinder) EXPECT() *MocklikeFinderMockRecorder {
+	return m.recorder
+}
+
+// GetReceivedLikes mocks base method.
+func (m *MocklikeFinder) GetReceivedLikes(ctx context.Context, userID, beforeID, limit int) ([]entity.ReceivedLike, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetReceivedLikes", ctx, userID, beforeID, limit)
+	ret0, _ := ret[0].([]entity.ReceivedLike)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetReceivedLikes indicates an expected call of GetReceivedLikes.
+func (mr *MocklikeFinderMockRecorder) GetReceivedLikes(ctx, userID, beforeID, limit interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetReceivedLikes", reflect.TypeOf((*MocklikeFinder)(nil).GetReceivedLikes), ctx, userID, beforeID, limit)
+}
+
+// MockprofileFetcher is a mock of profileFetcher interface.
+type MockprofileFetcher struct {
+	ctrl     *gomock.Controller
+	recorder *MockprofileFetcherMockRecorder
+}
+
+// MockprofileFetcherMockRecorder is the mock recorder for MockprofileFetcher.
+type MockprofileFetcherMockRecorder struct {
+	mock *MockprofileFetcher
+}
+
+// NewMockprofileFetcher creates a new mock instance.
+func NewMockprofileFetcher(ctrl *gomock.Controller) *MockprofileFetcher {
+	mock := &MockprofileFetcher{ctrl: ctrl}
+	mock.recorder = &MockprofileFetcherMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockprofileFetcher) EXPECT() *MockprofileFetcherMockRecorder {
+	return m.recorder
+}
+
+// FindByIDs mocks base method.
+func (m *MockprofileFetcher) FindByIDs(ctx context.Context, userIDs []int) ([]model.User, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindByIDs", ctx, userIDs)
+	ret0, _ := ret[0].([]model.User)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindByIDs indicates an expected call of FindByIDs.
+func (mr *MockprofileFetcherMockRecorder) FindByIDs(ctx, userIDs interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindByIDs", reflect.TypeOf((*MockprofileFetcher)(nil).FindByIDs), ctx, userIDs)
+}
+
+// MockblockLister is a mock of blockLister interface.
+type MockblockLister struct {
+	ctrl     *gomock.Controller
+	recorder *MockblockListerMockRecorder
+}
+
+// MockblockListerMockRecorder is the mock recorder for MockblockLister.
+type MockblockListerMockRecorder struct {
+	mock *MockblockLister
+}
+
+// NewMockblockLister creates a new mock instance.
+func NewMockblockLister(ctrl *gomock.Controller) *MockblockLister {
+	mock := &MockblockLister{ctrl: ctrl}
+	mock.recorder = &MockblockListerMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockblockLister) EXPECT() *MockblockListerMockRecorder {
+	return m.recorder
+}
+
+// BlockedIDs mocks base method.
+func (m *MockblockLister) BlockedIDs(ctx context.Context, userID int) ([]int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "BlockedIDs", ctx, userID)
+	ret0, _ := ret[0].([]int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// BlockedIDs indicates an expected call of BlockedIDs.
+func (mr *MockblockListerMockRecorder) BlockedIDs(ctx, userID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "BlockedIDs", reflect.TypeOf((*MockblockLister)(nil).BlockedIDs), ctx, userID)
+}
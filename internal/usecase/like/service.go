@@ -0,0 +1,104 @@
+package like
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/colmmurphy91/muzz/internal/adapter/mysql/user/model"
+	"github.com/colmmurphy91/muzz/internal/entity"
+)
+
+//go:generate mockgen -source $GOFILE -destination mocks/mocks_${GOFILE} -package mocks
+
+type likeFinder interface {
+	GetReceivedLikes(ctx context.Context, userID, beforeID, limit int) ([]entity.ReceivedLike, error)
+}
+
+type profileFetcher interface {
+	FindByIDs(ctx context.Context, userIDs []int) ([]model.User, error)
+}
+
+// blockLister lists everyone a user has blocked or been blocked by.
+type blockLister interface {
+	BlockedIDs(ctx context.Context, userID int) ([]int, error)
+}
+
+type Service struct {
+	likeFinder     likeFinder
+	profileFetcher profileFetcher
+	blockLister    blockLister
+}
+
+func NewService(likes likeFinder, profiles profileFetcher, blocks blockLister) *Service {
+	return &Service{
+		likeFinder:     likes,
+		profileFetcher: profiles,
+		blockLister:    blocks,
+	}
+}
+
+// ReceivedLikes returns a page of people who swiped yes on the user and whom
+// the user has not swiped on yet, newest first. Likes between people either
+// way blocked are left out.
+func (s *Service) ReceivedLikes(ctx context.Context, userID, limit int, after *entity.LikesCursor) (entity.LikesPage, error) {
+	if limit <= 0 {
+		limit = entity.DefaultLikesLimit
+	}
+
+	beforeID := 0
+	if after != nil {
+		beforeID = after.SwipeID
+	}
+
+	// Ask for one extra to know whether there is another page.
+	received, err := s.likeFinder.GetReceivedLikes(ctx, userID, beforeID, limit+1)
+	if err != nil {
+		return entity.LikesPage{}, fmt.Errorf("failed to get received likes: %w", err)
+	}
+
+	var page entity.LikesPage
+
+	if len(received) > limit {
+		received = received[:limit]
+		page.Next = &entity.LikesCursor{SwipeID: received[limit-1].SwipeID}
+	}
+
+	blocked, err := s.blockLister.BlockedIDs(ctx, userID)
+	if err != nil {
+		return entity.LikesPage{}, fmt.Errorf("failed to get blocks: %w", err)
+	}
+
+	skip := make(map[int]bool, len(blocked))
+	for _, id := range blocked {
+		skip[id] = true
+	}
+
+	ids := make([]int, len(received))
+	for i, like := range received {
+		ids[i] = like.UserID
+	}
+
+	users, err := s.profileFetcher.FindByIDs(ctx, ids)
+	if err != nil {
+		return entity.LikesPage{}, fmt.Errorf("failed to get profiles: %w", err)
+	}
+
+	profiles := make(map[int]entity.User, len(users))
+	for _, user := range users {
+		profiles[user.ID] = user.Profile()
+	}
+
+	page.Likes = make([]entity.Like, 0, len(received))
+
+	for _, like := range received {
+		// People who have since deleted their account are left out.
+		profile, ok := profiles[like.UserID]
+		if !ok || skip[like.UserID] {
+			continue
+		}
+
+		page.Likes = append(page.Likes, entity.Like{User: profile, Super: like.Super, LikedAt: like.LikedAt})
+	}
+
+	return page, nil
+}
@@ -0,0 +1,148 @@
+package like
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/colmmurphy91/muzz/internal/adapter/mysql/user/model"
+	"github.com/colmmurphy91/muzz/internal/entity"
+	"github.com/colmmurphy91/muzz/internal/usecase/like/mocks"
+)
+
+func TestService_ReceivedLikes(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockLikes := mocks.NewMocklikeFinder(ctrl)
+	mockProfiles := mocks.NewMockprofileFetcher(ctrl)
+	mockBlocks := mocks.NewMockblockLister(ctrl)
+	service := NewService(mockLikes, mockProfiles, mockBlocks)
+
+	ctx := context.Background()
+	likedAt := time.Date(2024, 6, 24, 12, 0, 0, 0, time.UTC)
+	alice := model.User{ID: 2, Email: "alice@example.com", Password: "hash", Name: "Alice", Gender: "female", Age: 29, Lat: 51.5, Lon: -0.12}
+	beth := model.User{ID: 3, Email: "beth@example.com", Password: "hash", Name: "Beth", Gender: "female", Age: 31, Lat: 53.4, Lon: -2.2}
+
+	tests := []struct {
+		name          string
+		limit         int
+		after         *entity.LikesCursor
+		setupMocks    func()
+		expected      entity.LikesPage
+		expectedError error
+	}{
+		{
+			name:  "hydrates likes with public profiles and flags super likes",
+			limit: 0,
+			setupMocks: func() {
+				mockLikes.EXPECT().GetReceivedLikes(ctx, 1, 0, entity.DefaultLikesLimit+1).Return([]entity.ReceivedLike{
+					{SwipeID: 9, UserID: 3, Super: true, LikedAt: likedAt},
+					{SwipeID: 7, UserID: 2, LikedAt: likedAt.Add(-time.Hour)},
+				}, nil)
+				mockProfiles.EXPECT().FindByIDs(ctx, []int{3, 2}).Return([]model.User{alice, beth}, nil)
+				mockBlocks.EXPECT().BlockedIDs(ctx, 1).Return([]int{}, nil)
+			},
+			expected: entity.LikesPage{
+				Likes: []entity.Like{
+					{User: beth.Profile(), Super: true, LikedAt: likedAt},
+					{User: alice.Profile(), LikedAt: likedAt.Add(-time.Hour)},
+				},
+			},
+		},
+		{
+			name:  "returns a cursor when there are more likes",
+			limit: 1,
+			after: &entity.LikesCursor{SwipeID: 12},
+			setupMocks: func() {
+				mockLikes.EXPECT().GetReceivedLikes(ctx, 1, 12, 2).Return([]entity.ReceivedLike{
+					{SwipeID: 9, UserID: 3, LikedAt: likedAt},
+					{SwipeID: 7, UserID: 2, LikedAt: likedAt},
+				}, nil)
+				mockProfiles.EXPECT().FindByIDs(ctx, []int{3}).Return([]model.User{beth}, nil)
+				mockBlocks.EXPECT().BlockedIDs(ctx, 1).Return([]int{}, nil)
+			},
+			expected: entity.LikesPage{
+				Likes: []entity.Like{{User: beth.Profile(), LikedAt: likedAt}},
+				Next:  &entity.LikesCursor{SwipeID: 9},
+			},
+		},
+		{
+			name:  "leaves out deleted users",
+			limit: 10,
+			setupMocks: func() {
+				mockLikes.EXPECT().GetReceivedLikes(ctx, 1, 0, 11).Return([]entity.ReceivedLike{
+					{SwipeID: 9, UserID: 3, LikedAt: likedAt},
+					{SwipeID: 7, UserID: 2, LikedAt: likedAt},
+				}, nil)
+				mockProfiles.EXPECT().FindByIDs(ctx, []int{3, 2}).Return([]model.User{alice}, nil)
+				mockBlocks.EXPECT().BlockedIDs(ctx, 1).Return([]int{}, nil)
+			},
+			expected: entity.LikesPage{
+				Likes: []entity.Like{{User: alice.Profile(), LikedAt: likedAt}},
+			},
+		},
+		{
+			name:  "leaves out blocked users",
+			limit: 10,
+			setupMocks: func() {
+				mockLikes.EXPECT().GetReceivedLikes(ctx, 1, 0, 11).Return([]entity.ReceivedLike{
+					{SwipeID: 9, UserID: 3, LikedAt: likedAt},
+					{SwipeID: 7, UserID: 2, LikedAt: likedAt},
+				}, nil)
+				mockProfiles.EXPECT().FindByIDs(ctx, []int{3, 2}).Return([]model.User{alice, beth}, nil)
+				mockBlocks.EXPECT().BlockedIDs(ctx, 1).Return([]int{3}, nil)
+			},
+			expected: entity.LikesPage{
+				Likes: []entity.Like{{User: alice.Profile(), LikedAt: likedAt}},
+			},
+		},
+		{
+			name:  "blocks failure",
+			limit: 10,
+			setupMocks: func() {
+				mockLikes.EXPECT().GetReceivedLikes(ctx, 1, 0, 11).Return([]entity.ReceivedLike{{SwipeID: 9, UserID: 3}}, nil)
+				mockBlocks.EXPECT().BlockedIDs(ctx, 1).Return(nil, errors.New("db error"))
+			},
+			expectedError: errors.New("failed to get blocks: db error"),
+		},
+		{
+			name:  "likes failure",
+			limit: 10,
+			setupMocks: func() {
+				mockLikes.EXPECT().GetReceivedLikes(ctx, 1, 0, 11).Return(nil, errors.New("db error"))
+			},
+			expectedError: errors.New("failed to get received likes: db error"),
+		},
+		{
+			name:  "profiles failure",
+			limit: 10,
+			setupMocks: func() {
+				mockLikes.EXPECT().GetReceivedLikes(ctx, 1, 0, 11).Return([]entity.ReceivedLike{{SwipeID: 9, UserID: 3}}, nil)
+				mockBlocks.EXPECT().BlockedIDs(ctx, 1).Return([]int{}, nil)
+				mockProfiles.EXPECT().FindByIDs(ctx, []int{3}).Return(nil, errors.New("db error"))
+			},
+			expectedError: errors.New("failed to get profiles: db error"),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.setupMocks()
+
+			page, err := service.ReceivedLikes(ctx, 1, tt.limit, tt.after)
+
+			if tt.expectedError != nil {
+				assert.EqualError(t, err, tt.expectedError.Error())
+				return
+			}
+
+			assert.NoError(t, err)
+			assert.Equal(t, tt.expected, page)
+		})
+	}
+}
@@ -0,0 +1,156 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: service.go
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	context "context"
+	reflect "reflect"
+
+	model "github.com/colmmurphy91/muzz/internal/adapter/mysql/user/model"
+	entity "github.com/colmmurphy91/muzz/internal/entity"
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockmatchStore is a mock of matchStore interface.
+type MockmatchStore struct {
+	ctrl     *gomock.Controller
+	recorder *MockmatchStoreMockRecorder
+}
+
+// MockmatchStoreMockRecorder is the mock recorder for MockmatchStore.
+type MockmatchStoreMockRecorder struct {
+	mock *MockmatchStore
+}
+
+// NewMockmatchStore creates a new mock instance.
+func NewMockmatchStore(ctrl *gomock.Controller) *MockmatchStore {
+	mock := &MockmatchStore{ctrl: ctrl}
+	mock.recorder = &MockmatchStoreMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockmatchStore) EXPECT() *MockmatchStoreMockRecorder {
+	return m.recorder
+}
+
+// FindMatch mocks base method.
+func (m *MockmatchStore) FindMatch(ctx context.Context, id int) (entity.Match, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindMatch", ctx, id)
+	ret0, _ := ret[0].(entity.Match)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindMatch indicates an expected call of FindMatch.
+func (mr *MockmatchStoreMockRecorder) FindMatch(ctx, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindMatch", reflect.TypeOf((*MockmatchStore)(nil).FindMatch), ctx, id)
+}
+
+// GetMatches mocks base method.
+func (m *MockmatchStore) GetMatches(ctx context.Context, userID, beforeID, limit int) ([]entity.Match, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetMatches", ctx, userID, beforeID, limit)
+	ret0, _ := ret[0].([]entity.Match)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetMatches indicates an expected call of GetMatches.
+func (mr *MockmatchStoreMockRecorder) GetMatches(ctx, userID, beforeID, limit interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetMatches", reflect.TypeOf((*MockmatchStore)(nil).GetMatches), ctx, userID, beforeID, limit)
+}
+
+// Unmatch mocks base method.
+func (m *MockmatchStore) Unmatch(ctx context.Context, id, userID int) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Unmatch", ctx, id, userID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Unmatch indicates an expected call of Unmatch.
+func (mr *MockmatchStoreMockRecorder) Unmatch(ctx, id, userID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Unmatch", reflect.TypeOf((*MockmatchStore)(nil).Unmatch), ctx, id, userID)
+}
+
+// MockprofileFetcher is a mock of profileFetcher interface.
+type MockprofileFetcher struct {
+	ctrl     *gomock.Controller
+	recorder *MockprofileFetcherMockRecorder
+}
+
+// MockprofileFetcherMockRecorder is the mock recorder for MockprofileFetcher.
+type MockprofileFetcherMockRecorder struct {
+	mock *MockprofileFetcher
+}
+
+// NewMockprofileFetcher creates a new mock instance.
+func NewMockprofileFetcher(ctrl *gomock.Controller) *MockprofileFetcher {
+	mock := &MockprofileFetcher{ctrl: ctrl}
+	mock.recorder = &MockprofileFetcherMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockprofileFetcher) EXPECT() *MockprofileFetcherMockRecorder {
+	return m.recorder
+}
+
+// FindByIDs mocks base method.
+func (m *MockprofileFetcher) FindByIDs(ctx context.Context, userIDs []int) ([]model.User, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindByIDs", ctx, userIDs)
+	ret0, _ := ret[0].([]model.User)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindByIDs indicates an expected call of FindByIDs.
+func (mr *MockprofileFetcherMockRecorder) FindByIDs(ctx, userIDs interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindByIDs", reflect.TypeOf((*MockprofileFetcher)(nil).FindByIDs), ctx, userIDs)
+}
+
+// MockswipedIndexer is a mock of swipedIndexer interface.
+type MockswipedIndexer struct {
+	ctrl     *gomock.Controller
+	recorder *MockswipedIndexerMockRecorder
+}
+
+// MockswipedIndexerMockRecorder is the mock recorder for MockswipedIndexer.
+type MockswipedIndexerMockRecorder struct {
+	mock *MockswipedIndexer
+}
+
+// NewMockswipedIndexer creates a new mock instance.
+func NewMockswipedIndexer(ctrl *gomock.Controller) *MockswipedIndexer {
+	mock := &MockswipedIndexer{ctrl: ctrl}
+	mock.recorder = &MockswipedIndexerMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockswipedIndexer) EXPECT() *MockswipedIndexerMockRecorder {
+	return m.recorder
+}
+
+// AddSwiped mocks base method.
+func (m *MockswipedIndexer) AddSwiped(ctx context.Context, userID, targetID int) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AddSwiped", ctx, userID, targetID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// AddSwiped indicates an expected call of AddSwiped.
+func (mr *MockswipedIndexerMockRecorder) AddSwiped(ctx, userID, targetID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddSwiped", reflect.TypeOf((*MockswipedIndexer)(nil).AddSwiped), ctx, userID, targetID)
+}
@@ -0,0 +1,133 @@
+package match
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/colmmurphy91/muzz/internal/adapter/mysql/user/model"
+	"github.com/colmmurphy91/muzz/internal/entity"
+)
+
+//go:generate mockgen -source $GOFILE -destination mocks/mocks_${GOFILE} -package mocks
+
+type matchStore interface {
+	GetMatches(ctx context.Context, userID, beforeID, limit int) ([]entity.Match, error)
+	FindMatch(ctx context.Context, id int) (entity.Match, error)
+	Unmatch(ctx context.Context, id, userID int) error
+}
+
+type profileFetcher interface {
+	FindByIDs(ctx context.Context, userIDs []int) ([]model.User, error)
+}
+
+// swipedIndexer keeps the list of people a user has swiped on that discovery excludes.
+type swipedIndexer interface {
+	AddSwiped(ctx context.Context, userID, targetID int) error
+}
+
+type Service struct {
+	matchStore     matchStore
+	profileFetcher profileFetcher
+	swipedIndexer  swipedIndexer
+}
+
+func NewService(matches matchStore, profiles profileFetcher, indexer swipedIndexer) *Service {
+	return &Service{
+		matchStore:     matches,
+		profileFetcher: profiles,
+		swipedIndexer:  indexer,
+	}
+}
+
+// GetMatches returns a page of the user's matches, newest first, each with the
+// profile of the person they matched with.
+func (s *Service) GetMatches(ctx context.Context, userID, limit int, after *entity.MatchesCursor) (entity.MatchesPage, error) {
+	if limit <= 0 {
+		limit = entity.DefaultMatchesLimit
+	}
+
+	beforeID := 0
+	if after != nil {
+		beforeID = after.ID
+	}
+
+	// Ask for one extra to know whether there is another page.
+	matches, err := s.matchStore.GetMatches(ctx, userID, beforeID, limit+1)
+	if err != nil {
+		return entity.MatchesPage{}, fmt.Errorf("failed to get matches: %w", err)
+	}
+
+	var page entity.MatchesPage
+
+	if len(matches) > limit {
+		matches = matches[:limit]
+		page.Next = &entity.MatchesCursor{ID: matches[limit-1].ID}
+	}
+
+	ids := make([]int, len(matches))
+	for i, match := range matches {
+		ids[i] = match.Other(userID)
+	}
+
+	users, err := s.profileFetcher.FindByIDs(ctx, ids)
+	if err != nil {
+		return entity.MatchesPage{}, fmt.Errorf("failed to get profiles: %w", err)
+	}
+
+	profiles := make(map[int]entity.User, len(users))
+	for _, user := range users {
+		profiles[user.ID] = user.Profile()
+	}
+
+	page.Matches = make([]entity.MatchView, 0, len(matches))
+
+	for _, match := range matches {
+		// People who have since deleted their account are left out.
+		profile, ok := profiles[match.Other(userID)]
+		if !ok {
+			continue
+		}
+
+		page.Matches = append(page.Matches, entity.MatchView{
+			ID:        match.ID,
+			User:      profile,
+			MatchedAt: match.CreatedAt,
+		})
+	}
+
+	return page, nil
+}
+
+// Unmatch ends a match on behalf of one of the pair. The match stays on record
+// with who ended it, and the pair are kept out of each other's discover
+// results. Unmatching an already ended match does nothing.
+func (s *Service) Unmatch(ctx context.Context, userID, matchID int) error {
+	match, err := s.matchStore.FindMatch(ctx, matchID)
+	if err != nil {
+		return fmt.Errorf("failed to find match: %w", err)
+	}
+
+	if !match.Includes(userID) {
+		return fmt.Errorf("not part of match: %w", entity.ErrForbidden)
+	}
+
+	if match.UnmatchedAt.Valid {
+		return nil
+	}
+
+	if err := s.matchStore.Unmatch(ctx, matchID, userID); err != nil {
+		return fmt.Errorf("failed to unmatch: %w", err)
+	}
+
+	// Both already swiped on each other, so this only repairs the swiped index
+	// should either entry be missing.
+	if err := s.swipedIndexer.AddSwiped(ctx, match.User1ID, match.User2ID); err != nil {
+		return fmt.Errorf("failed to index swipe: %w", err)
+	}
+
+	if err := s.swipedIndexer.AddSwiped(ctx, match.User2ID, match.User1ID); err != nil {
+		return fmt.Errorf("failed to index swipe: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,204 @@
+package match
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	null "github.com/guregu/null/v5"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/colmmurphy91/muzz/internal/adapter/mysql/user/model"
+	"github.com/colmmurphy91/muzz/internal/entity"
+	"github.com/colmmurphy91/muzz/internal/usecase/match/mocks"
+)
+
+func TestService_GetMatches(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockMatches := mocks.NewMockmatchStore(ctrl)
+	mockProfiles := mocks.NewMockprofileFetcher(ctrl)
+	service := NewService(mockMatches, mockProfiles, mocks.NewMockswipedIndexer(ctrl))
+
+	ctx := context.Background()
+	matchedAt := time.Date(2024, 6, 25, 18, 0, 0, 0, time.UTC)
+	alice := model.User{ID: 2, Email: "alice@example.com", Password: "hash", Name: "Alice", Gender: "female", Age: 29}
+	beth := model.User{ID: 3, Email: "beth@example.com", Password: "hash", Name: "Beth", Gender: "female", Age: 31}
+
+	tests := []struct {
+		name          string
+		limit         int
+		after         *entity.MatchesCursor
+		setupMocks    func()
+		expected      entity.MatchesPage
+		expectedError error
+	}{
+		{
+			name: "shows the other person from either side",
+			setupMocks: func() {
+				mockMatches.EXPECT().GetMatches(ctx, 1, 0, entity.DefaultMatchesLimit+1).Return([]entity.Match{
+					{ID: 8, User1ID: 3, User2ID: 1, CreatedAt: matchedAt},
+					{ID: 5, User1ID: 1, User2ID: 2, CreatedAt: matchedAt.Add(-time.Hour)},
+				}, nil)
+				mockProfiles.EXPECT().FindByIDs(ctx, []int{3, 2}).Return([]model.User{alice, beth}, nil)
+			},
+			expected: entity.MatchesPage{
+				Matches: []entity.MatchView{
+					{ID: 8, User: beth.Profile(), MatchedAt: matchedAt},
+					{ID: 5, User: alice.Profile(), MatchedAt: matchedAt.Add(-time.Hour)},
+				},
+			},
+		},
+		{
+			name:  "returns a cursor when there are more matches",
+			limit: 1,
+			after: &entity.MatchesCursor{ID: 10},
+			setupMocks: func() {
+				mockMatches.EXPECT().GetMatches(ctx, 1, 10, 2).Return([]entity.Match{
+					{ID: 8, User1ID: 3, User2ID: 1, CreatedAt: matchedAt},
+					{ID: 5, User1ID: 1, User2ID: 2, CreatedAt: matchedAt},
+				}, nil)
+				mockProfiles.EXPECT().FindByIDs(ctx, []int{3}).Return([]model.User{beth}, nil)
+			},
+			expected: entity.MatchesPage{
+				Matches: []entity.MatchView{{ID: 8, User: beth.Profile(), MatchedAt: matchedAt}},
+				Next:    &entity.MatchesCursor{ID: 8},
+			},
+		},
+		{
+			name:  "leaves out deleted users",
+			limit: 10,
+			setupMocks: func() {
+				mockMatches.EXPECT().GetMatches(ctx, 1, 0, 11).Return([]entity.Match{
+					{ID: 8, User1ID: 3, User2ID: 1, CreatedAt: matchedAt},
+				}, nil)
+				mockProfiles.EXPECT().FindByIDs(ctx, []int{3}).Return([]model.User{}, nil)
+			},
+			expected: entity.MatchesPage{Matches: []entity.MatchView{}},
+		},
+		{
+			name:  "matches failure",
+			limit: 10,
+			setupMocks: func() {
+				mockMatches.EXPECT().GetMatches(ctx, 1, 0, 11).Return(nil, errors.New("db error"))
+			},
+			expectedError: errors.New("failed to get matches: db error"),
+		},
+		{
+			name:  "profiles failure",
+			limit: 10,
+			setupMocks: func() {
+				mockMatches.EXPECT().GetMatches(ctx, 1, 0, 11).Return([]entity.Match{{ID: 8, User1ID: 3, User2ID: 1}}, nil)
+				mockProfiles.EXPECT().FindByIDs(ctx, []int{3}).Return(nil, errors.New("db error"))
+			},
+			expectedError: errors.New("failed to get profiles: db error"),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.setupMocks()
+
+			page, err := service.GetMatches(ctx, 1, tt.limit, tt.after)
+
+			if tt.expectedError != nil {
+				assert.EqualError(t, err, tt.expectedError.Error())
+				return
+			}
+
+			assert.NoError(t, err)
+			assert.Equal(t, tt.expected, page)
+		})
+	}
+}
+
+func TestService_Unmatch(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockMatches := mocks.NewMockmatchStore(ctrl)
+	mockIndexer := mocks.NewMockswipedIndexer(ctrl)
+	service := NewService(mockMatches, mocks.NewMockprofileFetcher(ctrl), mockIndexer)
+
+	ctx := context.Background()
+	active := entity.Match{ID: 8, User1ID: 3, User2ID: 1}
+	ended := entity.Match{ID: 8, User1ID: 3, User2ID: 1, UnmatchedAt: null.TimeFrom(time.Now()), UnmatchedBy: null.IntFrom(3)}
+
+	tests := []struct {
+		name          string
+		userID        int
+		setupMocks    func()
+		expectedError error
+	}{
+		{
+			name:   "either of the pair can unmatch",
+			userID: 1,
+			setupMocks: func() {
+				mockMatches.EXPECT().FindMatch(ctx, 8).Return(active, nil)
+				mockMatches.EXPECT().Unmatch(ctx, 8, 1).Return(nil)
+				mockIndexer.EXPECT().AddSwiped(ctx, 3, 1).Return(nil)
+				mockIndexer.EXPECT().AddSwiped(ctx, 1, 3).Return(nil)
+			},
+		},
+		{
+			name:   "someone else cannot",
+			userID: 2,
+			setupMocks: func() {
+				mockMatches.EXPECT().FindMatch(ctx, 8).Return(active, nil)
+			},
+			expectedError: errors.New("not part of match: forbidden"),
+		},
+		{
+			name:   "already unmatched",
+			userID: 1,
+			setupMocks: func() {
+				mockMatches.EXPECT().FindMatch(ctx, 8).Return(ended, nil)
+			},
+		},
+		{
+			name:   "match not found",
+			userID: 1,
+			setupMocks: func() {
+				mockMatches.EXPECT().FindMatch(ctx, 8).Return(entity.Match{}, entity.ErrMatchNotFound)
+			},
+			expectedError: errors.New("failed to find match: match does not exist"),
+		},
+		{
+			name:   "unmatch failure",
+			userID: 1,
+			setupMocks: func() {
+				mockMatches.EXPECT().FindMatch(ctx, 8).Return(active, nil)
+				mockMatches.EXPECT().Unmatch(ctx, 8, 1).Return(errors.New("db error"))
+			},
+			expectedError: errors.New("failed to unmatch: db error"),
+		},
+		{
+			name:   "index failure",
+			userID: 3,
+			setupMocks: func() {
+				mockMatches.EXPECT().FindMatch(ctx, 8).Return(active, nil)
+				mockMatches.EXPECT().Unmatch(ctx, 8, 3).Return(nil)
+				mockIndexer.EXPECT().AddSwiped(ctx, 3, 1).Return(errors.New("es error"))
+			},
+			expectedError: errors.New("failed to index swipe: es error"),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.setupMocks()
+
+			err := service.Unmatch(ctx, tt.userID, 8)
+
+			if tt.expectedError != nil {
+				assert.EqualError(t, err, tt.expectedError.Error())
+				return
+			}
+
+			assert.NoError(t, err)
+		})
+	}
+}
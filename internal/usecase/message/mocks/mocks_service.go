@@ -0,0 +1,309 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: service.go
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	context "context"
+	reflect "reflect"
+
+	entity "github.com/colmmurphy91/muzz/internal/entity"
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockmessageStore is a mock of messageStore interface.
+type MockmessageStore struct {
+	ctrl     *gomock.Controller
+	recorder *MockmessageStoreMockRecorder
+}
+
+// MockmessageStoreMockRecorder is the mock recorder for MockmessageStore.
+type MockmessageStoreMockRecorder struct {
+	mock *MockmessageStore
+}
+
+// NewMockmessageStore creates a new mock instance.
+func NewMockmessageStore(ctrl *gomock.Controller) *MockmessageStore {
+	mock := &MockmessageStore{ctrl: ctrl}
+	mock.recorder = &MockmessageStoreMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockmessageStore) EXPECT() *MockmessageStoreMockRecorder {
+	return m.recorder
+}
+
+// CountUnread mocks base method.
+func (m *MockmessageStore) CountUnread(ctx context.Context, matchID, userID, afterID int) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CountUnread", ctx, matchID, userID, afterID)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CountUnread indicates an expected call of CountUnread.
+func (mr *MockmessageStoreMockRecorder) CountUnread(ctx, matchID, userID, afterID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CountUnread", reflect.TypeOf((*MockmessageStore)(nil).CountUnread), ctx, matchID, userID, afterID)
+}
+
+// CreateMessage mocks base method.
+func (m *MockmessageStore) CreateMessage(ctx context.Context, message entity.Message) (entity.Message, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateMessage", ctx, message)
+	ret0, _ := ret[0].(entity.Message)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateMessage indicates an expected call of CreateMessage.
+func (mr *MockmessageStoreMockRecorder) CreateMessage(ctx, message interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateMessage", reflect.TypeOf((*MockmessageStore)(nil).CreateMessage), ctx, message)
+}
+
+// GetMessages mocks base method.
+func (m *MockmessageStore) GetMessages(ctx context.Context, matchID, beforeID, limit int) ([]entity.Message, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetMessages", ctx, matchID, beforeID, limit)
+	ret0, _ := ret[0].([]entity.Message)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetMessages indicates an expected call of GetMessages.
+func (mr *MockmessageStoreMockRecorder) GetMessages(ctx, matchID, beforeID, limit interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetMessages", reflect.TypeOf((*MockmessageStore)(nil).GetMessages), ctx, matchID, beforeID, limit)
+}
+
+// GetReads mocks base method.
+func (m *MockmessageStore) GetReads(ctx context.Context, matchID int) ([]entity.MessageRead, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetReads", ctx, matchID)
+	ret0, _ := ret[0].([]entity.MessageRead)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetReads indicates an expected call of GetReads.
+func (mr *MockmessageStoreMockRecorder) GetReads(ctx, matchID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetReads", reflect.TypeOf((*MockmessageStore)(nil).GetReads), ctx, matchID)
+}
+
+// GetUnreadCounts mocks base method.
+func (m *MockmessageStore) GetUnreadCounts(ctx context.Context, userID int) ([]entity.UnreadCount, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetUnreadCounts", ctx, userID)
+	ret0, _ := ret[0].([]entity.UnreadCount)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetUnreadCounts indicates an expected call of GetUnreadCounts.
+func (mr *MockmessageStoreMockRecorder) GetUnreadCounts(ctx, userID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUnreadCounts", reflect.TypeOf((*MockmessageStore)(nil).GetUnreadCounts), ctx, userID)
+}
+
+// LastMessageID mocks base method.
+func (m *MockmessageStore) LastMessageID(ctx context.Context, matchID int) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "LastMessageID", ctx, matchID)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// LastMessageID indicates an expected call of LastMessageID.
+func (mr *MockmessageStoreMockRecorder) LastMessageID(ctx, matchID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "LastMessageID", reflect.TypeOf((*MockmessageStore)(nil).LastMessageID), ctx, matchID)
+}
+
+// MarkRead mocks base method.
+func (m *MockmessageStore) MarkRead(ctx context.Context, read entity.MessageRead) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "MarkRead", ctx, read)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// MarkRead indicates an expected call of MarkRead.
+func (mr *MockmessageStoreMockRecorder) MarkRead(ctx, read interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MarkRead", reflect.TypeOf((*MockmessageStore)(nil).MarkRead), ctx, read)
+}
+
+// StartConversation mocks base method.
+func (m *MockmessageStore) StartConversation(ctx context.Context, matchID int) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "StartConversation", ctx, matchID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// StartConversation indicates an expected call of StartConversation.
+func (mr *MockmessageStoreMockRecorder) StartConversation(ctx, matchID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "StartConversation", reflect.TypeOf((*MockmessageStore)(nil).StartConversation), ctx, matchID)
+}
+
+// MockmatchFinder is a mock of matchFinder interface.
+type MockmatchFinder struct {
+	ctrl     *gomock.Controller
+	recorder *MockmatchFinderMockRecorder
+}
+
+// MockmatchFinderMockRecorder is the mock recorder for MockmatchFinder.
+type MockmatchFinderMockRecorder struct {
+	mock *MockmatchFinder
+}
+
+// NewMockmatchFinder creates a new mock instance.
+func NewMockmatchFinder(ctrl *gomock.Controller) *MockmatchFinder {
+	mock := &MockmatchFinder{ctrl: ctrl}
+	mock.recorder = &MockmatchFinderMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockmatchFinder) EXPECT() *MockmatchFinderMockRecorder {
+	return m.recorder
+}
+
+// FindMatch mocks base method.
+func (m *MockmatchFinder) FindMatch(ctx context.Context, id int) (entity.Match, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindMatch", ctx, id)
+	ret0, _ := ret[0].(entity.Match)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindMatch indicates an expected call of FindMatch.
+func (mr *MockmatchFinderMockRecorder) FindMatch(ctx, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindMatch", reflect.TypeOf((*MockmatchFinder)(nil).FindMatch), ctx, id)
+}
+
+// MockblockChecker is a mock of blockChecker interface.
+type MockblockChecker struct {
+	ctrl     *gomock.Controller
+	recorder *MockblockCheckerMockRecorder
+}
+
+// MockblockCheckerMockRecorder is the mock recorder for MockblockChecker.
+type MockblockCheckerMockRecorder struct {
+	mock *MockblockChecker
+}
+
+// NewMockblockChecker creates a new mock instance.
+func NewMockblockChecker(ctrl *gomock.Controller) *MockblockChecker {
+	mock := &MockblockChecker{ctrl: ctrl}
+	mock.recorder = &MockblockCheckerMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockblockChecker) EXPECT() *MockblockCheckerMockRecorder {
+	return m.recorder
+}
+
+// IsBlocked mocks base method.
+func (m *MockblockChecker) IsBlocked(ctx context.Context, userID, otherID int) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "IsBlocked", ctx, userID, otherID)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// IsBlocked indicates an expected call of IsBlocked.
+func (mr *MockblockCheckerMockRecorder) IsBlocked(ctx, userID, otherID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "IsBlocked", reflect.TypeOf((*MockblockChecker)(nil).IsBlocked), ctx, userID, otherID)
+}
+
+// Mockpusher is a mock of pusher interface.
+type Mockpusher struct {
+	ctrl     *gomock.Controller
+	recorder *MockpusherMockRecorder
+}
+
+// MockpusherMockRecorder is the mock recorder for Mockpusher.
+type MockpusherMockRecorder struct {
+	mock *Mockpusher
+}
+
+// NewMockpusher creates a new mock instance.
+func NewMockpusher(ctrl *gomock.Controller) *Mockpusher {
+	mock := &Mockpusher{ctrl: ctrl}
+	mock.recorder = &MockpusherMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *Mockpusher) EXPECT() *MockpusherMockRecorder {
+	return m.recorder
+}
+
+// Push mocks base method.
+func (m *Mockpusher) Push(ctx context.Context, notifications ...entity.Notification) error {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{ctx}
+	for _, a := range notifications {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "Push", varargs...)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Push indicates an expected call of Push.
+func (mr *MockpusherMockRecorder) Push(ctx interface{}, notifications ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{ctx}, notifications...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Push", reflect.TypeOf((*Mockpusher)(nil).Push), varargs...)
+}
+
+// MockunitOfWork is a mock of unitOfWork interface.
+type MockunitOfWork struct {
+	ctrl     *gomock.Controller
+	recorder *MockunitOfWorkMockRecorder
+}
+
+// MockunitOfWorkMockRecorder is the mock recorder for MockunitOfWork.
+type MockunitOfWorkMockRecorder struct {
+	mock *MockunitOfWork
+}
+
+// NewMockunitOfWork creates a new mock instance.
+func NewMockunitOfWork(ctrl *gomock.Controller) *MockunitOfWork {
+	mock := &MockunitOfWork{ctrl: ctrl}
+	mock.recorder = &MockunitOfWorkMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockunitOfWork) EXPECT() *MockunitOfWorkMockRecorder {
+	return m.recorder
+}
+
+// Do mocks base method.
+func (m *MockunitOfWork) Do(ctx context.Context, fn func(context.Context) error) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Do", ctx, fn)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Do indicates an expected call of Do.
+func (mr *MockunitOfWorkMockRecorder) Do(ctx, fn interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Do", reflect.TypeOf((*MockunitOfWork)(nil).Do), ctx, fn)
+}
@@ -0,0 +1,272 @@
+package message
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"go.uber.org/zap"
+
+	"github.com/colmmurphy91/muzz/internal/entity"
+)
+
+//go:generate mockgen -source $GOFILE -destination mocks/mocks_${GOFILE} -package mocks
+
+type messageStore interface {
+	StartConversation(ctx context.Context, matchID int) error
+	CreateMessage(ctx context.Context, message entity.Message) (entity.Message, error)
+	GetMessages(ctx context.Context, matchID, beforeID, limit int) ([]entity.Message, error)
+	LastMessageID(ctx context.Context, matchID int) (int, error)
+	GetReads(ctx context.Context, matchID int) ([]entity.MessageRead, error)
+	MarkRead(ctx context.Context, read entity.MessageRead) error
+	CountUnread(ctx context.Context, matchID, userID, afterID int) (int, error)
+	GetUnreadCounts(ctx context.Context, userID int) ([]entity.UnreadCount, error)
+}
+
+type matchFinder interface {
+	FindMatch(ctx context.Context, id int) (entity.Match, error)
+}
+
+// blockChecker reports whether either of a pair has blocked the other.
+type blockChecker interface {
+	IsBlocked(ctx context.Context, userID, otherID int) (bool, error)
+}
+
+// pusher sends notifications to the devices users have connected.
+type pusher interface {
+	Push(ctx context.Context, notifications ...entity.Notification) error
+}
+
+type unitOfWork interface {
+	Do(ctx context.Context, fn func(ctx context.Context) error) error
+}
+
+type Service struct {
+	logger       *zap.SugaredLogger
+	messageStore messageStore
+	matchFinder  matchFinder
+	blockChecker blockChecker
+	pusher       pusher
+	unitOfWork   unitOfWork
+	now          func() time.Time
+}
+
+func NewService(
+	logger *zap.SugaredLogger,
+	messages messageStore,
+	matches matchFinder,
+	blocks blockChecker,
+	pusher pusher,
+	unitOfWork unitOfWork,
+) *Service {
+	return &Service{
+		logger:       logger,
+		messageStore: messages,
+		matchFinder:  matches,
+		blockChecker: blocks,
+		pusher:       pusher,
+		unitOfWork:   unitOfWork,
+		now:          time.Now,
+	}
+}
+
+// Send adds a message from the user to the conversation of their match and
+// pushes it to the other person's devices. Only the pair can send, and only
+// until one of them unmatches, after which sending fails with
+// entity.ErrMatchEnded, or blocks the other, after which it fails with
+// entity.ErrBlocked.
+func (s *Service) Send(ctx context.Context, userID, matchID int, body string) (entity.Message, error) {
+	message := entity.Message{
+		MatchID:   matchID,
+		SenderID:  userID,
+		Body:      body,
+		CreatedAt: s.now().UTC().Truncate(time.Second),
+	}
+
+	if err := message.Validate(); err != nil {
+		return entity.Message{}, err
+	}
+
+	var match entity.Match
+
+	// The block check locks the pair's blocks, so a block made meanwhile waits
+	// for the message rather than missing it.
+	err := s.unitOfWork.Do(ctx, func(ctx context.Context) error {
+		var err error
+
+		match, err = s.conversation(ctx, userID, matchID)
+		if err != nil {
+			return err
+		}
+
+		if err := s.messageStore.StartConversation(ctx, matchID); err != nil {
+			return fmt.Errorf("failed to start conversation: %w", err)
+		}
+
+		message, err = s.messageStore.CreateMessage(ctx, message)
+		if err != nil {
+			return fmt.Errorf("failed to save message: %w", err)
+		}
+
+		return nil
+	})
+	if err != nil {
+		return entity.Message{}, err
+	}
+
+	// The message is saved, so a device that misses the push sees it in the
+	// unread counts instead.
+	err = s.pusher.Push(ctx, entity.Notification{
+		UserID:    match.Other(userID),
+		Type:      entity.NotificationMessageReceived,
+		ActorID:   userID,
+		MatchID:   matchID,
+		CreatedAt: message.CreatedAt,
+	})
+	if err != nil {
+		s.logger.Errorw("failed to push message", "match_id", matchID, "error", err)
+	}
+
+	return message, nil
+}
+
+// Messages returns a page of the conversation of the user's match, newest
+// first, with how many messages they have not read and how far the other
+// person has read.
+func (s *Service) Messages(ctx context.Context, userID, matchID, limit int, after *entity.MessagesCursor) (entity.MessagesPage, error) {
+	if limit <= 0 {
+		limit = entity.DefaultMessagesLimit
+	}
+
+	beforeID := 0
+	if after != nil {
+		beforeID = after.ID
+	}
+
+	if _, err := s.conversation(ctx, userID, matchID); err != nil {
+		return entity.MessagesPage{}, err
+	}
+
+	// Ask for one extra to know whether there is another page.
+	messages, err := s.messageStore.GetMessages(ctx, matchID, beforeID, limit+1)
+	if err != nil {
+		return entity.MessagesPage{}, fmt.Errorf("failed to get messages: %w", err)
+	}
+
+	reads, err := s.messageStore.GetReads(ctx, matchID)
+	if err != nil {
+		return entity.MessagesPage{}, fmt.Errorf("failed to get reads: %w", err)
+	}
+
+	var page entity.MessagesPage
+
+	if len(messages) > limit {
+		messages = messages[:limit]
+		page.Next = &entity.MessagesCursor{ID: messages[limit-1].ID}
+	}
+
+	lastReadID := 0
+
+	for _, read := range reads {
+		if read.UserID == userID {
+			lastReadID = read.LastReadID
+		} else {
+			page.OtherLastReadID = read.LastReadID
+		}
+	}
+
+	for i, message := range messages {
+		if message.SenderID == userID {
+			messages[i].Read = message.ID <= page.OtherLastReadID
+		} else {
+			messages[i].Read = message.ID <= lastReadID
+		}
+	}
+
+	page.Messages = messages
+
+	page.Unread, err = s.messageStore.CountUnread(ctx, matchID, userID, lastReadID)
+	if err != nil {
+		return entity.MessagesPage{}, fmt.Errorf("failed to count unread messages: %w", err)
+	}
+
+	return page, nil
+}
+
+// MarkRead records that the user has read the conversation of their match up
+// to and including messageID, or all of it when messageID is 0. The other
+// person sees it as a read receipt on their messages.
+func (s *Service) MarkRead(ctx context.Context, userID, matchID, messageID int) error {
+	if messageID < 0 {
+		return fmt.Errorf("invalid message id: %w", entity.ErrInvalidParam)
+	}
+
+	if _, err := s.conversation(ctx, userID, matchID); err != nil {
+		return err
+	}
+
+	lastID, err := s.messageStore.LastMessageID(ctx, matchID)
+	if err != nil {
+		return fmt.Errorf("failed to get last message: %w", err)
+	}
+
+	if messageID == 0 || messageID > lastID {
+		messageID = lastID
+	}
+
+	if messageID == 0 {
+		return nil
+	}
+
+	err = s.messageStore.MarkRead(ctx, entity.MessageRead{
+		MatchID:    matchID,
+		UserID:     userID,
+		LastReadID: messageID,
+		ReadAt:     s.now().UTC().Truncate(time.Second),
+	})
+	if err != nil {
+		return fmt.Errorf("failed to mark read: %w", err)
+	}
+
+	return nil
+}
+
+// UnreadCounts returns how many unread messages the user has in each of their
+// active matches that has any.
+func (s *Service) UnreadCounts(ctx context.Context, userID int) ([]entity.UnreadCount, error) {
+	counts, err := s.messageStore.GetUnreadCounts(ctx, userID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get unread counts: %w", err)
+	}
+
+	return counts, nil
+}
+
+// conversation returns the match whose conversation the user wants, as long
+// as they are one of the pair, it has not ended and neither has blocked the
+// other.
+func (s *Service) conversation(ctx context.Context, userID, matchID int) (entity.Match, error) {
+	match, err := s.matchFinder.FindMatch(ctx, matchID)
+	if err != nil {
+		return entity.Match{}, fmt.Errorf("failed to find match: %w", err)
+	}
+
+	if !match.Includes(userID) {
+		return entity.Match{}, fmt.Errorf("not part of match: %w", entity.ErrForbidden)
+	}
+
+	if match.UnmatchedAt.Valid {
+		return entity.Match{}, entity.ErrMatchEnded
+	}
+
+	blocked, err := s.blockChecker.IsBlocked(ctx, userID, match.Other(userID))
+	if err != nil {
+		return entity.Match{}, fmt.Errorf("failed to check block: %w", err)
+	}
+
+	if blocked {
+		return entity.Match{}, entity.ErrBlocked
+	}
+
+	return match, nil
+}
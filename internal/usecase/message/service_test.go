@@ -0,0 +1,380 @@
+package message
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	null "github.com/guregu/null/v5"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/zap"
+
+	"github.com/colmmurphy91/muzz/internal/entity"
+	"github.com/colmmurphy91/muzz/internal/usecase/message/mocks"
+)
+
+func TestService_Send(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockMessages := mocks.NewMockmessageStore(ctrl)
+	mockMatches := mocks.NewMockmatchFinder(ctrl)
+	mockBlocks := mocks.NewMockblockChecker(ctrl)
+	mockPusher := mocks.NewMockpusher(ctrl)
+	mockUnitOfWork := mocks.NewMockunitOfWork(ctrl)
+	service := NewService(zap.NewNop().Sugar(), mockMessages, mockMatches, mockBlocks, mockPusher, mockUnitOfWork)
+	now := time.Date(2024, 7, 4, 10, 0, 0, 0, time.UTC)
+	service.now = func() time.Time {
+		return now
+	}
+
+	ctx := context.Background()
+	match := entity.Match{ID: 7, User1ID: 1, User2ID: 2}
+	unsaved := entity.Message{MatchID: 7, SenderID: 1, Body: "hi", CreatedAt: now}
+	saved := unsaved
+	saved.ID = 3
+
+	mockUnitOfWork.EXPECT().Do(ctx, gomock.Any()).DoAndReturn(
+		func(ctx context.Context, fn func(ctx context.Context) error) error {
+			return fn(ctx)
+		}).AnyTimes()
+
+	tests := []struct {
+		name          string
+		body          string
+		setupMocks    func()
+		expected      entity.Message
+		expectedError error
+	}{
+		{
+			name: "saves the message and pushes it to the other person",
+			body: "hi",
+			setupMocks: func() {
+				mockMatches.EXPECT().FindMatch(ctx, 7).Return(match, nil)
+				mockBlocks.EXPECT().IsBlocked(ctx, 1, 2).Return(false, nil)
+				mockMessages.EXPECT().StartConversation(ctx, 7).Return(nil)
+				mockMessages.EXPECT().CreateMessage(ctx, unsaved).Return(saved, nil)
+				mockPusher.EXPECT().Push(ctx, []entity.Notification{{
+					UserID:    2,
+					Type:      entity.NotificationMessageReceived,
+					ActorID:   1,
+					MatchID:   7,
+					CreatedAt: now,
+				}}).Return(errors.New("hub error"))
+			},
+			expected: saved,
+		},
+		{
+			name:          "empty message",
+			body:          "",
+			setupMocks:    func() {},
+			expectedError: errors.New("body: cannot be blank."),
+		},
+		{
+			name:          "message too long",
+			body:          strings.Repeat("a", entity.MaxMessageLength+1),
+			setupMocks:    func() {},
+			expectedError: errors.New("body: the length must be between 1 and 2000."),
+		},
+		{
+			name: "not part of the match",
+			body: "hi",
+			setupMocks: func() {
+				mockMatches.EXPECT().FindMatch(ctx, 7).Return(entity.Match{ID: 7, User1ID: 2, User2ID: 3}, nil)
+			},
+			expectedError: errors.New("not part of match: forbidden"),
+		},
+		{
+			name: "match has ended",
+			body: "hi",
+			setupMocks: func() {
+				mockMatches.EXPECT().FindMatch(ctx, 7).Return(entity.Match{
+					ID:          7,
+					User1ID:     1,
+					User2ID:     2,
+					UnmatchedAt: null.TimeFrom(now),
+				}, nil)
+			},
+			expectedError: entity.ErrMatchEnded,
+		},
+		{
+			name: "blocked",
+			body: "hi",
+			setupMocks: func() {
+				mockMatches.EXPECT().FindMatch(ctx, 7).Return(match, nil)
+				mockBlocks.EXPECT().IsBlocked(ctx, 1, 2).Return(true, nil)
+			},
+			expectedError: entity.ErrBlocked,
+		},
+		{
+			name: "match ends while sending",
+			body: "hi",
+			setupMocks: func() {
+				mockMatches.EXPECT().FindMatch(ctx, 7).Return(match, nil)
+				mockBlocks.EXPECT().IsBlocked(ctx, 1, 2).Return(false, nil)
+				mockMessages.EXPECT().StartConversation(ctx, 7).Return(nil)
+				mockMessages.EXPECT().CreateMessage(ctx, unsaved).Return(entity.Message{}, entity.ErrMatchEnded)
+			},
+			expectedError: errors.New("failed to save message: match has ended"),
+		},
+		{
+			name: "match not found",
+			body: "hi",
+			setupMocks: func() {
+				mockMatches.EXPECT().FindMatch(ctx, 7).Return(entity.Match{}, entity.ErrMatchNotFound)
+			},
+			expectedError: errors.New("failed to find match: match does not exist"),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.setupMocks()
+
+			message, err := service.Send(ctx, 1, 7, tt.body)
+
+			if tt.expectedError != nil {
+				assert.EqualError(t, err, tt.expectedError.Error())
+				return
+			}
+
+			assert.NoError(t, err)
+			assert.Equal(t, tt.expected, message)
+		})
+	}
+}
+
+func TestService_Messages(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockMessages := mocks.NewMockmessageStore(ctrl)
+	mockMatches := mocks.NewMockmatchFinder(ctrl)
+	mockBlocks := mocks.NewMockblockChecker(ctrl)
+	service := NewService(
+		zap.NewNop().Sugar(), mockMessages, mockMatches, mockBlocks, mocks.NewMockpusher(ctrl), mocks.NewMockunitOfWork(ctrl),
+	)
+
+	ctx := context.Background()
+	match := entity.Match{ID: 7, User1ID: 1, User2ID: 2}
+
+	tests := []struct {
+		name          string
+		limit         int
+		after         *entity.MessagesCursor
+		setupMocks    func()
+		expected      entity.MessagesPage
+		expectedError error
+	}{
+		{
+			name:  "marks what each person has read",
+			limit: 2,
+			after: &entity.MessagesCursor{ID: 9},
+			setupMocks: func() {
+				mockMatches.EXPECT().FindMatch(ctx, 7).Return(match, nil)
+				mockBlocks.EXPECT().IsBlocked(ctx, 1, 2).Return(false, nil)
+				mockMessages.EXPECT().GetMessages(ctx, 7, 9, 3).Return([]entity.Message{
+					{ID: 6, SenderID: 2},
+					{ID: 5, SenderID: 1},
+					{ID: 4, SenderID: 2},
+				}, nil)
+				mockMessages.EXPECT().GetReads(ctx, 7).Return([]entity.MessageRead{
+					{MatchID: 7, UserID: 1, LastReadID: 4},
+					{MatchID: 7, UserID: 2, LastReadID: 5},
+				}, nil)
+				mockMessages.EXPECT().CountUnread(ctx, 7, 1, 4).Return(3, nil)
+			},
+			expected: entity.MessagesPage{
+				Messages: []entity.Message{
+					{ID: 6, SenderID: 2, Read: false},
+					{ID: 5, SenderID: 1, Read: true},
+				},
+				Next:            &entity.MessagesCursor{ID: 5},
+				Unread:          3,
+				OtherLastReadID: 5,
+			},
+		},
+		{
+			name: "nothing read yet",
+			setupMocks: func() {
+				mockMatches.EXPECT().FindMatch(ctx, 7).Return(match, nil)
+				mockBlocks.EXPECT().IsBlocked(ctx, 1, 2).Return(false, nil)
+				mockMessages.EXPECT().GetMessages(ctx, 7, 0, entity.DefaultMessagesLimit+1).Return([]entity.Message{
+					{ID: 1, SenderID: 2},
+				}, nil)
+				mockMessages.EXPECT().GetReads(ctx, 7).Return([]entity.MessageRead{}, nil)
+				mockMessages.EXPECT().CountUnread(ctx, 7, 1, 0).Return(1, nil)
+			},
+			expected: entity.MessagesPage{
+				Messages: []entity.Message{{ID: 1, SenderID: 2}},
+				Unread:   1,
+			},
+		},
+		{
+			name: "not part of the match",
+			setupMocks: func() {
+				mockMatches.EXPECT().FindMatch(ctx, 7).Return(entity.Match{ID: 7, User1ID: 2, User2ID: 3}, nil)
+			},
+			expectedError: errors.New("not part of match: forbidden"),
+		},
+		{
+			name: "block check failure",
+			setupMocks: func() {
+				mockMatches.EXPECT().FindMatch(ctx, 7).Return(match, nil)
+				mockBlocks.EXPECT().IsBlocked(ctx, 1, 2).Return(false, errors.New("db error"))
+			},
+			expectedError: errors.New("failed to check block: db error"),
+		},
+		{
+			name: "store failure",
+			setupMocks: func() {
+				mockMatches.EXPECT().FindMatch(ctx, 7).Return(match, nil)
+				mockBlocks.EXPECT().IsBlocked(ctx, 1, 2).Return(false, nil)
+				mockMessages.EXPECT().GetMessages(ctx, 7, 0, entity.DefaultMessagesLimit+1).Return(nil, errors.New("db error"))
+			},
+			expectedError: errors.New("failed to get messages: db error"),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.setupMocks()
+
+			page, err := service.Messages(ctx, 1, 7, tt.limit, tt.after)
+
+			if tt.expectedError != nil {
+				assert.EqualError(t, err, tt.expectedError.Error())
+				return
+			}
+
+			assert.NoError(t, err)
+			assert.Equal(t, tt.expected, page)
+		})
+	}
+}
+
+func TestService_MarkRead(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockMessages := mocks.NewMockmessageStore(ctrl)
+	mockMatches := mocks.NewMockmatchFinder(ctrl)
+	mockBlocks := mocks.NewMockblockChecker(ctrl)
+	service := NewService(
+		zap.NewNop().Sugar(), mockMessages, mockMatches, mockBlocks, mocks.NewMockpusher(ctrl), mocks.NewMockunitOfWork(ctrl),
+	)
+	now := time.Date(2024, 7, 4, 10, 0, 0, 0, time.UTC)
+	service.now = func() time.Time {
+		return now
+	}
+
+	ctx := context.Background()
+	match := entity.Match{ID: 7, User1ID: 1, User2ID: 2}
+
+	tests := []struct {
+		name          string
+		messageID     int
+		setupMocks    func()
+		expectedError error
+	}{
+		{
+			name:      "marks read up to the message",
+			messageID: 4,
+			setupMocks: func() {
+				mockMatches.EXPECT().FindMatch(ctx, 7).Return(match, nil)
+				mockBlocks.EXPECT().IsBlocked(ctx, 1, 2).Return(false, nil)
+				mockMessages.EXPECT().LastMessageID(ctx, 7).Return(6, nil)
+				mockMessages.EXPECT().MarkRead(ctx, entity.MessageRead{MatchID: 7, UserID: 1, LastReadID: 4, ReadAt: now}).Return(nil)
+			},
+		},
+		{
+			name: "marks everything read",
+			setupMocks: func() {
+				mockMatches.EXPECT().FindMatch(ctx, 7).Return(match, nil)
+				mockBlocks.EXPECT().IsBlocked(ctx, 1, 2).Return(false, nil)
+				mockMessages.EXPECT().LastMessageID(ctx, 7).Return(6, nil)
+				mockMessages.EXPECT().MarkRead(ctx, entity.MessageRead{MatchID: 7, UserID: 1, LastReadID: 6, ReadAt: now}).Return(nil)
+			},
+		},
+		{
+			name:      "cannot read past the last message",
+			messageID: 100,
+			setupMocks: func() {
+				mockMatches.EXPECT().FindMatch(ctx, 7).Return(match, nil)
+				mockBlocks.EXPECT().IsBlocked(ctx, 1, 2).Return(false, nil)
+				mockMessages.EXPECT().LastMessageID(ctx, 7).Return(6, nil)
+				mockMessages.EXPECT().MarkRead(ctx, entity.MessageRead{MatchID: 7, UserID: 1, LastReadID: 6, ReadAt: now}).Return(nil)
+			},
+		},
+		{
+			name: "no messages yet",
+			setupMocks: func() {
+				mockMatches.EXPECT().FindMatch(ctx, 7).Return(match, nil)
+				mockBlocks.EXPECT().IsBlocked(ctx, 1, 2).Return(false, nil)
+				mockMessages.EXPECT().LastMessageID(ctx, 7).Return(0, nil)
+			},
+		},
+		{
+			name:          "invalid message id",
+			messageID:     -1,
+			setupMocks:    func() {},
+			expectedError: errors.New("invalid message id: invalid param"),
+		},
+		{
+			name: "match has ended",
+			setupMocks: func() {
+				mockMatches.EXPECT().FindMatch(ctx, 7).Return(entity.Match{
+					ID:          7,
+					User1ID:     1,
+					User2ID:     2,
+					UnmatchedAt: null.TimeFrom(now),
+				}, nil)
+			},
+			expectedError: entity.ErrMatchEnded,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.setupMocks()
+
+			err := service.MarkRead(ctx, 1, 7, tt.messageID)
+
+			if tt.expectedError != nil {
+				assert.EqualError(t, err, tt.expectedError.Error())
+				return
+			}
+
+			assert.NoError(t, err)
+		})
+	}
+}
+
+func TestService_UnreadCounts(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockMessages := mocks.NewMockmessageStore(ctrl)
+	service := NewService(
+		zap.NewNop().Sugar(), mockMessages, mocks.NewMockmatchFinder(ctrl), mocks.NewMockblockChecker(ctrl),
+		mocks.NewMockpusher(ctrl), mocks.NewMockunitOfWork(ctrl),
+	)
+
+	ctx := context.Background()
+
+	mockMessages.EXPECT().GetUnreadCounts(ctx, 1).Return([]entity.UnreadCount{{MatchID: 7, Unread: 2}}, nil)
+
+	counts, err := service.UnreadCounts(ctx, 1)
+
+	assert.NoError(t, err)
+	assert.Equal(t, []entity.UnreadCount{{MatchID: 7, Unread: 2}}, counts)
+
+	mockMessages.EXPECT().GetUnreadCounts(ctx, 1).Return(nil, errors.New("db error"))
+
+	_, err = service.UnreadCounts(ctx, 1)
+
+	assert.EqualError(t, err, "failed to get unread counts: db error")
+}
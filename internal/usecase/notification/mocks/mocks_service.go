@@ -0,0 +1,118 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: service.go
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	context "context"
+	reflect "reflect"
+
+	entity "github.com/colmmurphy91/muzz/internal/entity"
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MocknotificationStore is a mock of notificationStore interface.
+type MocknotificationStore struct {
+	ctrl     *gomock.Controller
+	recorder *MocknotificationStoreMockRecorder
+}
+
+// MocknotificationStoreMockRecorder is the mock recorder for MocknotificationStore.
+type MocknotificationStoreMockRecorder struct {
+	mock *MocknotificationStore
+}
+
+// NewMocknotificationStore creates a new mock instance.
+func NewMocknotificationStore(ctrl *gomock.Controller) *MocknotificationStore {
+	mock := &MocknotificationStore{ctrl: ctrl}
+	mock.recorder = &MocknotificationStoreMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MocknotificationStore) EXPECT() *MocknotificationStoreMockRecorder {
+	return m.recorder
+}
+
+// CreateNotification mocks base method.
+func (m *MocknotificationStore) CreateNotification(ctx context.Context, notification entity.Notification) (entity.Notification, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateNotification", ctx, notification)
+	ret0, _ := ret[0].(entity.Notification)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateNotification indicates an expected call of CreateNotification.
+func (mr *MocknotificationStoreMockRecorder) CreateNotification(ctx, notification interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateNotification", reflect.TypeOf((*MocknotificationStore)(nil).CreateNotification), ctx, notification)
+}
+
+// GetNotifications mocks base method.
+func (m *MocknotificationStore) GetNotifications(ctx context.Context, userID, beforeID, limit int) ([]entity.Notification, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetNotifications", ctx, userID, beforeID, limit)
+	ret0, _ := ret[0].([]entity.Notification)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetNotifications indicates an expected call of GetNotifications.
+func (mr *MocknotificationStoreMockRecorder) GetNotifications(ctx, userID, beforeID, limit interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetNotifications", reflect.TypeOf((*MocknotificationStore)(nil).GetNotifications), ctx, userID, beforeID, limit)
+}
+
+// Mockhub is a mock of hub interface.
+type Mockhub struct {
+	ctrl     *gomock.Controller
+	recorder *MockhubMockRecorder
+}
+
+// MockhubMockRecorder is the mock recorder for Mockhub.
+type MockhubMockRecorder struct {
+	mock *Mockhub
+}
+
+// NewMockhub creates a new mock instance.
+func NewMockhub(ctrl *gomock.Controller) *Mockhub {
+	mock := &Mockhub{ctrl: ctrl}
+	mock.recorder = &MockhubMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *Mockhub) EXPECT() *MockhubMockRecorder {
+	return m.recorder
+}
+
+// Publish mocks base method.
+func (m *Mockhub) Publish(ctx context.Context, notification entity.Notification) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Publish", ctx, notification)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Publish indicates an expected call of Publish.
+func (mr *MockhubMockRecorder) Publish(ctx, notification interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Publish", reflect.TypeOf((*Mockhub)(nil).Publish), ctx, notification)
+}
+
+// Subscribe mocks base method.
+func (m *Mockhub) Subscribe(userID int) (<-chan entity.Notification, func()) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Subscribe", userID)
+	ret0, _ := ret[0].(<-chan entity.Notification)
+	ret1, _ := ret[1].(func())
+	return ret0, ret1
+}
+
+// Subscribe indicates an expected call of Subscribe.
+func (mr *MockhubMockRecorder) Subscribe(userID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Subscribe", reflect.TypeOf((*Mockhub)(nil).Subscribe), userID)
+}
@@ -0,0 +1,102 @@
+package notification
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/colmmurphy91/muzz/internal/entity"
+)
+
+//go:generate mockgen -source $GOFILE -destination mocks/mocks_${GOFILE} -package mocks
+
+type notificationStore interface {
+	CreateNotification(ctx context.Context, notification entity.Notification) (entity.Notification, error)
+	GetNotifications(ctx context.Context, userID, beforeID, limit int) ([]entity.Notification, error)
+}
+
+// hub delivers notifications to every device a user has connected. The
+// in-memory hub only reaches devices connected to the same instance; a hub
+// fanning out between instances can take its place.
+type hub interface {
+	Publish(ctx context.Context, notification entity.Notification) error
+	Subscribe(userID int) (<-chan entity.Notification, func())
+}
+
+type Service struct {
+	notificationStore notificationStore
+	hub               hub
+	now               func() time.Time
+}
+
+func NewService(notifications notificationStore, hub hub) *Service {
+	return &Service{
+		notificationStore: notifications,
+		hub:               hub,
+		now:               time.Now,
+	}
+}
+
+// Notify saves a notification to the user's inbox. Called in a unit of work,
+// it is only kept if the rest of the work is, so it is only pushed to the
+// user's devices, with Push, once the work is committed.
+func (s *Service) Notify(ctx context.Context, notification entity.Notification) (entity.Notification, error) {
+	notification.CreatedAt = s.now().UTC().Truncate(time.Second)
+
+	saved, err := s.notificationStore.CreateNotification(ctx, notification)
+	if err != nil {
+		return entity.Notification{}, fmt.Errorf("failed to save notification: %w", err)
+	}
+
+	return saved, nil
+}
+
+// Push sends saved notifications to their users' connected devices. Devices
+// that are not connected catch up from the inbox.
+func (s *Service) Push(ctx context.Context, notifications ...entity.Notification) error {
+	var errs []error
+
+	for _, notification := range notifications {
+		if err := s.hub.Publish(ctx, notification); err != nil {
+			errs = append(errs, fmt.Errorf("failed to push notification: %w", err))
+		}
+	}
+
+	return errors.Join(errs...)
+}
+
+// Subscribe streams the user's notifications to one of their devices until
+// the returned func is called.
+func (s *Service) Subscribe(userID int) (<-chan entity.Notification, func()) {
+	return s.hub.Subscribe(userID)
+}
+
+// Notifications returns a page of the user's notifications, newest first.
+func (s *Service) Notifications(ctx context.Context, userID, limit int, after *entity.NotificationsCursor) (entity.NotificationsPage, error) {
+	if limit <= 0 {
+		limit = entity.DefaultNotificationsLimit
+	}
+
+	beforeID := 0
+	if after != nil {
+		beforeID = after.ID
+	}
+
+	// Ask for one extra to know whether there is another page.
+	notifications, err := s.notificationStore.GetNotifications(ctx, userID, beforeID, limit+1)
+	if err != nil {
+		return entity.NotificationsPage{}, fmt.Errorf("failed to get notifications: %w", err)
+	}
+
+	var page entity.NotificationsPage
+
+	if len(notifications) > limit {
+		notifications = notifications[:limit]
+		page.Next = &entity.NotificationsCursor{ID: notifications[limit-1].ID}
+	}
+
+	page.Notifications = notifications
+
+	return page, nil
+}
@@ -0,0 +1,165 @@
+package notification
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/colmmurphy91/muzz/internal/entity"
+	"github.com/colmmurphy91/muzz/internal/usecase/notification/mocks"
+)
+
+func TestService_Notify(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockStore := mocks.NewMocknotificationStore(ctrl)
+	service := NewService(mockStore, mocks.NewMockhub(ctrl))
+	now := time.Date(2024, 7, 3, 9, 30, 0, 500, time.UTC)
+	service.now = func() time.Time {
+		return now
+	}
+
+	ctx := context.Background()
+	notification := entity.Notification{UserID: 2, Type: entity.NotificationSuperLike, ActorID: 1}
+	stamped := notification
+	stamped.CreatedAt = now.Truncate(time.Second)
+
+	tests := []struct {
+		name          string
+		setupMocks    func()
+		expected      entity.Notification
+		expectedError error
+	}{
+		{
+			name: "saves the notification",
+			setupMocks: func() {
+				saved := stamped
+				saved.ID = 5
+
+				mockStore.EXPECT().CreateNotification(ctx, stamped).Return(saved, nil)
+			},
+			expected: entity.Notification{
+				ID:        5,
+				UserID:    2,
+				Type:      entity.NotificationSuperLike,
+				ActorID:   1,
+				CreatedAt: now.Truncate(time.Second),
+			},
+		},
+		{
+			name: "store failure",
+			setupMocks: func() {
+				mockStore.EXPECT().CreateNotification(ctx, stamped).Return(entity.Notification{}, errors.New("db error"))
+			},
+			expectedError: errors.New("failed to save notification: db error"),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.setupMocks()
+
+			saved, err := service.Notify(ctx, notification)
+
+			if tt.expectedError != nil {
+				assert.EqualError(t, err, tt.expectedError.Error())
+				return
+			}
+
+			assert.NoError(t, err)
+			assert.Equal(t, tt.expected, saved)
+		})
+	}
+}
+
+func TestService_Push(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockHub := mocks.NewMockhub(ctrl)
+	service := NewService(mocks.NewMocknotificationStore(ctrl), mockHub)
+
+	ctx := context.Background()
+	first := entity.Notification{ID: 1, UserID: 1, Type: entity.NotificationMatchCreated, ActorID: 2, MatchID: 7}
+	second := entity.Notification{ID: 2, UserID: 2, Type: entity.NotificationMatchCreated, ActorID: 1, MatchID: 7}
+
+	// A failure for one user does not stop the others being pushed.
+	mockHub.EXPECT().Publish(ctx, first).Return(errors.New("hub error"))
+	mockHub.EXPECT().Publish(ctx, second).Return(nil)
+
+	err := service.Push(ctx, first, second)
+
+	assert.EqualError(t, err, "failed to push notification: hub error")
+}
+
+func TestService_Notifications(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockStore := mocks.NewMocknotificationStore(ctrl)
+	service := NewService(mockStore, mocks.NewMockhub(ctrl))
+
+	ctx := context.Background()
+	createdAt := time.Date(2024, 6, 29, 12, 0, 0, 0, time.UTC)
+	newer := entity.Notification{ID: 9, UserID: 1, Type: entity.NotificationSuperLike, ActorID: 3, CreatedAt: createdAt}
+	older := entity.Notification{ID: 7, UserID: 1, Type: entity.NotificationSuperLike, ActorID: 2, CreatedAt: createdAt.Add(-time.Hour)}
+
+	tests := []struct {
+		name          string
+		limit         int
+		after         *entity.NotificationsCursor
+		setupMocks    func()
+		expected      entity.NotificationsPage
+		expectedError error
+	}{
+		{
+			name:  "uses the default limit",
+			limit: 0,
+			setupMocks: func() {
+				mockStore.EXPECT().GetNotifications(ctx, 1, 0, entity.DefaultNotificationsLimit+1).Return([]entity.Notification{newer, older}, nil)
+			},
+			expected: entity.NotificationsPage{Notifications: []entity.Notification{newer, older}},
+		},
+		{
+			name:  "returns a cursor when there are more notifications",
+			limit: 1,
+			after: &entity.NotificationsCursor{ID: 12},
+			setupMocks: func() {
+				mockStore.EXPECT().GetNotifications(ctx, 1, 12, 2).Return([]entity.Notification{newer, older}, nil)
+			},
+			expected: entity.NotificationsPage{
+				Notifications: []entity.Notification{newer},
+				Next:          &entity.NotificationsCursor{ID: 9},
+			},
+		},
+		{
+			name:  "store failure",
+			limit: 10,
+			setupMocks: func() {
+				mockStore.EXPECT().GetNotifications(ctx, 1, 0, 11).Return(nil, errors.New("db error"))
+			},
+			expectedError: errors.New("failed to get notifications: db error"),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.setupMocks()
+
+			page, err := service.Notifications(ctx, 1, tt.limit, tt.after)
+
+			if tt.expectedError != nil {
+				assert.EqualError(t, err, tt.expectedError.Error())
+				return
+			}
+
+			assert.NoError(t, err)
+			assert.Equal(t, tt.expected, page)
+		})
+	}
+}
@@ -0,0 +1,116 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: service.go
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	context "context"
+	reflect "reflect"
+
+	entity "github.com/colmmurphy91/muzz/internal/entity"
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockpreferenceStore is a mock of preferenceStore interface.
+type MockpreferenceStore struct {
+	ctrl     *gomock.Controller
+	recorder *MockpreferenceStoreMockRecorder
+}
+
+// MockpreferenceStoreMockRecorder is the mock recorder for MockpreferenceStore.
+type MockpreferenceStoreMockRecorder struct {
+	mock *MockpreferenceStore
+}
+
+// NewMockpreferenceStore creates a new mock instance.
+func NewMockpreferenceStore(ctrl *gomock.Controller) *MockpreferenceStore {
+	mock := &MockpreferenceStore{ctrl: ctrl}
+	mock.recorder = &MockpreferenceStoreMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockpreferenceStore) EXPECT() *MockpreferenceStoreMockRecorder {
+	return m.recorder
+}
+
+// DeletePreferences mocks base method.
+func (m *MockpreferenceStore) DeletePreferences(ctx context.Context, userID int) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeletePreferences", ctx, userID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeletePreferences indicates an expected call of DeletePreferences.
+func (mr *MockpreferenceStoreMockRecorder) DeletePreferences(ctx, userID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeletePreferences", reflect.TypeOf((*MockpreferenceStore)(nil).DeletePreferences), ctx, userID)
+}
+
+// GetPreferences mocks base method.
+func (m *MockpreferenceStore) GetPreferences(ctx context.Context, userID int) (entity.Preferences, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetPreferences", ctx, userID)
+	ret0, _ := ret[0].(entity.Preferences)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetPreferences indicates an expected call of GetPreferences.
+func (mr *MockpreferenceStoreMockRecorder) GetPreferences(ctx, userID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPreferences", reflect.TypeOf((*MockpreferenceStore)(nil).GetPreferences), ctx, userID)
+}
+
+// SavePreferences mocks base method.
+func (m *MockpreferenceStore) SavePreferences(ctx context.Context, prefs entity.Preferences) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SavePreferences", ctx, prefs)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SavePreferences indicates an expected call of SavePreferences.
+func (mr *MockpreferenceStoreMockRecorder) SavePreferences(ctx, prefs interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SavePreferences", reflect.TypeOf((*MockpreferenceStore)(nil).SavePreferences), ctx, prefs)
+}
+
+// MockpreferenceIndexer is a mock of preferenceIndexer interface.
+type MockpreferenceIndexer struct {
+	ctrl     *gomock.Controller
+	recorder *MockpreferenceIndexerMockRecorder
+}
+
+// MockpreferenceIndexerMockRecorder is the mock recorder for MockpreferenceIndexer.
+type MockpreferenceIndexerMockRecorder struct {
+	mock *MockpreferenceIndexer
+}
+
+// NewMockpreferenceIndexer creates a new mock instance.
+func NewMockpreferenceIndexer(ctrl *gomock.Controller) *MockpreferenceIndexer {
+	mock := &MockpreferenceIndexer{ctrl: ctrl}
+	mock.recorder = &MockpreferenceIndexerMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockpreferenceIndexer) EXPECT() *MockpreferenceIndexerMockRecorder {
+	return m.recorder
+}
+
+// UpdatePreferences mocks base method.
+func (m *MockpreferenceIndexer) UpdatePreferences(ctx context.Context, prefs entity.Preferences) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdatePreferences", ctx, prefs)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdatePreferences indicates an expected call of UpdatePreferences.
+func (mr *MockpreferenceIndexerMockRecorder) UpdatePreferences(ctx, prefs interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdatePreferences", reflect.TypeOf((*MockpreferenceIndexer)(nil).UpdatePreferences), ctx, prefs)
+}
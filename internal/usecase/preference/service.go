@@ -0,0 +1,80 @@
+package preference
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/colmmurphy91/muzz/internal/entity"
+)
+
+//go:generate mockgen -source $GOFILE -destination mocks/mocks_${GOFILE} -package mocks
+
+type preferenceStore interface {
+	GetPreferences(ctx context.Context, userID int) (entity.Preferences, error)
+	SavePreferences(ctx context.Context, prefs entity.Preferences) error
+	DeletePreferences(ctx context.Context, userID int) error
+}
+
+type preferenceIndexer interface {
+	UpdatePreferences(ctx context.Context, prefs entity.Preferences) error
+}
+
+type Service struct {
+	store   preferenceStore
+	indexer preferenceIndexer
+}
+
+func NewService(store preferenceStore, indexer preferenceIndexer) *Service {
+	return &Service{
+		store:   store,
+		indexer: indexer,
+	}
+}
+
+// GetPreferences returns the user's saved preferences, or the defaults when none are saved.
+func (s *Service) GetPreferences(ctx context.Context, userID int) (entity.Preferences, error) {
+	prefs, err := s.store.GetPreferences(ctx, userID)
+	if err != nil {
+		if errors.Is(err, entity.ErrPreferencesNotFound) {
+			return entity.DefaultPreferences(userID), nil
+		}
+
+		return entity.Preferences{}, fmt.Errorf("failed to get preferences: %w", err)
+	}
+
+	return prefs, nil
+}
+
+func (s *Service) SavePreferences(ctx context.Context, prefs entity.Preferences) (entity.Preferences, error) {
+	if err := prefs.Validate(); err != nil {
+		return entity.Preferences{}, fmt.Errorf("invalid preferences: %w", err)
+	}
+
+	if prefs.Genders == nil {
+		prefs.Genders = []string{}
+	}
+
+	if err := s.store.SavePreferences(ctx, prefs); err != nil {
+		return entity.Preferences{}, fmt.Errorf("failed to save preferences: %w", err)
+	}
+
+	if err := s.indexer.UpdatePreferences(ctx, prefs); err != nil {
+		return entity.Preferences{}, fmt.Errorf("failed to index preferences: %w", err)
+	}
+
+	return prefs, nil
+}
+
+// DeletePreferences resets the user to the default preferences.
+func (s *Service) DeletePreferences(ctx context.Context, userID int) error {
+	if err := s.store.DeletePreferences(ctx, userID); err != nil {
+		return fmt.Errorf("failed to delete preferences: %w", err)
+	}
+
+	if err := s.indexer.UpdatePreferences(ctx, entity.DefaultPreferences(userID)); err != nil {
+		return fmt.Errorf("failed to index preferences: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,154 @@
+package preference
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	validation "github.com/go-ozzo/ozzo-validation/v4"
+	"github.com/golang/mock/gomock"
+	null "github.com/guregu/null/v5"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/colmmurphy91/muzz/internal/entity"
+	"github.com/colmmurphy91/muzz/internal/usecase/preference/mocks"
+)
+
+func TestService_GetPreferences(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockStore := mocks.NewMockpreferenceStore(ctrl)
+	service := NewService(mockStore, mocks.NewMockpreferenceIndexer(ctrl))
+
+	saved := entity.Preferences{UserID: 1, MinAge: null.IntFrom(25), Genders: []string{"female"}, ShowMe: true}
+
+	tests := []struct {
+		name          string
+		setupMocks    func()
+		expectedPrefs entity.Preferences
+		expectedError error
+	}{
+		{
+			name: "saved preferences",
+			setupMocks: func() {
+				mockStore.EXPECT().GetPreferences(gomock.Any(), 1).Return(saved, nil)
+			},
+			expectedPrefs: saved,
+		},
+		{
+			name: "defaults when none saved",
+			setupMocks: func() {
+				mockStore.EXPECT().GetPreferences(gomock.Any(), 1).Return(entity.Preferences{}, entity.ErrPreferencesNotFound)
+			},
+			expectedPrefs: entity.DefaultPreferences(1),
+		},
+		{
+			name: "store failure",
+			setupMocks: func() {
+				mockStore.EXPECT().GetPreferences(gomock.Any(), 1).Return(entity.Preferences{}, errors.New("db error"))
+			},
+			expectedError: errors.New("failed to get preferences: db error"),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.setupMocks()
+
+			prefs, err := service.GetPreferences(context.Background(), 1)
+
+			if tt.expectedError != nil {
+				assert.EqualError(t, err, tt.expectedError.Error())
+			} else {
+				assert.NoError(t, err)
+			}
+
+			assert.Equal(t, tt.expectedPrefs, prefs)
+		})
+	}
+}
+
+func TestService_SavePreferences(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockStore := mocks.NewMockpreferenceStore(ctrl)
+	mockIndexer := mocks.NewMockpreferenceIndexer(ctrl)
+	service := NewService(mockStore, mockIndexer)
+
+	valid := entity.Preferences{
+		UserID:        1,
+		MinAge:        null.IntFrom(25),
+		MaxAge:        null.IntFrom(35),
+		Genders:       []string{"female"},
+		MaxDistanceKm: null.FloatFrom(50),
+		ShowMe:        true,
+	}
+
+	tests := []struct {
+		name             string
+		prefs            entity.Preferences
+		setupMocks       func()
+		expectValidation bool
+		expectedError    error
+	}{
+		{
+			name:  "saves and indexes",
+			prefs: valid,
+			setupMocks: func() {
+				mockStore.EXPECT().SavePreferences(gomock.Any(), valid).Return(nil)
+				mockIndexer.EXPECT().UpdatePreferences(gomock.Any(), valid).Return(nil)
+			},
+		},
+		{
+			name: "max age below min age",
+			prefs: entity.Preferences{
+				UserID: 1,
+				MinAge: null.IntFrom(30),
+				MaxAge: null.IntFrom(25),
+			},
+			setupMocks:       func() {},
+			expectValidation: true,
+		},
+		{
+			name:             "under 18",
+			prefs:            entity.Preferences{UserID: 1, MinAge: null.IntFrom(16)},
+			setupMocks:       func() {},
+			expectValidation: true,
+		},
+		{
+			name:             "unknown gender",
+			prefs:            entity.Preferences{UserID: 1, Genders: []string{"robot"}},
+			setupMocks:       func() {},
+			expectValidation: true,
+		},
+		{
+			name:  "index failure",
+			prefs: valid,
+			setupMocks: func() {
+				mockStore.EXPECT().SavePreferences(gomock.Any(), valid).Return(nil)
+				mockIndexer.EXPECT().UpdatePreferences(gomock.Any(), valid).Return(errors.New("es error"))
+			},
+			expectedError: errors.New("failed to index preferences: es error"),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.setupMocks()
+
+			_, err := service.SavePreferences(context.Background(), tt.prefs)
+
+			switch {
+			case tt.expectValidation:
+				var validationErrs validation.Errors
+				assert.ErrorAs(t, err, &validationErrs)
+			case tt.expectedError != nil:
+				assert.EqualError(t, err, tt.expectedError.Error())
+			default:
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
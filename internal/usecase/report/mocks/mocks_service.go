@@ -0,0 +1,171 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: service.go
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	context "context"
+	reflect "reflect"
+
+	model "github.com/colmmurphy91/muzz/internal/adapter/mysql/user/model"
+	entity "github.com/colmmurphy91/muzz/internal/entity"
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockreportStore is a mock of reportStore interface.
+type MockreportStore struct {
+	ctrl     *gomock.Controller
+	recorder *MockreportStoreMockRecorder
+}
+
+// MockreportStoreMockRecorder is the mock recorder for MockreportStore.
+type MockreportStoreMockRecorder struct {
+	mock *MockreportStore
+}
+
+// NewMockreportStore creates a new mock instance.
+func NewMockreportStore(ctrl *gomock.Controller) *MockreportStore {
+	mock := &MockreportStore{ctrl: ctrl}
+	mock.recorder = &MockreportStoreMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockreportStore) EXPECT() *MockreportStoreMockRecorder {
+	return m.recorder
+}
+
+// CreateReport mocks base method.
+func (m *MockreportStore) CreateReport(ctx context.Context, report entity.Report) (entity.Report, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateReport", ctx, report)
+	ret0, _ := ret[0].(entity.Report)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateReport indicates an expected call of CreateReport.
+func (mr *MockreportStoreMockRecorder) CreateReport(ctx, report interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateReport", reflect.TypeOf((*MockreportStore)(nil).CreateReport), ctx, report)
+}
+
+// FindReportForUpdate mocks base method.
+func (m *MockreportStore) FindReportForUpdate(ctx context.Context, id int) (entity.Report, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindReportForUpdate", ctx, id)
+	ret0, _ := ret[0].(entity.Report)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindReportForUpdate indicates an expected call of FindReportForUpdate.
+func (mr *MockreportStoreMockRecorder) FindReportForUpdate(ctx, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindReportForUpdate", reflect.TypeOf((*MockreportStore)(nil).FindReportForUpdate), ctx, id)
+}
+
+// GetReports mocks base method.
+func (m *MockreportStore) GetReports(ctx context.Context, status entity.ReportStatus, afterID, limit int) ([]entity.Report, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetReports", ctx, status, afterID, limit)
+	ret0, _ := ret[0].([]entity.Report)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetReports indicates an expected call of GetReports.
+func (mr *MockreportStoreMockRecorder) GetReports(ctx, status, afterID, limit interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetReports", reflect.TypeOf((*MockreportStore)(nil).GetReports), ctx, status, afterID, limit)
+}
+
+// UpdateReportStatus mocks base method.
+func (m *MockreportStore) UpdateReportStatus(ctx context.Context, report entity.Report) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateReportStatus", ctx, report)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateReportStatus indicates an expected call of UpdateReportStatus.
+func (mr *MockreportStoreMockRecorder) UpdateReportStatus(ctx, report interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateReportStatus", reflect.TypeOf((*MockreportStore)(nil).UpdateReportStatus), ctx, report)
+}
+
+// MockuserFetcher is a mock of userFetcher interface.
+type MockuserFetcher struct {
+	ctrl     *gomock.Controller
+	recorder *MockuserFetcherMockRecorder
+}
+
+// MockuserFetcherMockRecorder is the mock recorder for MockuserFetcher.
+type MockuserFetcherMockRecorder struct {
+	mock *MockuserFetcher
+}
+
+// NewMockuserFetcher creates a new mock instance.
+func NewMockuserFetcher(ctrl *gomock.Controller) *MockuserFetcher {
+	mock := &MockuserFetcher{ctrl: ctrl}
+	mock.recorder = &MockuserFetcherMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockuserFetcher) EXPECT() *MockuserFetcherMockRecorder {
+	return m.recorder
+}
+
+// FindByID mocks base method.
+func (m *MockuserFetcher) FindByID(ctx context.Context, userID int) (model.User, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindByID", ctx, userID)
+	ret0, _ := ret[0].(model.User)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindByID indicates an expected call of FindByID.
+func (mr *MockuserFetcherMockRecorder) FindByID(ctx, userID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindByID", reflect.TypeOf((*MockuserFetcher)(nil).FindByID), ctx, userID)
+}
+
+// MockunitOfWork is a mock of unitOfWork interface.
+type MockunitOfWork struct {
+	ctrl     *gomock.Controller
+	recorder *MockunitOfWorkMockRecorder
+}
+
+// MockunitOfWorkMockRecorder is the mock recorder for MockunitOfWork.
+type MockunitOfWorkMockRecorder struct {
+	mock *MockunitOfWork
+}
+
+// NewMockunitOfWork creates a new mock instance.
+func NewMockunitOfWork(ctrl *gomock.Controller) *MockunitOfWork {
+	mock := &MockunitOfWork{ctrl: ctrl}
+	mock.recorder = &MockunitOfWorkMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockunitOfWork) EXPECT() *MockunitOfWorkMockRecorder {
+	return m.recorder
+}
+
+// Do mocks base method.
+func (m *MockunitOfWork) Do(ctx context.Context, fn func(context.Context) error) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Do", ctx, fn)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Do indicates an expected call of Do.
+func (mr *MockunitOfWorkMockRecorder) Do(ctx, fn interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Do", reflect.TypeOf((*MockunitOfWork)(nil).Do), ctx, fn)
+}
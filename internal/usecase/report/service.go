@@ -0,0 +1,147 @@
+package report
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	null "github.com/guregu/null/v5"
+
+	"github.com/colmmurphy91/muzz/internal/adapter/mysql/user/model"
+	"github.com/colmmurphy91/muzz/internal/entity"
+)
+
+//go:generate mockgen -source $GOFILE -destination mocks/mocks_${GOFILE} -package mocks
+
+type reportStore interface {
+	CreateReport(ctx context.Context, report entity.Report) (entity.Report, error)
+	FindReportForUpdate(ctx context.Context, id int) (entity.Report, error)
+	UpdateReportStatus(ctx context.Context, report entity.Report) error
+	GetReports(ctx context.Context, status entity.ReportStatus, afterID, limit int) ([]entity.Report, error)
+}
+
+type userFetcher interface {
+	FindByID(ctx context.Context, userID int) (model.User, error)
+}
+
+type unitOfWork interface {
+	Do(ctx context.Context, fn func(ctx context.Context) error) error
+}
+
+type Service struct {
+	reportStore reportStore
+	userFetcher userFetcher
+	unitOfWork  unitOfWork
+	now         func() time.Time
+}
+
+func NewService(reports reportStore, users userFetcher, unitOfWork unitOfWork) *Service {
+	return &Service{
+		reportStore: reports,
+		userFetcher: users,
+		unitOfWork:  unitOfWork,
+		now:         time.Now,
+	}
+}
+
+// Report queues a report from the user about someone else for moderators.
+func (s *Service) Report(ctx context.Context, report entity.Report) (entity.Report, error) {
+	if err := report.Validate(); err != nil {
+		return entity.Report{}, err
+	}
+
+	if report.ReporterID == report.ReportedID {
+		return entity.Report{}, fmt.Errorf("cannot report yourself: %w", entity.ErrInvalidParam)
+	}
+
+	if _, err := s.userFetcher.FindByID(ctx, report.ReportedID); err != nil {
+		return entity.Report{}, fmt.Errorf("failed to find user: %w", err)
+	}
+
+	now := s.now().UTC().Truncate(time.Second)
+
+	report.Status = entity.ReportStatusOpen
+	report.ReviewedBy = null.Int{}
+	report.CreatedAt = now
+	report.UpdatedAt = now
+
+	saved, err := s.reportStore.CreateReport(ctx, report)
+	if err != nil {
+		return entity.Report{}, fmt.Errorf("failed to save report: %w", err)
+	}
+
+	return saved, nil
+}
+
+// Queue returns a page of the reports with the status, oldest first, so
+// moderators work through them in the order they came in.
+func (s *Service) Queue(ctx context.Context, status entity.ReportStatus, limit int, after *entity.ReportsCursor) (entity.ReportsPage, error) {
+	if limit <= 0 {
+		limit = entity.DefaultReportsLimit
+	}
+
+	if status == "" {
+		status = entity.ReportStatusOpen
+	}
+
+	if !status.Valid() {
+		return entity.ReportsPage{}, fmt.Errorf("unknown status %q: %w", status, entity.ErrInvalidParam)
+	}
+
+	afterID := 0
+	if after != nil {
+		afterID = after.ID
+	}
+
+	// Ask for one extra to know whether there is another page.
+	reports, err := s.reportStore.GetReports(ctx, status, afterID, limit+1)
+	if err != nil {
+		return entity.ReportsPage{}, fmt.Errorf("failed to get reports: %w", err)
+	}
+
+	var page entity.ReportsPage
+
+	if len(reports) > limit {
+		reports = reports[:limit]
+		page.Next = &entity.ReportsCursor{ID: reports[limit-1].ID}
+	}
+
+	page.Reports = reports
+
+	return page, nil
+}
+
+// Transition moves a report to status on behalf of a moderator. Only the moves
+// entity.ReportStatus allows can be made; others fail with
+// entity.ErrReportTransition.
+func (s *Service) Transition(ctx context.Context, moderatorID, reportID int, status entity.ReportStatus) (entity.Report, error) {
+	var report entity.Report
+
+	err := s.unitOfWork.Do(ctx, func(ctx context.Context) error {
+		var err error
+
+		report, err = s.reportStore.FindReportForUpdate(ctx, reportID)
+		if err != nil {
+			return fmt.Errorf("failed to find report: %w", err)
+		}
+
+		if !report.Status.CanTransitionTo(status) {
+			return fmt.Errorf("%s to %s: %w", report.Status, status, entity.ErrReportTransition)
+		}
+
+		report.Status = status
+		report.ReviewedBy = null.IntFrom(int64(moderatorID))
+		report.UpdatedAt = s.now().UTC().Truncate(time.Second)
+
+		if err := s.reportStore.UpdateReportStatus(ctx, report); err != nil {
+			return fmt.Errorf("failed to update report: %w", err)
+		}
+
+		return nil
+	})
+	if err != nil {
+		return entity.Report{}, err
+	}
+
+	return report, nil
+}
@@ -0,0 +1,261 @@
+package report
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	null "github.com/guregu/null/v5"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/colmmurphy91/muzz/internal/adapter/mysql/user/model"
+	"github.com/colmmurphy91/muzz/internal/entity"
+	"github.com/colmmurphy91/muzz/internal/usecase/report/mocks"
+)
+
+func TestService_Report(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockReports := mocks.NewMockreportStore(ctrl)
+	mockUsers := mocks.NewMockuserFetcher(ctrl)
+	service := NewService(mockReports, mockUsers, mocks.NewMockunitOfWork(ctrl))
+	now := time.Date(2024, 7, 5, 9, 0, 0, 0, time.UTC)
+	service.now = func() time.Time {
+		return now
+	}
+
+	ctx := context.Background()
+	report := entity.Report{ReporterID: 1, ReportedID: 2, Reason: entity.ReportReasonSpam, Details: "sells things"}
+	queued := report
+	queued.Status = entity.ReportStatusOpen
+	queued.CreatedAt = now
+	queued.UpdatedAt = now
+
+	tests := []struct {
+		name          string
+		report        entity.Report
+		setupMocks    func()
+		expected      entity.Report
+		expectedError error
+	}{
+		{
+			name:   "queues the report",
+			report: report,
+			setupMocks: func() {
+				mockUsers.EXPECT().FindByID(ctx, 2).Return(model.User{ID: 2}, nil)
+
+				saved := queued
+				saved.ID = 4
+
+				mockReports.EXPECT().CreateReport(ctx, queued).Return(saved, nil)
+			},
+			expected: entity.Report{
+				ID:         4,
+				ReporterID: 1,
+				ReportedID: 2,
+				Reason:     entity.ReportReasonSpam,
+				Details:    "sells things",
+				Status:     entity.ReportStatusOpen,
+				CreatedAt:  now,
+				UpdatedAt:  now,
+			},
+		},
+		{
+			name:          "unknown reason",
+			report:        entity.Report{ReporterID: 1, ReportedID: 2, Reason: "boring"},
+			setupMocks:    func() {},
+			expectedError: errors.New("reason: must be a valid value."),
+		},
+		{
+			name:          "cannot report yourself",
+			report:        entity.Report{ReporterID: 1, ReportedID: 1, Reason: entity.ReportReasonOther},
+			setupMocks:    func() {},
+			expectedError: errors.New("cannot report yourself: invalid param"),
+		},
+		{
+			name:   "user not found",
+			report: report,
+			setupMocks: func() {
+				mockUsers.EXPECT().FindByID(ctx, 2).Return(model.User{}, entity.ErrUserNotFound)
+			},
+			expectedError: errors.New("failed to find user: user does not exists"),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.setupMocks()
+
+			saved, err := service.Report(ctx, tt.report)
+
+			if tt.expectedError != nil {
+				assert.EqualError(t, err, tt.expectedError.Error())
+				return
+			}
+
+			assert.NoError(t, err)
+			assert.Equal(t, tt.expected, saved)
+		})
+	}
+}
+
+func TestService_Transition(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockReports := mocks.NewMockreportStore(ctrl)
+	mockUnitOfWork := mocks.NewMockunitOfWork(ctrl)
+	service := NewService(mockReports, mocks.NewMockuserFetcher(ctrl), mockUnitOfWork)
+	now := time.Date(2024, 7, 5, 9, 0, 0, 0, time.UTC)
+	service.now = func() time.Time {
+		return now
+	}
+
+	ctx := context.Background()
+	open := entity.Report{ID: 4, ReporterID: 1, ReportedID: 2, Status: entity.ReportStatusOpen}
+
+	mockUnitOfWork.EXPECT().Do(ctx, gomock.Any()).DoAndReturn(
+		func(ctx context.Context, fn func(ctx context.Context) error) error {
+			return fn(ctx)
+		}).AnyTimes()
+
+	tests := []struct {
+		name          string
+		status        entity.ReportStatus
+		setupMocks    func()
+		expected      entity.Report
+		expectedError error
+	}{
+		{
+			name:   "picks up an open report",
+			status: entity.ReportStatusInReview,
+			setupMocks: func() {
+				mockReports.EXPECT().FindReportForUpdate(ctx, 4).Return(open, nil)
+				mockReports.EXPECT().UpdateReportStatus(ctx, entity.Report{
+					ID:         4,
+					ReporterID: 1,
+					ReportedID: 2,
+					Status:     entity.ReportStatusInReview,
+					ReviewedBy: null.IntFrom(9),
+					UpdatedAt:  now,
+				}).Return(nil)
+			},
+			expected: entity.Report{
+				ID:         4,
+				ReporterID: 1,
+				ReportedID: 2,
+				Status:     entity.ReportStatusInReview,
+				ReviewedBy: null.IntFrom(9),
+				UpdatedAt:  now,
+			},
+		},
+		{
+			name:   "closed reports stay closed",
+			status: entity.ReportStatusOpen,
+			setupMocks: func() {
+				dismissed := open
+				dismissed.Status = entity.ReportStatusDismissed
+
+				mockReports.EXPECT().FindReportForUpdate(ctx, 4).Return(dismissed, nil)
+			},
+			expectedError: errors.New("dismissed to open: report cannot move to that status"),
+		},
+		{
+			name:   "report not found",
+			status: entity.ReportStatusDismissed,
+			setupMocks: func() {
+				mockReports.EXPECT().FindReportForUpdate(ctx, 4).Return(entity.Report{}, entity.ErrReportNotFound)
+			},
+			expectedError: errors.New("failed to find report: report does not exist"),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.setupMocks()
+
+			report, err := service.Transition(ctx, 9, 4, tt.status)
+
+			if tt.expectedError != nil {
+				assert.EqualError(t, err, tt.expectedError.Error())
+				return
+			}
+
+			assert.NoError(t, err)
+			assert.Equal(t, tt.expected, report)
+		})
+	}
+}
+
+func TestService_Queue(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockReports := mocks.NewMockreportStore(ctrl)
+	service := NewService(mockReports, mocks.NewMockuserFetcher(ctrl), mocks.NewMockunitOfWork(ctrl))
+
+	ctx := context.Background()
+
+	tests := []struct {
+		name          string
+		status        entity.ReportStatus
+		limit         int
+		after         *entity.ReportsCursor
+		setupMocks    func()
+		expected      entity.ReportsPage
+		expectedError error
+	}{
+		{
+			name:  "oldest open reports first with a cursor to the next page",
+			limit: 2,
+			setupMocks: func() {
+				mockReports.EXPECT().GetReports(ctx, entity.ReportStatusOpen, 0, 3).Return([]entity.Report{{ID: 1}, {ID: 2}, {ID: 3}}, nil)
+			},
+			expected: entity.ReportsPage{
+				Reports: []entity.Report{{ID: 1}, {ID: 2}},
+				Next:    &entity.ReportsCursor{ID: 2},
+			},
+		},
+		{
+			name:   "last page of reports in review",
+			status: entity.ReportStatusInReview,
+			after:  &entity.ReportsCursor{ID: 2},
+			setupMocks: func() {
+				mockReports.EXPECT().GetReports(ctx, entity.ReportStatusInReview, 2, entity.DefaultReportsLimit+1).Return([]entity.Report{{ID: 3}}, nil)
+			},
+			expected: entity.ReportsPage{Reports: []entity.Report{{ID: 3}}},
+		},
+		{
+			name:          "unknown status",
+			status:        "closed",
+			setupMocks:    func() {},
+			expectedError: errors.New(`unknown status "closed": invalid param`),
+		},
+		{
+			name: "store failure",
+			setupMocks: func() {
+				mockReports.EXPECT().GetReports(ctx, entity.ReportStatusOpen, 0, entity.DefaultReportsLimit+1).Return(nil, errors.New("db error"))
+			},
+			expectedError: errors.New("failed to get reports: db error"),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.setupMocks()
+
+			page, err := service.Queue(ctx, tt.status, tt.limit, tt.after)
+
+			if tt.expectedError != nil {
+				assert.EqualError(t, err, tt.expectedError.Error())
+				return
+			}
+
+			assert.NoError(t, err)
+			assert.Equal(t, tt.expected, page)
+		})
+	}
+}
@@ -0,0 +1,126 @@
+package service
+
+import (
+	"context"
+	"math/rand"
+	"os"
+	"sync"
+	"testing"
+	"time"
+
+	_ "github.com/go-sql-driver/mysql"
+	"github.com/jmoiron/sqlx"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+
+	blockStore "github.com/colmmurphy91/muzz/internal/adapter/mysql/block"
+	matchStore "github.com/colmmurphy91/muzz/internal/adapter/mysql/match"
+	quotaStore "github.com/colmmurphy91/muzz/internal/adapter/mysql/quota"
+	swipeStore "github.com/colmmurphy91/muzz/internal/adapter/mysql/swipe"
+	"github.com/colmmurphy91/muzz/internal/adapter/mysql/uow"
+	"github.com/colmmurphy91/muzz/internal/entity"
+)
+
+type noopIndexer struct{}
+
+func (noopIndexer) AddSwiped(context.Context, int, int) error { return nil }
+
+func (noopIndexer) RemoveSwiped(context.Context, int, int) error { return nil }
+
+func (noopIndexer) AddSuperLike(context.Context, int, int) error { return nil }
+
+func (noopIndexer) RemoveSuperLike(context.Context, int, int) error { return nil }
+
+type noopNotifier struct{}
+
+func (noopNotifier) Notify(_ context.Context, n entity.Notification) (entity.Notification, error) {
+	return n, nil
+}
+
+func (noopNotifier) Push(context.Context, ...entity.Notification) error { return nil }
+
+// TestService_Swipe_Concurrent has many pairs swipe yes on each other at the
+// same moment and checks each pair gets exactly one match. It needs a migrated
+// database, e.g.
+//
+//	MYSQL_DSN='user:user_password@tcp(localhost:3306)/my_database?parseTime=true' go test ./internal/usecase/swipe/
+func TestService_Swipe_Concurrent(t *testing.T) {
+	dsn := os.Getenv("MYSQL_DSN")
+	if dsn == "" {
+		t.Skip("MYSQL_DSN not set")
+	}
+
+	db, err := sqlx.Connect("mysql", dsn)
+	require.NoError(t, err)
+
+	defer db.Close()
+
+	logger := zap.NewNop().Sugar()
+	service := NewService(
+		logger,
+		Config{},
+		swipeStore.NewStore(logger, db),
+		noopIndexer{},
+		matchStore.NewStore(logger, db),
+		blockStore.NewStore(logger, db),
+		noopNotifier{},
+		quotaStore.NewStore(logger, db),
+		uow.NewUnitOfWork(logger, db),
+	)
+
+	const pairs = 50
+
+	// Ids well above any real user, so the test can run against a dev database.
+	base := 1_000_000_000 + rand.New(rand.NewSource(time.Now().UnixNano())).Intn(100_000_000) //nolint:gosec
+	ctx := context.Background()
+
+	defer func() {
+		db.MustExec("DELETE FROM swipes WHERE user_id BETWEEN ? AND ?", base, base+2*pairs)
+		db.MustExec("DELETE FROM swipe_history WHERE user_id BETWEEN ? AND ?", base, base+2*pairs)
+		db.MustExec("DELETE FROM matches WHERE user1_id BETWEEN ? AND ?", base, base+2*pairs)
+	}()
+
+	var (
+		wg      sync.WaitGroup
+		start   = make(chan struct{})
+		mu      sync.Mutex
+		matched = map[int]int{}
+	)
+
+	for i := 0; i < pairs; i++ {
+		a, b := base+2*i, base+2*i+1
+
+		for _, swipe := range [][2]int{{a, b}, {b, a}} {
+			wg.Add(1)
+
+			go func(userID, targetID int) {
+				defer wg.Done()
+
+				<-start
+
+				resp, err := service.Swipe(ctx, userID, targetID, entity.PreferenceYes)
+				assert.NoError(t, err)
+
+				if resp.Matched {
+					mu.Lock()
+					matched[min(userID, targetID)]++
+					mu.Unlock()
+				}
+			}(swipe[0], swipe[1])
+		}
+	}
+
+	close(start)
+	wg.Wait()
+
+	for i := 0; i < pairs; i++ {
+		a, b := base+2*i, base+2*i+1
+
+		var count int
+		require.NoError(t, db.Get(&count, "SELECT COUNT(*) FROM matches WHERE user1_id = ? AND user2_id = ?", a, b))
+
+		assert.Equal(t, 1, count, "matches stored for %d and %d", a, b)
+		assert.Equal(t, 1, matched[a], "swipes reporting a match for %d and %d", a, b)
+	}
+}
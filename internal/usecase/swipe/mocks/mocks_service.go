@@ -7,6 +7,7 @@ package mocks
 import (
 	context "context"
 	reflect "reflect"
+	time "time"
 
 	entity "github.com/colmmurphy91/muzz/internal/entity"
 	gomock "github.com/golang/mock/gomock"
@@ -35,19 +36,122 @@ func (m *Mockswiper) EXPECT() *MockswiperMockRecorder {
 	return m.recorder
 }
 
-// GetUsersYesSwipes mocks base method.
-func (m *Mockswiper) GetTargetsYesSwipes(ctx context.Context, userID int) (map[int]entity.Swipe, error) {
+// CountSuperLikes mocks base method.
+func (m *Mockswiper) CountSuperLikes(ctx context.Context, userID int, since time.Time) (int, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetTargetsYesSwipes", ctx, userID)
-	ret0, _ := ret[0].(map[int]entity.Swipe)
+	ret := m.ctrl.Call(m, "CountSuperLikes", ctx, userID, since)
+	ret0, _ := ret[0].(int)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// GetUsersYesSwipes indicates an expected call of GetUsersYesSwipes.
-func (mr *MockswiperMockRecorder) GetUsersYesSwipes(ctx, userID interface{}) *gomock.Call {
+// CountSuperLikes indicates an expected call of CountSuperLikes.
+func (mr *MockswiperMockRecorder) CountSuperLikes(ctx, userID, since interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetTargetsYesSwipes", reflect.TypeOf((*Mockswiper)(nil).GetTargetsYesSwipes), ctx, userID)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CountSuperLikes", reflect.TypeOf((*Mockswiper)(nil).CountSuperLikes), ctx, userID, since)
+}
+
+// CountSwipeEvents mocks base method.
+func (m *Mockswiper) CountSwipeEvents(ctx context.Context, userID int, action entity.SwipeAction, since time.Time) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CountSwipeEvents", ctx, userID, action, since)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CountSwipeEvents indicates an expected call of CountSwipeEvents.
+func (mr *MockswiperMockRecorder) CountSwipeEvents(ctx, userID, action, since interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CountSwipeEvents", reflect.TypeOf((*Mockswiper)(nil).CountSwipeEvents), ctx, userID, action, since)
+}
+
+// DeleteSwipe mocks base method.
+func (m *Mockswiper) DeleteSwipe(ctx context.Context, id int) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteSwipe", ctx, id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteSwipe indicates an expected call of DeleteSwipe.
+func (mr *MockswiperMockRecorder) DeleteSwipe(ctx, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteSwipe", reflect.TypeOf((*Mockswiper)(nil).DeleteSwipe), ctx, id)
+}
+
+// FindSwipe mocks base method.
+func (m *Mockswiper) FindSwipe(ctx context.Context, userID, targetID int) (entity.Swipe, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindSwipe", ctx, userID, targetID)
+	ret0, _ := ret[0].(entity.Swipe)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindSwipe indicates an expected call of FindSwipe.
+func (mr *MockswiperMockRecorder) FindSwipe(ctx, userID, targetID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindSwipe", reflect.TypeOf((*Mockswiper)(nil).FindSwipe), ctx, userID, targetID)
+}
+
+// FindSwipeKey mocks base method.
+func (m *Mockswiper) FindSwipeKey(ctx context.Context, userID int, key string) (entity.SwipeKey, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindSwipeKey", ctx, userID, key)
+	ret0, _ := ret[0].(entity.SwipeKey)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindSwipeKey indicates an expected call of FindSwipeKey.
+func (mr *MockswiperMockRecorder) FindSwipeKey(ctx, userID, key interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindSwipeKey", reflect.TypeOf((*Mockswiper)(nil).FindSwipeKey), ctx, userID, key)
+}
+
+// HasLiked mocks base method.
+func (m *Mockswiper) HasLiked(ctx context.Context, userID, targetID int) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "HasLiked", ctx, userID, targetID)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// HasLiked indicates an expected call of HasLiked.
+func (mr *MockswiperMockRecorder) HasLiked(ctx, userID, targetID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "HasLiked", reflect.TypeOf((*Mockswiper)(nil).HasLiked), ctx, userID, targetID)
+}
+
+// LastSwipe mocks base method.
+func (m *Mockswiper) LastSwipe(ctx context.Context, userID int) (entity.Swipe, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "LastSwipe", ctx, userID)
+	ret0, _ := ret[0].(entity.Swipe)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// LastSwipe indicates an expected call of LastSwipe.
+func (mr *MockswiperMockRecorder) LastSwipe(ctx, userID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "LastSwipe", reflect.TypeOf((*Mockswiper)(nil).LastSwipe), ctx, userID)
+}
+
+// RecordSwipeEvent mocks base method.
+func (m *Mockswiper) RecordSwipeEvent(ctx context.Context, event entity.SwipeEvent) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RecordSwipeEvent", ctx, event)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RecordSwipeEvent indicates an expected call of RecordSwipeEvent.
+func (mr *MockswiperMockRecorder) RecordSwipeEvent(ctx, event interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RecordSwipeEvent", reflect.TypeOf((*Mockswiper)(nil).RecordSwipeEvent), ctx, event)
 }
 
 // SaveSwipe mocks base method.
@@ -64,6 +168,259 @@ func (mr *MockswiperMockRecorder) SaveSwipe(ctx, swipe interface{}) *gomock.Call
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SaveSwipe", reflect.TypeOf((*Mockswiper)(nil).SaveSwipe), ctx, swipe)
 }
 
+// SaveSwipeKey mocks base method.
+func (m *Mockswiper) SaveSwipeKey(ctx context.Context, swipeKey entity.SwipeKey) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SaveSwipeKey", ctx, swipeKey)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SaveSwipeKey indicates an expected call of SaveSwipeKey.
+func (mr *MockswiperMockRecorder) SaveSwipeKey(ctx, swipeKey interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SaveSwipeKey", reflect.TypeOf((*Mockswiper)(nil).SaveSwipeKey), ctx, swipeKey)
+}
+
+// MockswipedIndexer is a mock of swipedIndexer interface.
+type MockswipedIndexer struct {
+	ctrl     *gomock.Controller
+	recorder *MockswipedIndexerMockRecorder
+}
+
+// MockswipedIndexerMockRecorder is the mock recorder for MockswipedIndexer.
+type MockswipedIndexerMockRecorder struct {
+	mock *MockswipedIndexer
+}
+
+// NewMockswipedIndexer creates a new mock instance.
+func NewMockswipedIndexer(ctrl *gomock.Controller) *MockswipedIndexer {
+	mock := &MockswipedIndexer{ctrl: ctrl}
+	mock.recorder = &MockswipedIndexerMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockswipedIndexer) EXPECT() *MockswipedIndexerMockRecorder {
+	return m.recorder
+}
+
+// AddSuperLike mocks base method.
+func (m *MockswipedIndexer) AddSuperLike(ctx context.Context, userID, fromID int) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AddSuperLike", ctx, userID, fromID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// AddSuperLike indicates an expected call of AddSuperLike.
+func (mr *MockswipedIndexerMockRecorder) AddSuperLike(ctx, userID, fromID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddSuperLike", reflect.TypeOf((*MockswipedIndexer)(nil).AddSuperLike), ctx, userID, fromID)
+}
+
+// AddSwiped mocks base method.
+func (m *MockswipedIndexer) AddSwiped(ctx context.Context, userID, targetID int) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AddSwiped", ctx, userID, targetID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// AddSwiped indicates an expected call of AddSwiped.
+func (mr *MockswipedIndexerMockRecorder) AddSwiped(ctx, userID, targetID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddSwiped", reflect.TypeOf((*MockswipedIndexer)(nil).AddSwiped), ctx, userID, targetID)
+}
+
+// RemoveSuperLike mocks base method.
+func (m *MockswipedIndexer) RemoveSuperLike(ctx context.Context, userID, fromID int) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RemoveSuperLike", ctx, userID, fromID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RemoveSuperLike indicates an expected call of RemoveSuperLike.
+func (mr *MockswipedIndexerMockRecorder) RemoveSuperLike(ctx, userID, fromID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RemoveSuperLike", reflect.TypeOf((*MockswipedIndexer)(nil).RemoveSuperLike), ctx, userID, fromID)
+}
+
+// RemoveSwiped mocks base method.
+func (m *MockswipedIndexer) RemoveSwiped(ctx context.Context, userID, targetID int) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RemoveSwiped", ctx, userID, targetID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RemoveSwiped indicates an expected call of RemoveSwiped.
+func (mr *MockswipedIndexerMockRecorder) RemoveSwiped(ctx, userID, targetID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RemoveSwiped", reflect.TypeOf((*MockswipedIndexer)(nil).RemoveSwiped), ctx, userID, targetID)
+}
+
+// MocklikeLimiter is a mock of likeLimiter interface.
+type MocklikeLimiter struct {
+	ctrl     *gomock.Controller
+	recorder *MocklikeLimiterMockRecorder
+}
+
+// MocklikeLimiterMockRecorder is the mock recorder for MocklikeLimiter.
+type MocklikeLimiterMockRecorder struct {
+	mock *MocklikeLimiter
+}
+
+// NewMocklikeLimiter creates a new mock instance.
+func NewMocklikeLimiter(ctrl *gomock.Controller) *MocklikeLimiter {
+	mock := &MocklikeLimiter{ctrl: ctrl}
+	mock.recorder = &MocklikeLimiterMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MocklikeLimiter) EXPECT() *MocklikeLimiterMockRecorder {
+	return m.recorder
+}
+
+// Quota mocks base method.
+func (m *MocklikeLimiter) Quota(ctx context.Context, userID int, rule entity.QuotaRule, now time.Time) (entity.Quota, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Quota", ctx, userID, rule, now)
+	ret0, _ := ret[0].(entity.Quota)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Quota indicates an expected call of Quota.
+func (mr *MocklikeLimiterMockRecorder) Quota(ctx, userID, rule, now interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Quota", reflect.TypeOf((*MocklikeLimiter)(nil).Quota), ctx, userID, rule, now)
+}
+
+// Refund mocks base method.
+func (m *MocklikeLimiter) Refund(ctx context.Context, userID int, usedAt time.Time) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "Refund", ctx, userID, usedAt)
+}
+
+// Refund indicates an expected call of Refund.
+func (mr *MocklikeLimiterMockRecorder) Refund(ctx, userID, usedAt interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Refund", reflect.TypeOf((*MocklikeLimiter)(nil).Refund), ctx, userID, usedAt)
+}
+
+// Take mocks base method.
+func (m *MocklikeLimiter) Take(ctx context.Context, userID int, rule entity.QuotaRule, now time.Time) (entity.Quota, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Take", ctx, userID, rule, now)
+	ret0, _ := ret[0].(entity.Quota)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Take indicates an expected call of Take.
+func (mr *MocklikeLimiterMockRecorder) Take(ctx, userID, rule, now interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Take", reflect.TypeOf((*MocklikeLimiter)(nil).Take), ctx, userID, rule, now)
+}
+
+// Mocknotifier is a mock of notifier interface.
+type Mocknotifier struct {
+	ctrl     *gomock.Controller
+	recorder *MocknotifierMockRecorder
+}
+
+// MocknotifierMockRecorder is the mock recorder for Mocknotifier.
+type MocknotifierMockRecorder struct {
+	mock *Mocknotifier
+}
+
+// NewMocknotifier creates a new mock instance.
+func NewMocknotifier(ctrl *gomock.Controller) *Mocknotifier {
+	mock := &Mocknotifier{ctrl: ctrl}
+	mock.recorder = &MocknotifierMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *Mocknotifier) EXPECT() *MocknotifierMockRecorder {
+	return m.recorder
+}
+
+// Notify mocks base method.
+func (m *Mocknotifier) Notify(ctx context.Context, notification entity.Notification) (entity.Notification, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Notify", ctx, notification)
+	ret0, _ := ret[0].(entity.Notification)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Notify indicates an expected call of Notify.
+func (mr *MocknotifierMockRecorder) Notify(ctx, notification interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Notify", reflect.TypeOf((*Mocknotifier)(nil).Notify), ctx, notification)
+}
+
+// Push mocks base method.
+func (m *Mocknotifier) Push(ctx context.Context, notifications ...entity.Notification) error {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{ctx}
+	for _, a := range notifications {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "Push", varargs...)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Push indicates an expected call of Push.
+func (mr *MocknotifierMockRecorder) Push(ctx interface{}, notifications ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{ctx}, notifications...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Push", reflect.TypeOf((*Mocknotifier)(nil).Push), varargs...)
+}
+
+// MockblockChecker is a mock of blockChecker interface.
+type MockblockChecker struct {
+	ctrl     *gomock.Controller
+	recorder *MockblockCheckerMockRecorder
+}
+
+// MockblockCheckerMockRecorder is the mock recorder for MockblockChecker.
+type MockblockCheckerMockRecorder struct {
+	mock *MockblockChecker
+}
+
+// NewMockblockChecker creates a new mock instance.
+func NewMockblockChecker(ctrl *gomock.Controller) *MockblockChecker {
+	mock := &MockblockChecker{ctrl: ctrl}
+	mock.recorder = &MockblockCheckerMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockblockChecker) EXPECT() *MockblockCheckerMockRecorder {
+	return m.recorder
+}
+
+// IsBlocked mocks base method.
+func (m *MockblockChecker) IsBlocked(ctx context.Context, userID, otherID int) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "IsBlocked", ctx, userID, otherID)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// IsBlocked indicates an expected call of IsBlocked.
+func (mr *MockblockCheckerMockRecorder) IsBlocked(ctx, userID, otherID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "IsBlocked", reflect.TypeOf((*MockblockChecker)(nil).IsBlocked), ctx, userID, otherID)
+}
+
 // Mockmatcher is a mock of matcher interface.
 type Mockmatcher struct {
 	ctrl     *gomock.Controller
@@ -101,3 +458,55 @@ func (mr *MockmatcherMockRecorder) CreateMatch(ctx, match interface{}) *gomock.C
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateMatch", reflect.TypeOf((*Mockmatcher)(nil).CreateMatch), ctx, match)
 }
+
+// FindMatchByPair mocks base method.
+func (m *Mockmatcher) FindMatchByPair(ctx context.Context, user1ID, user2ID int) (entity.Match, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindMatchByPair", ctx, user1ID, user2ID)
+	ret0, _ := ret[0].(entity.Match)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindMatchByPair indicates an expected call of FindMatchByPair.
+func (mr *MockmatcherMockRecorder) FindMatchByPair(ctx, user1ID, user2ID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindMatchByPair", reflect.TypeOf((*Mockmatcher)(nil).FindMatchByPair), ctx, user1ID, user2ID)
+}
+
+// MockunitOfWork is a mock of unitOfWork interface.
+type MockunitOfWork struct {
+	ctrl     *gomock.Controller
+	recorder *MockunitOfWorkMockRecorder
+}
+
+// MockunitOfWorkMockRecorder is the mock recorder for MockunitOfWork.
+type MockunitOfWorkMockRecorder struct {
+	mock *MockunitOfWork
+}
+
+// NewMockunitOfWork creates a new mock instance.
+func NewMockunitOfWork(ctrl *gomock.Controller) *MockunitOfWork {
+	mock := &MockunitOfWork{ctrl: ctrl}
+	mock.recorder = &MockunitOfWorkMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockunitOfWork) EXPECT() *MockunitOfWorkMockRecorder {
+	return m.recorder
+}
+
+// Do mocks base method.
+func (m *MockunitOfWork) Do(ctx context.Context, fn func(context.Context) error) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Do", ctx, fn)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Do indicates an expected call of Do.
+func (mr *MockunitOfWorkMockRecorder) Do(ctx, fn interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Do", reflect.TypeOf((*MockunitOfWork)(nil).Do), ctx, fn)
+}
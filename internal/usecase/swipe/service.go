@@ -2,75 +2,657 @@ package service
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"sort"
+	"time"
+
+	"go.uber.org/zap"
 
 	"github.com/colmmurphy91/muzz/internal/entity"
 )
 
 //go:generate mockgen -source $GOFILE -destination mocks/mocks_${GOFILE} -package mocks
 
+const (
+	// DefaultRewindsPerDay is how many swipes a user can rewind a day unless configured.
+	DefaultRewindsPerDay = 3
+	// DefaultSuperLikesPerDay is how many super likes a user can make a day unless configured.
+	DefaultSuperLikesPerDay = 1
+	// DefaultLikesPerWindow is how many likes a user can make in DefaultLikeWindow unless configured.
+	DefaultLikesPerWindow = 100
+	DefaultLikeWindow     = 24 * time.Hour
+)
+
 type swiper interface {
-	GetTargetsYesSwipes(ctx context.Context, userID int) (map[int]entity.Swipe, error)
+	FindSwipe(ctx context.Context, userID, targetID int) (entity.Swipe, error)
+	LastSwipe(ctx context.Context, userID int) (entity.Swipe, error)
+	HasLiked(ctx context.Context, userID, targetID int) (bool, error)
 	SaveSwipe(ctx context.Context, swipe entity.Swipe) error
+	DeleteSwipe(ctx context.Context, id int) error
+	RecordSwipeEvent(ctx context.Context, event entity.SwipeEvent) error
+	CountSwipeEvents(ctx context.Context, userID int, action entity.SwipeAction, since time.Time) (int, error)
+	CountSuperLikes(ctx context.Context, userID int, since time.Time) (int, error)
+	FindSwipeKey(ctx context.Context, userID int, key string) (entity.SwipeKey, error)
+	SaveSwipeKey(ctx context.Context, swipeKey entity.SwipeKey) error
+}
+
+// swipedIndexer keeps the list of people a user has swiped on that discovery
+// excludes, and the list of people who super liked them that it puts first.
+type swipedIndexer interface {
+	AddSwiped(ctx context.Context, userID, targetID int) error
+	RemoveSwiped(ctx context.Context, userID, targetID int) error
+	AddSuperLike(ctx context.Context, userID, fromID int) error
+	RemoveSuperLike(ctx context.Context, userID, fromID int) error
+}
+
+// likeLimiter counts each user's likes over a rolling window. Likes are taken
+// in the swipe's unit of work, and given back with Refund when it is not
+// committed, for limiters that do not take part in it.
+type likeLimiter interface {
+	Take(ctx context.Context, userID int, rule entity.QuotaRule, now time.Time) (entity.Quota, error)
+	Refund(ctx context.Context, userID int, usedAt time.Time)
+	Quota(ctx context.Context, userID int, rule entity.QuotaRule, now time.Time) (entity.Quota, error)
+}
+
+type notifier interface {
+	Notify(ctx context.Context, notification entity.Notification) (entity.Notification, error)
+	Push(ctx context.Context, notifications ...entity.Notification) error
+}
+
+// blockChecker reports whether either of a pair has blocked the other.
+type blockChecker interface {
+	IsBlocked(ctx context.Context, userID, otherID int) (bool, error)
 }
 
 type matcher interface {
 	CreateMatch(ctx context.Context, match entity.Match) (entity.Match, error)
+	FindMatchByPair(ctx context.Context, user1ID, user2ID int) (entity.Match, error)
+}
+
+// unitOfWork runs fn in a single transaction shared by the stores.
+type unitOfWork interface {
+	Do(ctx context.Context, fn func(ctx context.Context) error) error
+}
+
+// Config holds the swipe limits.
+type Config struct {
+	// RewindsPerDay is how many swipes a user can rewind each UTC day.
+	RewindsPerDay int
+	// SuperLikesPerDay is how many super likes a user can make each UTC day.
+	SuperLikesPerDay int
+	// LikesPerWindow is how many likes, super or not, a user can make in any
+	// rolling LikeWindow. Passes are not limited.
+	LikesPerWindow int
+	LikeWindow     time.Duration
 }
 
 type Service struct {
-	swiper  swiper
-	matcher matcher
+	logger        *zap.SugaredLogger
+	config        Config
+	swiper        swiper
+	swipedIndexer swipedIndexer
+	matcher       matcher
+	blockChecker  blockChecker
+	notifier      notifier
+	likeLimiter   likeLimiter
+	unitOfWork    unitOfWork
+	now           func() time.Time
 }
 
-func NewService(swipe swiper, match matcher) *Service {
+func NewService(
+	logger *zap.SugaredLogger,
+	config Config,
+	swipe swiper,
+	indexer swipedIndexer,
+	match matcher,
+	blocks blockChecker,
+	notify notifier,
+	limiter likeLimiter,
+	unitOfWork unitOfWork,
+) *Service {
+	if config.RewindsPerDay <= 0 {
+		config.RewindsPerDay = DefaultRewindsPerDay
+	}
+
+	if config.SuperLikesPerDay <= 0 {
+		config.SuperLikesPerDay = DefaultSuperLikesPerDay
+	}
+
+	if config.LikesPerWindow <= 0 {
+		config.LikesPerWindow = DefaultLikesPerWindow
+	}
+
+	if config.LikeWindow <= 0 {
+		config.LikeWindow = DefaultLikeWindow
+	}
+
 	return &Service{
-		swiper:  swipe,
-		matcher: match,
+		logger:        logger,
+		config:        config,
+		swiper:        swipe,
+		swipedIndexer: indexer,
+		matcher:       match,
+		blockChecker:  blocks,
+		notifier:      notify,
+		likeLimiter:   limiter,
+		unitOfWork:    unitOfWork,
+		now:           time.Now,
 	}
 }
 
+// Swipe records a swipe and, when it is a yes and the target has already swiped
+// yes on the user, creates their match. Both happen in one transaction, so two
+// people swiping yes on each other at the same time make exactly one match.
+//
+// Swiping on someone again changes the earlier decision:
+//   - repeating it changes nothing and reports the current match, if any;
+//   - otherwise the decision is replaced, and a new yes is checked for a match
+//     like a first swipe;
+//   - once a pair have matched, even if they have since unmatched, neither can
+//     change their decision and fails with entity.ErrSwipeLocked.
+//
+// Both people are notified of a new match.
+//
+// A super like counts as a yes. The target is notified of it and discovery
+// shows the user to them first. Only Config.SuperLikesPerDay can be made each
+// UTC day.
+//
+// Likes, but not passes, use up the user's quota of Config.LikesPerWindow, and
+// changing between a like and a super like does not use it again. Once it is
+// used up likes fail with entity.QuotaExceededError.
+//
+// Swiping on someone either of the pair has blocked fails with
+// entity.ErrBlocked, and swiping on yourself with entity.ErrInvalidParam.
 func (s *Service) Swipe(ctx context.Context, userID, target int, preference entity.Preference) (MatchResponse, error) {
-	// Save the swipe
-	err := s.swiper.SaveSwipe(ctx, entity.Swipe{
-		UserID:     userID,
-		TargetID:   target,
-		Preference: preference,
+	var (
+		resp   MatchResponse
+		change swipeChange
+		taken  []time.Time
+	)
+
+	err := s.unitOfWork.Do(ctx, func(ctx context.Context) error {
+		var err error
+
+		resp, change, err = s.saveSwipe(ctx, entity.Swipe{UserID: userID, TargetID: target, Preference: preference})
+		taken = append(taken, change.likeTakenAt)
+
+		return err
+	})
+
+	s.refundLikes(ctx, userID, taken, err == nil)
+
+	if err != nil {
+		return MatchResponse{}, err
+	}
+
+	s.push(ctx, change)
+
+	if err := s.indexSwipe(ctx, change); err != nil {
+		return MatchResponse{}, err
+	}
+
+	return resp, nil
+}
+
+// BatchResult is the outcome of one swipe in a batch. Replayed is set when the
+// key was sent before and the original result is returned.
+type BatchResult struct {
+	Swipe    entity.BatchSwipe
+	Match    MatchResponse
+	Replayed bool
+	Err      error
+}
+
+// SwipeBatch applies swipes a client queued, each like Swipe and in its own
+// transaction, so one failing does not stop the rest. They are applied in the
+// order the client made them, and the results are returned in the order given.
+//
+// Each swipe's result is kept under its key: a key sent again replays that
+// result, even if the swipe has since changed, and a key sent again for a
+// different swipe fails with entity.ErrSwipeKeyReused. Replays index the swipe
+// as it is now again, so a batch retried after indexing failed repairs it.
+func (s *Service) SwipeBatch(ctx context.Context, userID int, swipes []entity.BatchSwipe) []BatchResult {
+	results := make([]BatchResult, len(swipes))
+
+	order := make([]int, len(swipes))
+	for i := range order {
+		order[i] = i
+	}
+
+	sort.SliceStable(order, func(a, b int) bool {
+		return swipes[order[a]].SwipedAt.Before(swipes[order[b]].SwipedAt)
+	})
+
+	for _, i := range order {
+		results[i] = s.batchSwipe(ctx, userID, swipes[i])
+	}
+
+	return results
+}
+
+func (s *Service) batchSwipe(ctx context.Context, userID int, batched entity.BatchSwipe) BatchResult {
+	result := BatchResult{Swipe: batched}
+
+	if err := batched.Validate(); err != nil {
+		result.Err = err
+		return result
+	}
+
+	var (
+		change swipeChange
+		taken  []time.Time
+	)
+
+	err := s.unitOfWork.Do(ctx, func(ctx context.Context) error {
+		result.Replayed = false
+		change = swipeChange{}
+
+		defer func() { taken = append(taken, change.likeTakenAt) }()
+
+		key, err := s.swiper.FindSwipeKey(ctx, userID, batched.Key)
+
+		switch {
+		case errors.Is(err, entity.ErrSwipeKeyNotFound):
+		case err != nil:
+			return fmt.Errorf("failed to find swipe key: %w", err)
+		case key.TargetID != batched.TargetID || key.Preference != batched.Preference:
+			return entity.ErrSwipeKeyReused
+		default:
+			result.Match = MatchResponse{Matched: key.MatchID > 0, MatchID: key.MatchID}
+			result.Replayed = true
+
+			return s.replayedChange(ctx, &change, userID, batched.TargetID)
+		}
+
+		swipe := entity.Swipe{UserID: userID, TargetID: batched.TargetID, Preference: batched.Preference}
+
+		result.Match, change, err = s.saveSwipe(ctx, swipe)
+		if err != nil {
+			return err
+		}
+
+		return s.swiper.SaveSwipeKey(ctx, entity.SwipeKey{
+			UserID:     userID,
+			Key:        batched.Key,
+			TargetID:   batched.TargetID,
+			Preference: batched.Preference,
+			MatchID:    result.Match.MatchID,
+		})
 	})
+
+	s.refundLikes(ctx, userID, taken, err == nil)
+
 	if err != nil {
-		return MatchResponse{}, fmt.Errorf("failed to save swipe: %w", err)
+		return BatchResult{Swipe: batched, Err: err}
+	}
+
+	if !result.Replayed {
+		s.push(ctx, change)
+	}
+
+	// Replays are indexed again too, in case indexing failed the first time.
+	if change.swipe.TargetID > 0 {
+		if err := s.indexSwipe(ctx, change); err != nil {
+			return BatchResult{Swipe: batched, Err: err}
+		}
+	}
+
+	return result
+}
+
+// replayedChange sets change to the user's swipe on target as it is now, to
+// index again. The swipe may have changed since the key was first sent, and
+// nothing is left to index if it has been rewound.
+func (s *Service) replayedChange(ctx context.Context, change *swipeChange, userID, target int) error {
+	current, err := s.swiper.FindSwipe(ctx, userID, target)
+
+	switch {
+	case errors.Is(err, entity.ErrSwipeNotFound):
+		return nil
+	case err != nil:
+		return fmt.Errorf("failed to find swipe: %w", err)
+	}
+
+	change.swipe = entity.Swipe{UserID: userID, TargetID: target, Preference: current.Preference}
+
+	return nil
+}
+
+// swipeChange is what saving a swipe changed, to index and push once it is
+// committed. likeTakenAt is when a like was taken from the user's quota for it,
+// if one was.
+type swipeChange struct {
+	swipe         entity.Swipe
+	changed       bool
+	wasSuper      bool
+	likeTakenAt   time.Time
+	notifications []entity.Notification
+}
+
+// refundLikes gives back the likes taken by attempts at a swipe that were not
+// committed: all of them when it failed, and those before the last when the
+// unit of work was retried and then committed.
+func (s *Service) refundLikes(ctx context.Context, userID int, taken []time.Time, committed bool) {
+	if committed && len(taken) > 0 {
+		taken = taken[:len(taken)-1]
 	}
 
-	if len(preference) == len(entity.PreferenceNo) {
-		return MatchResponse{Matched: false}, nil
+	for _, usedAt := range taken {
+		if !usedAt.IsZero() {
+			s.likeLimiter.Refund(ctx, userID, usedAt)
+		}
+	}
+}
+
+// saveSwipe does the work of Swipe that has to happen in its transaction.
+//
+// nolint:cyclop
+func (s *Service) saveSwipe(ctx context.Context, swipe entity.Swipe) (MatchResponse, swipeChange, error) {
+	var (
+		userID, target, preference = swipe.UserID, swipe.TargetID, swipe.Preference
+		change                     = swipeChange{swipe: swipe}
+		action                     = entity.SwipeActionSwipe
+		wasLike                    bool
+	)
+
+	// A like on yourself would find itself liked back and match.
+	if userID == target {
+		return MatchResponse{}, change, fmt.Errorf("cannot swipe on yourself: %w", entity.ErrInvalidParam)
 	}
 
-	// Check if it's a match
-	yesSwipes, err := s.swiper.GetTargetsYesSwipes(ctx, target)
+	// The check locks the pair's blocks, so a block made meanwhile waits for the
+	// swipe and then ends any match it made.
+	blocked, err := s.blockChecker.IsBlocked(ctx, userID, target)
 	if err != nil {
-		return MatchResponse{}, fmt.Errorf("failed to get user's yes swipes: %w", err)
+		return MatchResponse{}, change, fmt.Errorf("failed to check block: %w", err)
+	}
+
+	if blocked {
+		return MatchResponse{}, change, entity.ErrBlocked
 	}
 
-	if _, aMatch := yesSwipes[userID]; aMatch {
-		match := entity.Match{
-			User1ID: userID,
-			User2ID: target,
+	previous, err := s.swiper.FindSwipe(ctx, userID, target)
+
+	switch {
+	case errors.Is(err, entity.ErrSwipeNotFound):
+	case err != nil:
+		return MatchResponse{}, change, fmt.Errorf("failed to find previous swipe: %w", err)
+	case previous.Preference == preference:
+		resp, err := s.currentMatch(ctx, userID, target)
+
+		return resp, change, err
+	default:
+		action = entity.SwipeActionReswipe
+		change.wasSuper = previous.Preference == entity.PreferenceSuper
+		wasLike = previous.Preference.IsLike()
+
+		if err := s.checkUnlocked(ctx, userID, target); err != nil {
+			return MatchResponse{}, change, err
+		}
+	}
+
+	if preference == entity.PreferenceSuper {
+		if err := s.checkSuperLikesLeft(ctx, userID); err != nil {
+			return MatchResponse{}, change, err
 		}
+	}
+
+	// Changing between a like and a super like is still the one like.
+	if preference.IsLike() && !wasLike {
+		now := s.now()
+
+		if _, err := s.likeLimiter.Take(ctx, userID, s.likeQuota(), now); err != nil {
+			return MatchResponse{}, change, fmt.Errorf("failed to take like quota: %w", err)
+		}
+
+		change.likeTakenAt = now
+	}
 
-		match.GenerateMatchID()
+	if err := s.swiper.SaveSwipe(ctx, swipe); err != nil {
+		return MatchResponse{}, change, fmt.Errorf("failed to save swipe: %w", err)
+	}
+
+	if err := s.recordEvent(ctx, swipe, action); err != nil {
+		return MatchResponse{}, change, err
+	}
+
+	change.changed = true
 
-		createdMatch, err := s.matcher.CreateMatch(ctx, match)
+	if preference == entity.PreferenceSuper {
+		err := s.notify(ctx, &change, entity.Notification{
+			UserID:  target,
+			Type:    entity.NotificationSuperLike,
+			ActorID: userID,
+		})
 		if err != nil {
-			return MatchResponse{}, fmt.Errorf("failed to create match: %w", err)
+			return MatchResponse{}, change, fmt.Errorf("failed to notify target: %w", err)
 		}
+	}
+
+	if !preference.IsLike() {
+		return MatchResponse{}, change, nil
+	}
 
-		return MatchResponse{
-			Matched: true,
+	liked, err := s.swiper.HasLiked(ctx, target, userID)
+	if err != nil {
+		return MatchResponse{}, change, fmt.Errorf("failed to get target's swipe: %w", err)
+	}
+
+	if !liked {
+		return MatchResponse{}, change, nil
+	}
+
+	createdMatch, err := s.matcher.CreateMatch(ctx, entity.NewMatch(userID, target))
+	if err != nil {
+		return MatchResponse{}, change, fmt.Errorf("failed to create match: %w", err)
+	}
+
+	for _, pair := range [][2]int{{userID, target}, {target, userID}} {
+		err := s.notify(ctx, &change, entity.Notification{
+			UserID:  pair[0],
+			Type:    entity.NotificationMatchCreated,
+			ActorID: pair[1],
 			MatchID: createdMatch.ID,
-		}, nil
+		})
+		if err != nil {
+			return MatchResponse{}, change, fmt.Errorf("failed to notify match: %w", err)
+		}
+	}
+
+	return MatchResponse{Matched: true, MatchID: createdMatch.ID}, change, nil
+}
+
+// notify saves a notification in the swipe's transaction, keeping it to push
+// once the swipe is committed.
+func (s *Service) notify(ctx context.Context, change *swipeChange, notification entity.Notification) error {
+	saved, err := s.notifier.Notify(ctx, notification)
+	if err != nil {
+		return err
+	}
+
+	change.notifications = append(change.notifications, saved)
+
+	return nil
+}
+
+// push sends a committed swipe's notifications to connected devices. They are
+// already in the users' inboxes, so failing to push them doesn't fail the
+// swipe.
+func (s *Service) push(ctx context.Context, change swipeChange) {
+	if len(change.notifications) == 0 {
+		return
+	}
+
+	if err := s.notifier.Push(ctx, change.notifications...); err != nil {
+		s.logger.Errorw("failed to push swipe notifications", "user_id", change.swipe.UserID, "error", err)
+	}
+}
+
+// indexSwipe updates discovery once a swipe is committed. When it fails the
+// swipe, and any match it made, stay committed. A swipe that changed nothing,
+// such as the client retrying it, is indexed again in full from the decision
+// already stored, and repeating a decision is never locked, so a retry reports
+// the committed match and repairs the index.
+func (s *Service) indexSwipe(ctx context.Context, change swipeChange) error {
+	userID, target := change.swipe.UserID, change.swipe.TargetID
+
+	if err := s.swipedIndexer.AddSwiped(ctx, userID, target); err != nil {
+		return fmt.Errorf("failed to index swipe: %w", err)
+	}
+
+	switch {
+	case change.swipe.Preference == entity.PreferenceSuper:
+		if err := s.swipedIndexer.AddSuperLike(ctx, target, userID); err != nil {
+			return fmt.Errorf("failed to index super like: %w", err)
+		}
+	case change.wasSuper, !change.changed:
+		if err := s.swipedIndexer.RemoveSuperLike(ctx, target, userID); err != nil {
+			return fmt.Errorf("failed to index super like: %w", err)
+		}
+	}
+
+	return nil
+}
+
+// Rewind undoes the user's most recent swipe, so the person they swiped on can
+// be discovered again. Swipes that led to a match, even one since ended, cannot
+// be rewound, and only Config.RewindsPerDay swipes can be rewound each UTC day.
+func (s *Service) Rewind(ctx context.Context, userID int) (entity.Swipe, error) {
+	var last entity.Swipe
+
+	err := s.unitOfWork.Do(ctx, func(ctx context.Context) error {
+		var err error
+
+		// Locking the last swipe first makes concurrent rewinds by the same
+		// user wait, so they count each other's rewinds.
+		last, err = s.swiper.LastSwipe(ctx, userID)
+		if err != nil {
+			return fmt.Errorf("failed to find last swipe: %w", err)
+		}
+
+		rewinds, err := s.swiper.CountSwipeEvents(ctx, userID, entity.SwipeActionRewind, s.startOfDay())
+		if err != nil {
+			return fmt.Errorf("failed to count rewinds: %w", err)
+		}
+
+		if rewinds >= s.config.RewindsPerDay {
+			return entity.ErrRewindLimitReached
+		}
+
+		if err := s.checkUnlocked(ctx, userID, last.TargetID); err != nil {
+			return err
+		}
+
+		if err := s.swiper.DeleteSwipe(ctx, last.ID); err != nil {
+			return fmt.Errorf("failed to delete swipe: %w", err)
+		}
+
+		return s.recordEvent(ctx, last, entity.SwipeActionRewind)
+	})
+	if err != nil {
+		return entity.Swipe{}, err
+	}
+
+	if err := s.swipedIndexer.RemoveSwiped(ctx, userID, last.TargetID); err != nil {
+		return entity.Swipe{}, fmt.Errorf("failed to index rewind: %w", err)
+	}
+
+	if last.Preference == entity.PreferenceSuper {
+		if err := s.swipedIndexer.RemoveSuperLike(ctx, last.TargetID, userID); err != nil {
+			return entity.Swipe{}, fmt.Errorf("failed to index rewind: %w", err)
+		}
+	}
+
+	return last, nil
+}
+
+// Quota returns how many likes the user has left.
+func (s *Service) Quota(ctx context.Context, userID int) (entity.Quota, error) {
+	quota, err := s.likeLimiter.Quota(ctx, userID, s.likeQuota(), s.now())
+	if err != nil {
+		return entity.Quota{}, fmt.Errorf("failed to get like quota: %w", err)
+	}
+
+	return quota, nil
+}
+
+func (s *Service) likeQuota() entity.QuotaRule {
+	return entity.QuotaRule{Limit: s.config.LikesPerWindow, Window: s.config.LikeWindow}
+}
+
+// currentMatch reports the pair's match, if they have one that has not ended.
+func (s *Service) currentMatch(ctx context.Context, userID, target int) (MatchResponse, error) {
+	pair := entity.NewMatch(userID, target)
+
+	match, err := s.matcher.FindMatchByPair(ctx, pair.User1ID, pair.User2ID)
+	if err != nil {
+		if errors.Is(err, entity.ErrMatchNotFound) {
+			return MatchResponse{}, nil
+		}
+
+		return MatchResponse{}, fmt.Errorf("failed to find match: %w", err)
+	}
+
+	if match.UnmatchedAt.Valid {
+		return MatchResponse{}, nil
+	}
+
+	return MatchResponse{Matched: true, MatchID: match.ID}, nil
+}
+
+// checkSuperLikesLeft fails with entity.ErrSuperLikeLimitReached when the user
+// has made all of today's super likes. The count locks the day's super likes, so
+// concurrent super likes by the same user conflict and are retried rather than
+// both getting through.
+func (s *Service) checkSuperLikesLeft(ctx context.Context, userID int) error {
+	superLikes, err := s.swiper.CountSuperLikes(ctx, userID, s.startOfDay())
+	if err != nil {
+		return fmt.Errorf("failed to count super likes: %w", err)
+	}
+
+	if superLikes >= s.config.SuperLikesPerDay {
+		return entity.ErrSuperLikeLimitReached
+	}
+
+	return nil
+}
+
+// startOfDay is midnight UTC today, when the daily limits reset.
+func (s *Service) startOfDay() time.Time {
+	now := s.now().UTC()
+
+	return time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, time.UTC)
+}
+
+// checkUnlocked fails with entity.ErrSwipeLocked when the pair have ever matched.
+func (s *Service) checkUnlocked(ctx context.Context, userID, target int) error {
+	pair := entity.NewMatch(userID, target)
+
+	_, err := s.matcher.FindMatchByPair(ctx, pair.User1ID, pair.User2ID)
+
+	switch {
+	case errors.Is(err, entity.ErrMatchNotFound):
+		return nil
+	case err != nil:
+		return fmt.Errorf("failed to find match: %w", err)
+	default:
+		return entity.ErrSwipeLocked
+	}
+}
+
+func (s *Service) recordEvent(ctx context.Context, swipe entity.Swipe, action entity.SwipeAction) error {
+	err := s.swiper.RecordSwipeEvent(ctx, entity.SwipeEvent{
+		UserID:     swipe.UserID,
+		TargetID:   swipe.TargetID,
+		Preference: swipe.Preference,
+		Action:     action,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to record swipe: %w", err)
 	}
 
-	return MatchResponse{Matched: false}, nil
+	return nil
 }
 
 type MatchResponse struct {
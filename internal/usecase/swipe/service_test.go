@@ -4,9 +4,12 @@ import (
 	"context"
 	"fmt"
 	"testing"
+	"time"
 
 	"github.com/golang/mock/gomock"
+	null "github.com/guregu/null/v5"
 	"github.com/stretchr/testify/assert"
+	"go.uber.org/zap"
 
 	"github.com/colmmurphy91/muzz/internal/entity"
 	"github.com/colmmurphy91/muzz/internal/usecase/swipe/mocks"
@@ -17,157 +20,400 @@ func TestService_Swipe(t *testing.T) {
 	defer ctrl.Finish()
 
 	mockSwiper := mocks.NewMockswiper(ctrl)
+	mockIndexer := mocks.NewMockswipedIndexer(ctrl)
 	mockMatcher := mocks.NewMockmatcher(ctrl)
-	service := NewService(mockSwiper, mockMatcher)
+	mockBlocks := mocks.NewMockblockChecker(ctrl)
+	mockNotifier := mocks.NewMocknotifier(ctrl)
+	mockLimiter := mocks.NewMocklikeLimiter(ctrl)
+	mockUnitOfWork := mocks.NewMockunitOfWork(ctrl)
+	service := NewService(
+		zap.NewNop().Sugar(), Config{},
+		mockSwiper, mockIndexer, mockMatcher, mockBlocks, mockNotifier, mockLimiter, mockUnitOfWork,
+	)
+	now := time.Date(2024, 6, 29, 9, 0, 0, 0, time.UTC)
+	service.now = func() time.Time {
+		return now
+	}
 
 	ctx := context.Background()
 	userID := 1
 	targetID := 2
-	preferenceYes := entity.Preference("YES")
-	preferenceNo := entity.Preference("NO")
+	preferenceYes := entity.PreferenceYes
+	preferenceNo := entity.PreferenceNo
+	preferenceSuper := entity.PreferenceSuper
+	startOfDay := time.Date(2024, 6, 29, 0, 0, 0, 0, time.UTC)
+	superLikeNotification := entity.Notification{UserID: targetID, Type: entity.NotificationSuperLike, ActorID: userID}
+	savedSuperLike := superLikeNotification
+	savedSuperLike.ID = 10
+	likeQuota := entity.QuotaRule{Limit: DefaultLikesPerWindow, Window: DefaultLikeWindow}
+
+	mockUnitOfWork.EXPECT().Do(ctx, gomock.Any()).DoAndReturn(
+		func(ctx context.Context, fn func(ctx context.Context) error) error {
+			return fn(ctx)
+		}).AnyTimes()
+
+	saves := func(preference entity.Preference, action entity.SwipeAction) {
+		swipe := entity.Swipe{UserID: userID, TargetID: targetID, Preference: preference}
+
+		if preference.IsLike() {
+			mockLimiter.EXPECT().Take(ctx, userID, likeQuota, now).Return(entity.Quota{}, nil)
+		}
+
+		mockSwiper.EXPECT().SaveSwipe(ctx, swipe).Return(nil)
+		mockSwiper.EXPECT().RecordSwipeEvent(ctx, entity.SwipeEvent{
+			UserID:     userID,
+			TargetID:   targetID,
+			Preference: preference,
+			Action:     action,
+		}).Return(nil)
+	}
+
+	// matchNotified expects both people to be notified of the match, with the
+	// notifications pushed along with any others once it is committed.
+	matchNotified := func(matchID int, pushed ...entity.Notification) {
+		for i, pair := range [][2]int{{userID, targetID}, {targetID, userID}} {
+			notification := entity.Notification{
+				UserID:  pair[0],
+				Type:    entity.NotificationMatchCreated,
+				ActorID: pair[1],
+				MatchID: matchID,
+			}
+			saved := notification
+			saved.ID = 20 + i
+
+			mockNotifier.EXPECT().Notify(ctx, notification).Return(saved, nil)
+			pushed = append(pushed, saved)
+		}
+
+		mockNotifier.EXPECT().Push(ctx, pushed).Return(nil)
+	}
 
 	tests := []struct {
-		name            string
-		userID          int
-		targetID        int
-		preference      entity.Preference
-		mockSwiper      func()
-		mockMatcher     func()
-		expectedResp    MatchResponse
-		expectedError   error
-		shouldCallMatch bool
+		name          string
+		preference    entity.Preference
+		setupMocks    func()
+		expectedResp  MatchResponse
+		expectedError error
 	}{
 		{
 			name:       "successful swipe, no match",
-			userID:     userID,
-			targetID:   targetID,
 			preference: preferenceYes,
-			mockSwiper: func() {
-				mockSwiper.EXPECT().SaveSwipe(ctx, entity.Swipe{
-					UserID:     userID,
-					TargetID:   targetID,
-					Preference: preferenceYes,
-				}).Return(nil)
-				mockSwiper.EXPECT().GetUsersYesSwipes(ctx, gomock.Any()).Return(map[int]entity.Swipe{}, nil)
+			setupMocks: func() {
+				mockBlocks.EXPECT().IsBlocked(ctx, userID, targetID).Return(false, nil)
+				mockSwiper.EXPECT().FindSwipe(ctx, userID, targetID).Return(entity.Swipe{}, entity.ErrSwipeNotFound)
+				saves(preferenceYes, entity.SwipeActionSwipe)
+				mockSwiper.EXPECT().HasLiked(ctx, targetID, userID).Return(false, nil)
+				mockIndexer.EXPECT().AddSwiped(ctx, userID, targetID).Return(nil)
 			},
-			mockMatcher:     func() {},
-			expectedResp:    MatchResponse{Matched: false},
-			expectedError:   nil,
-			shouldCallMatch: false,
+			expectedResp: MatchResponse{Matched: false},
 		},
 		{
 			name:       "successful swipe, match found",
-			userID:     userID,
-			targetID:   targetID,
 			preference: preferenceYes,
-			mockSwiper: func() {
-				mockSwiper.EXPECT().SaveSwipe(ctx, entity.Swipe{
-					UserID:     userID,
-					TargetID:   targetID,
-					Preference: preferenceYes,
-				}).Return(nil)
-				mockSwiper.EXPECT().GetUsersYesSwipes(ctx, gomock.Any()).Return(map[int]entity.Swipe{
-					userID: {UserID: targetID, TargetID: userID, Preference: preferenceYes},
-				}, nil)
-			},
-			mockMatcher: func() {
-				mockMatcher.
-					EXPECT().
-					CreateMatch(ctx, gomock.Any()).DoAndReturn(
+			setupMocks: func() {
+				mockBlocks.EXPECT().IsBlocked(ctx, userID, targetID).Return(false, nil)
+				mockSwiper.EXPECT().FindSwipe(ctx, userID, targetID).Return(entity.Swipe{}, entity.ErrSwipeNotFound)
+				saves(preferenceYes, entity.SwipeActionSwipe)
+				mockSwiper.EXPECT().HasLiked(ctx, targetID, userID).Return(true, nil)
+				mockMatcher.EXPECT().CreateMatch(ctx, gomock.Any()).DoAndReturn(
 					func(_ context.Context, match entity.Match) (entity.Match, error) {
+						assert.Equal(t, entity.NewMatch(targetID, userID), match)
+
 						match.ID = 1
 						return match, nil
 					})
+				matchNotified(1)
+				mockIndexer.EXPECT().AddSwiped(ctx, userID, targetID).Return(nil)
 			},
-			expectedResp:    MatchResponse{Matched: true, MatchID: 1},
-			expectedError:   nil,
-			shouldCallMatch: true,
+			expectedResp: MatchResponse{Matched: true, MatchID: 1},
 		},
 		{
 			name:       "swipe no",
-			userID:     userID,
-			targetID:   targetID,
 			preference: preferenceNo,
-			mockSwiper: func() {
+			setupMocks: func() {
+				mockBlocks.EXPECT().IsBlocked(ctx, userID, targetID).Return(false, nil)
+				mockSwiper.EXPECT().FindSwipe(ctx, userID, targetID).Return(entity.Swipe{}, entity.ErrSwipeNotFound)
+				saves(preferenceNo, entity.SwipeActionSwipe)
+				mockIndexer.EXPECT().AddSwiped(ctx, userID, targetID).Return(nil)
+			},
+			expectedResp: MatchResponse{Matched: false},
+		},
+		{
+			name:       "repeated swipe reports the match",
+			preference: preferenceYes,
+			setupMocks: func() {
+				mockBlocks.EXPECT().IsBlocked(ctx, userID, targetID).Return(false, nil)
+				mockSwiper.EXPECT().FindSwipe(ctx, userID, targetID).Return(entity.Swipe{ID: 3, Preference: preferenceYes}, nil)
+				mockMatcher.EXPECT().FindMatchByPair(ctx, userID, targetID).Return(entity.Match{ID: 5}, nil)
+				mockIndexer.EXPECT().AddSwiped(ctx, userID, targetID).Return(nil)
+				mockIndexer.EXPECT().RemoveSuperLike(ctx, targetID, userID).Return(nil)
+			},
+			expectedResp: MatchResponse{Matched: true, MatchID: 5},
+		},
+		{
+			name:       "repeated swipe after unmatching",
+			preference: preferenceYes,
+			setupMocks: func() {
+				mockBlocks.EXPECT().IsBlocked(ctx, userID, targetID).Return(false, nil)
+				mockSwiper.EXPECT().FindSwipe(ctx, userID, targetID).Return(entity.Swipe{ID: 3, Preference: preferenceYes}, nil)
+				mockMatcher.EXPECT().FindMatchByPair(ctx, userID, targetID).Return(entity.Match{
+					ID:          5,
+					UnmatchedAt: null.TimeFrom(time.Now()),
+				}, nil)
+				mockIndexer.EXPECT().AddSwiped(ctx, userID, targetID).Return(nil)
+				mockIndexer.EXPECT().RemoveSuperLike(ctx, targetID, userID).Return(nil)
+			},
+			expectedResp: MatchResponse{Matched: false},
+		},
+		{
+			name:       "changing no to yes can match",
+			preference: preferenceYes,
+			setupMocks: func() {
+				mockBlocks.EXPECT().IsBlocked(ctx, userID, targetID).Return(false, nil)
+				mockSwiper.EXPECT().FindSwipe(ctx, userID, targetID).Return(entity.Swipe{ID: 3, Preference: preferenceNo}, nil)
+				mockMatcher.EXPECT().FindMatchByPair(ctx, userID, targetID).Return(entity.Match{}, entity.ErrMatchNotFound)
+				saves(preferenceYes, entity.SwipeActionReswipe)
+				mockSwiper.EXPECT().HasLiked(ctx, targetID, userID).Return(true, nil)
+				mockMatcher.EXPECT().CreateMatch(ctx, entity.NewMatch(userID, targetID)).Return(entity.Match{ID: 6}, nil)
+				matchNotified(6)
+				mockIndexer.EXPECT().AddSwiped(ctx, userID, targetID).Return(nil)
+			},
+			expectedResp: MatchResponse{Matched: true, MatchID: 6},
+		},
+		{
+			name:       "cannot change a swipe once matched",
+			preference: preferenceNo,
+			setupMocks: func() {
+				mockBlocks.EXPECT().IsBlocked(ctx, userID, targetID).Return(false, nil)
+				mockSwiper.EXPECT().FindSwipe(ctx, userID, targetID).Return(entity.Swipe{ID: 3, Preference: preferenceYes}, nil)
+				mockMatcher.EXPECT().FindMatchByPair(ctx, userID, targetID).Return(entity.Match{ID: 5}, nil)
+			},
+			expectedError: entity.ErrSwipeLocked,
+		},
+		{
+			name:       "super like notifies the target and boosts the user",
+			preference: preferenceSuper,
+			setupMocks: func() {
+				mockBlocks.EXPECT().IsBlocked(ctx, userID, targetID).Return(false, nil)
+				mockSwiper.EXPECT().FindSwipe(ctx, userID, targetID).Return(entity.Swipe{}, entity.ErrSwipeNotFound)
+				mockSwiper.EXPECT().CountSuperLikes(ctx, userID, startOfDay).Return(0, nil)
+				saves(preferenceSuper, entity.SwipeActionSwipe)
+				mockNotifier.EXPECT().Notify(ctx, superLikeNotification).Return(savedSuperLike, nil)
+				mockSwiper.EXPECT().HasLiked(ctx, targetID, userID).Return(false, nil)
+				mockNotifier.EXPECT().Push(ctx, []entity.Notification{savedSuperLike}).Return(nil)
+				mockIndexer.EXPECT().AddSwiped(ctx, userID, targetID).Return(nil)
+				mockIndexer.EXPECT().AddSuperLike(ctx, targetID, userID).Return(nil)
+			},
+			expectedResp: MatchResponse{Matched: false},
+		},
+		{
+			name:       "super like counts as a yes",
+			preference: preferenceSuper,
+			setupMocks: func() {
+				mockBlocks.EXPECT().IsBlocked(ctx, userID, targetID).Return(false, nil)
+				mockSwiper.EXPECT().FindSwipe(ctx, userID, targetID).Return(entity.Swipe{}, entity.ErrSwipeNotFound)
+				mockSwiper.EXPECT().CountSuperLikes(ctx, userID, startOfDay).Return(0, nil)
+				saves(preferenceSuper, entity.SwipeActionSwipe)
+				mockNotifier.EXPECT().Notify(ctx, superLikeNotification).Return(savedSuperLike, nil)
+				mockSwiper.EXPECT().HasLiked(ctx, targetID, userID).Return(true, nil)
+				mockMatcher.EXPECT().CreateMatch(ctx, entity.NewMatch(userID, targetID)).Return(entity.Match{ID: 8}, nil)
+				matchNotified(8, savedSuperLike)
+				mockIndexer.EXPECT().AddSwiped(ctx, userID, targetID).Return(nil)
+				mockIndexer.EXPECT().AddSuperLike(ctx, targetID, userID).Return(nil)
+			},
+			expectedResp: MatchResponse{Matched: true, MatchID: 8},
+		},
+		{
+			name:       "daily super like limit reached",
+			preference: preferenceSuper,
+			setupMocks: func() {
+				mockBlocks.EXPECT().IsBlocked(ctx, userID, targetID).Return(false, nil)
+				mockSwiper.EXPECT().FindSwipe(ctx, userID, targetID).Return(entity.Swipe{}, entity.ErrSwipeNotFound)
+				mockSwiper.EXPECT().CountSuperLikes(ctx, userID, startOfDay).Return(DefaultSuperLikesPerDay, nil)
+			},
+			expectedError: entity.ErrSuperLikeLimitReached,
+		},
+		{
+			name:       "repeated super like is not counted again",
+			preference: preferenceSuper,
+			setupMocks: func() {
+				mockBlocks.EXPECT().IsBlocked(ctx, userID, targetID).Return(false, nil)
+				mockSwiper.EXPECT().FindSwipe(ctx, userID, targetID).Return(entity.Swipe{ID: 3, Preference: preferenceSuper}, nil)
+				mockMatcher.EXPECT().FindMatchByPair(ctx, userID, targetID).Return(entity.Match{}, entity.ErrMatchNotFound)
+				mockIndexer.EXPECT().AddSwiped(ctx, userID, targetID).Return(nil)
+				mockIndexer.EXPECT().AddSuperLike(ctx, targetID, userID).Return(nil)
+			},
+			expectedResp: MatchResponse{Matched: false},
+		},
+		{
+			name:       "retrying a matched super like reports the match and indexes it again",
+			preference: preferenceSuper,
+			setupMocks: func() {
+				mockBlocks.EXPECT().IsBlocked(ctx, userID, targetID).Return(false, nil)
+				mockSwiper.EXPECT().FindSwipe(ctx, userID, targetID).Return(entity.Swipe{ID: 3, Preference: preferenceSuper}, nil)
+				mockMatcher.EXPECT().FindMatchByPair(ctx, userID, targetID).Return(entity.Match{ID: 8}, nil)
+				mockIndexer.EXPECT().AddSwiped(ctx, userID, targetID).Return(nil)
+				mockIndexer.EXPECT().AddSuperLike(ctx, targetID, userID).Return(nil)
+			},
+			expectedResp: MatchResponse{Matched: true, MatchID: 8},
+		},
+		{
+			name:       "changing a super like to yes removes the boost without taking another like",
+			preference: preferenceYes,
+			setupMocks: func() {
+				mockBlocks.EXPECT().IsBlocked(ctx, userID, targetID).Return(false, nil)
+				mockSwiper.EXPECT().FindSwipe(ctx, userID, targetID).Return(entity.Swipe{ID: 3, Preference: preferenceSuper}, nil)
+				mockMatcher.EXPECT().FindMatchByPair(ctx, userID, targetID).Return(entity.Match{}, entity.ErrMatchNotFound)
+				// Still the one like, so no more quota is taken.
 				mockSwiper.EXPECT().SaveSwipe(ctx, entity.Swipe{
 					UserID:     userID,
 					TargetID:   targetID,
-					Preference: preferenceNo,
+					Preference: preferenceYes,
+				}).Return(nil)
+				mockSwiper.EXPECT().RecordSwipeEvent(ctx, entity.SwipeEvent{
+					UserID:     userID,
+					TargetID:   targetID,
+					Preference: preferenceYes,
+					Action:     entity.SwipeActionReswipe,
 				}).Return(nil)
+				mockSwiper.EXPECT().HasLiked(ctx, targetID, userID).Return(false, nil)
+				mockIndexer.EXPECT().AddSwiped(ctx, userID, targetID).Return(nil)
+				mockIndexer.EXPECT().RemoveSuperLike(ctx, targetID, userID).Return(nil)
+			},
+			expectedResp: MatchResponse{Matched: false},
+		},
+		{
+			name:       "error notifying super like",
+			preference: preferenceSuper,
+			setupMocks: func() {
+				mockBlocks.EXPECT().IsBlocked(ctx, userID, targetID).Return(false, nil)
+				mockSwiper.EXPECT().FindSwipe(ctx, userID, targetID).Return(entity.Swipe{}, entity.ErrSwipeNotFound)
+				mockSwiper.EXPECT().CountSuperLikes(ctx, userID, startOfDay).Return(0, nil)
+				saves(preferenceSuper, entity.SwipeActionSwipe)
+				mockNotifier.EXPECT().Notify(ctx, superLikeNotification).Return(entity.Notification{}, fmt.Errorf("db error"))
+				mockLimiter.EXPECT().Refund(ctx, userID, now)
+			},
+			expectedError: fmt.Errorf("failed to notify target: db error"),
+		},
+		{
+			name:       "error notifying match",
+			preference: preferenceYes,
+			setupMocks: func() {
+				mockBlocks.EXPECT().IsBlocked(ctx, userID, targetID).Return(false, nil)
+				mockSwiper.EXPECT().FindSwipe(ctx, userID, targetID).Return(entity.Swipe{}, entity.ErrSwipeNotFound)
+				saves(preferenceYes, entity.SwipeActionSwipe)
+				mockSwiper.EXPECT().HasLiked(ctx, targetID, userID).Return(true, nil)
+				mockMatcher.EXPECT().CreateMatch(ctx, entity.NewMatch(userID, targetID)).Return(entity.Match{ID: 6}, nil)
+				mockNotifier.EXPECT().Notify(ctx, gomock.Any()).Return(entity.Notification{}, fmt.Errorf("db error"))
+				mockLimiter.EXPECT().Refund(ctx, userID, now)
+			},
+			expectedError: fmt.Errorf("failed to notify match: db error"),
+		},
+		{
+			name:       "failing to push does not fail the swipe",
+			preference: preferenceSuper,
+			setupMocks: func() {
+				mockBlocks.EXPECT().IsBlocked(ctx, userID, targetID).Return(false, nil)
+				mockSwiper.EXPECT().FindSwipe(ctx, userID, targetID).Return(entity.Swipe{}, entity.ErrSwipeNotFound)
+				mockSwiper.EXPECT().CountSuperLikes(ctx, userID, startOfDay).Return(0, nil)
+				saves(preferenceSuper, entity.SwipeActionSwipe)
+				mockNotifier.EXPECT().Notify(ctx, superLikeNotification).Return(savedSuperLike, nil)
+				mockSwiper.EXPECT().HasLiked(ctx, targetID, userID).Return(false, nil)
+				mockNotifier.EXPECT().Push(ctx, []entity.Notification{savedSuperLike}).Return(fmt.Errorf("hub error"))
+				mockIndexer.EXPECT().AddSwiped(ctx, userID, targetID).Return(nil)
+				mockIndexer.EXPECT().AddSuperLike(ctx, targetID, userID).Return(nil)
+			},
+			expectedResp: MatchResponse{Matched: false},
+		},
+		{
+			name:       "like quota used up",
+			preference: preferenceYes,
+			setupMocks: func() {
+				mockBlocks.EXPECT().IsBlocked(ctx, userID, targetID).Return(false, nil)
+				mockSwiper.EXPECT().FindSwipe(ctx, userID, targetID).Return(entity.Swipe{}, entity.ErrSwipeNotFound)
+				mockLimiter.EXPECT().Take(ctx, userID, likeQuota, now).Return(entity.Quota{}, entity.QuotaExceededError{
+					ResetAt: now.Add(time.Hour),
+				})
+			},
+			expectedError: fmt.Errorf("failed to take like quota: %w", entity.QuotaExceededError{ResetAt: now.Add(time.Hour)}),
+		},
+		{
+			name:       "blocked either way",
+			preference: preferenceYes,
+			setupMocks: func() {
+				mockBlocks.EXPECT().IsBlocked(ctx, userID, targetID).Return(true, nil)
+			},
+			expectedError: entity.ErrBlocked,
+		},
+		{
+			name:       "error finding previous swipe",
+			preference: preferenceYes,
+			setupMocks: func() {
+				mockBlocks.EXPECT().IsBlocked(ctx, userID, targetID).Return(false, nil)
+				mockSwiper.EXPECT().FindSwipe(ctx, userID, targetID).Return(entity.Swipe{}, fmt.Errorf("db error"))
 			},
-			mockMatcher:     func() {},
-			expectedResp:    MatchResponse{Matched: false},
-			expectedError:   nil,
-			shouldCallMatch: false,
+			expectedError: fmt.Errorf("failed to find previous swipe: db error"),
 		},
 		{
 			name:       "error saving swipe",
-			userID:     userID,
-			targetID:   targetID,
 			preference: preferenceYes,
-			mockSwiper: func() {
+			setupMocks: func() {
+				mockBlocks.EXPECT().IsBlocked(ctx, userID, targetID).Return(false, nil)
+				mockSwiper.EXPECT().FindSwipe(ctx, userID, targetID).Return(entity.Swipe{}, entity.ErrSwipeNotFound)
+				mockLimiter.EXPECT().Take(ctx, userID, likeQuota, now).Return(entity.Quota{}, nil)
 				mockSwiper.EXPECT().SaveSwipe(ctx, entity.Swipe{
 					UserID:     userID,
 					TargetID:   targetID,
 					Preference: preferenceYes,
 				}).Return(fmt.Errorf("db error"))
+				mockLimiter.EXPECT().Refund(ctx, userID, now)
 			},
-			mockMatcher:     func() {},
-			expectedResp:    MatchResponse{},
-			expectedError:   fmt.Errorf("failed to save swipe: db error"),
-			shouldCallMatch: false,
+			expectedError: fmt.Errorf("failed to save swipe: db error"),
 		},
 		{
-			name:       "error getting yes swipes",
-			userID:     userID,
-			targetID:   targetID,
+			name:       "error indexing swipe",
 			preference: preferenceYes,
-			mockSwiper: func() {
-				mockSwiper.EXPECT().SaveSwipe(ctx, entity.Swipe{
-					UserID:     userID,
-					TargetID:   targetID,
-					Preference: preferenceYes,
-				}).Return(nil)
-				mockSwiper.EXPECT().GetUsersYesSwipes(ctx, gomock.Any()).Return(nil, fmt.Errorf("db error"))
+			setupMocks: func() {
+				mockBlocks.EXPECT().IsBlocked(ctx, userID, targetID).Return(false, nil)
+				mockSwiper.EXPECT().FindSwipe(ctx, userID, targetID).Return(entity.Swipe{}, entity.ErrSwipeNotFound)
+				saves(preferenceYes, entity.SwipeActionSwipe)
+				mockSwiper.EXPECT().HasLiked(ctx, targetID, userID).Return(false, nil)
+				mockIndexer.EXPECT().AddSwiped(ctx, userID, targetID).Return(fmt.Errorf("es error"))
 			},
-			mockMatcher:     func() {},
-			expectedResp:    MatchResponse{},
-			expectedError:   fmt.Errorf("failed to get user's yes swipes: db error"),
-			shouldCallMatch: false,
+			expectedError: fmt.Errorf("failed to index swipe: es error"),
 		},
 		{
-			name:       "error creating match",
-			userID:     userID,
-			targetID:   targetID,
+			name:       "error getting target's swipe",
 			preference: preferenceYes,
-			mockSwiper: func() {
-				mockSwiper.EXPECT().SaveSwipe(ctx, entity.Swipe{
-					UserID:     userID,
-					TargetID:   targetID,
-					Preference: preferenceYes,
-				}).Return(nil)
-				mockSwiper.EXPECT().GetUsersYesSwipes(ctx, targetID).Return(map[int]entity.Swipe{
-					userID: {UserID: userID, TargetID: targetID, Preference: preferenceYes},
-				}, nil)
+			setupMocks: func() {
+				mockBlocks.EXPECT().IsBlocked(ctx, userID, targetID).Return(false, nil)
+				mockSwiper.EXPECT().FindSwipe(ctx, userID, targetID).Return(entity.Swipe{}, entity.ErrSwipeNotFound)
+				saves(preferenceYes, entity.SwipeActionSwipe)
+				mockSwiper.EXPECT().HasLiked(ctx, targetID, userID).Return(false, fmt.Errorf("db error"))
+				mockLimiter.EXPECT().Refund(ctx, userID, now)
 			},
-			mockMatcher: func() {
+			expectedError: fmt.Errorf("failed to get target's swipe: db error"),
+		},
+		{
+			name:       "error creating match",
+			preference: preferenceYes,
+			setupMocks: func() {
+				mockBlocks.EXPECT().IsBlocked(ctx, userID, targetID).Return(false, nil)
+				mockSwiper.EXPECT().FindSwipe(ctx, userID, targetID).Return(entity.Swipe{}, entity.ErrSwipeNotFound)
+				saves(preferenceYes, entity.SwipeActionSwipe)
+				mockSwiper.EXPECT().HasLiked(ctx, targetID, userID).Return(true, nil)
 				mockMatcher.EXPECT().CreateMatch(ctx, gomock.Any()).Return(entity.Match{}, fmt.Errorf("db error"))
+				mockLimiter.EXPECT().Refund(ctx, userID, now)
 			},
-			expectedResp:    MatchResponse{},
-			expectedError:   fmt.Errorf("failed to create match: db error"),
-			shouldCallMatch: true,
+			expectedError: fmt.Errorf("failed to create match: db error"),
 		},
 	}
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			tt.mockSwiper()
-
-			if tt.shouldCallMatch {
-				tt.mockMatcher()
-			}
+			tt.setupMocks()
 
-			resp, err := service.Swipe(ctx, tt.userID, tt.targetID, tt.preference)
+			resp, err := service.Swipe(ctx, userID, targetID, tt.preference)
 
 			if tt.expectedError != nil {
 				assert.EqualError(t, err, tt.expectedError.Error())
@@ -178,4 +424,382 @@ func TestService_Swipe(t *testing.T) {
 			assert.Equal(t, tt.expectedResp, resp)
 		})
 	}
+
+	t.Run("swiping on yourself", func(t *testing.T) {
+		resp, err := service.Swipe(ctx, userID, userID, preferenceYes)
+
+		assert.ErrorIs(t, err, entity.ErrInvalidParam)
+		assert.Equal(t, MatchResponse{}, resp)
+	})
+}
+
+func TestService_Swipe_RefundsRetriedAttempts(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockSwiper := mocks.NewMockswiper(ctrl)
+	mockIndexer := mocks.NewMockswipedIndexer(ctrl)
+	mockMatcher := mocks.NewMockmatcher(ctrl)
+	mockBlocks := mocks.NewMockblockChecker(ctrl)
+	mockNotifier := mocks.NewMocknotifier(ctrl)
+	mockLimiter := mocks.NewMocklikeLimiter(ctrl)
+	mockUnitOfWork := mocks.NewMockunitOfWork(ctrl)
+	service := NewService(
+		zap.NewNop().Sugar(), Config{},
+		mockSwiper, mockIndexer, mockMatcher, mockBlocks, mockNotifier, mockLimiter, mockUnitOfWork,
+	)
+	now := time.Date(2024, 6, 29, 9, 0, 0, 0, time.UTC)
+	service.now = func() time.Time {
+		return now
+	}
+
+	ctx := context.Background()
+	swipe := entity.Swipe{UserID: 1, TargetID: 2, Preference: entity.PreferenceYes}
+
+	// The first attempt is rolled back to break a deadlock and run again.
+	mockUnitOfWork.EXPECT().Do(ctx, gomock.Any()).DoAndReturn(
+		func(ctx context.Context, fn func(ctx context.Context) error) error {
+			_ = fn(ctx)
+
+			return fn(ctx)
+		})
+
+	mockBlocks.EXPECT().IsBlocked(ctx, 1, 2).Return(false, nil).Times(2)
+	mockSwiper.EXPECT().FindSwipe(ctx, 1, 2).Return(entity.Swipe{}, entity.ErrSwipeNotFound).Times(2)
+	mockLimiter.EXPECT().Take(ctx, 1, gomock.Any(), now).Return(entity.Quota{}, nil).Times(2)
+	mockSwiper.EXPECT().SaveSwipe(ctx, swipe).Return(nil).Times(2)
+	mockSwiper.EXPECT().RecordSwipeEvent(ctx, gomock.Any()).Return(nil).Times(2)
+	mockSwiper.EXPECT().HasLiked(ctx, 2, 1).Return(false, nil).Times(2)
+	mockIndexer.EXPECT().AddSwiped(ctx, 1, 2).Return(nil)
+
+	// Only the like taken by the attempt that was rolled back is given back.
+	mockLimiter.EXPECT().Refund(ctx, 1, now)
+
+	resp, err := service.Swipe(ctx, 1, 2, entity.PreferenceYes)
+	assert.NoError(t, err)
+	assert.Equal(t, MatchResponse{}, resp)
+}
+
+func TestService_Rewind(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockSwiper := mocks.NewMockswiper(ctrl)
+	mockIndexer := mocks.NewMockswipedIndexer(ctrl)
+	mockMatcher := mocks.NewMockmatcher(ctrl)
+	mockBlocks := mocks.NewMockblockChecker(ctrl)
+	mockNotifier := mocks.NewMocknotifier(ctrl)
+	mockLimiter := mocks.NewMocklikeLimiter(ctrl)
+	mockUnitOfWork := mocks.NewMockunitOfWork(ctrl)
+	service := NewService(
+		zap.NewNop().Sugar(), Config{RewindsPerDay: 2},
+		mockSwiper, mockIndexer, mockMatcher, mockBlocks, mockNotifier, mockLimiter, mockUnitOfWork,
+	)
+	service.now = func() time.Time {
+		return time.Date(2024, 6, 28, 15, 30, 0, 0, time.UTC)
+	}
+
+	ctx := context.Background()
+	startOfDay := time.Date(2024, 6, 28, 0, 0, 0, 0, time.UTC)
+	last := entity.Swipe{ID: 7, UserID: 1, TargetID: 3, Preference: entity.PreferenceNo}
+
+	mockUnitOfWork.EXPECT().Do(ctx, gomock.Any()).DoAndReturn(
+		func(ctx context.Context, fn func(ctx context.Context) error) error {
+			return fn(ctx)
+		}).AnyTimes()
+
+	tests := []struct {
+		name          string
+		setupMocks    func()
+		expected      entity.Swipe
+		expectedError error
+	}{
+		{
+			name: "rewinds the last swipe",
+			setupMocks: func() {
+				mockSwiper.EXPECT().LastSwipe(ctx, 1).Return(last, nil)
+				mockSwiper.EXPECT().CountSwipeEvents(ctx, 1, entity.SwipeActionRewind, startOfDay).Return(1, nil)
+				mockMatcher.EXPECT().FindMatchByPair(ctx, 1, 3).Return(entity.Match{}, entity.ErrMatchNotFound)
+				mockSwiper.EXPECT().DeleteSwipe(ctx, 7).Return(nil)
+				mockSwiper.EXPECT().RecordSwipeEvent(ctx, entity.SwipeEvent{
+					UserID:     1,
+					TargetID:   3,
+					Preference: entity.PreferenceNo,
+					Action:     entity.SwipeActionRewind,
+				}).Return(nil)
+				mockIndexer.EXPECT().RemoveSwiped(ctx, 1, 3).Return(nil)
+			},
+			expected: last,
+		},
+		{
+			name: "rewinding a super like removes the boost",
+			setupMocks: func() {
+				superLike := entity.Swipe{ID: 8, UserID: 1, TargetID: 4, Preference: entity.PreferenceSuper}
+
+				mockSwiper.EXPECT().LastSwipe(ctx, 1).Return(superLike, nil)
+				mockSwiper.EXPECT().CountSwipeEvents(ctx, 1, entity.SwipeActionRewind, startOfDay).Return(0, nil)
+				mockMatcher.EXPECT().FindMatchByPair(ctx, 1, 4).Return(entity.Match{}, entity.ErrMatchNotFound)
+				mockSwiper.EXPECT().DeleteSwipe(ctx, 8).Return(nil)
+				mockSwiper.EXPECT().RecordSwipeEvent(ctx, gomock.Any()).Return(nil)
+				mockIndexer.EXPECT().RemoveSwiped(ctx, 1, 4).Return(nil)
+				mockIndexer.EXPECT().RemoveSuperLike(ctx, 4, 1).Return(nil)
+			},
+			expected: entity.Swipe{ID: 8, UserID: 1, TargetID: 4, Preference: entity.PreferenceSuper},
+		},
+		{
+			name: "nothing to rewind",
+			setupMocks: func() {
+				mockSwiper.EXPECT().LastSwipe(ctx, 1).Return(entity.Swipe{}, entity.ErrSwipeNotFound)
+			},
+			expectedError: fmt.Errorf("failed to find last swipe: %w", entity.ErrSwipeNotFound),
+		},
+		{
+			name: "daily limit reached",
+			setupMocks: func() {
+				mockSwiper.EXPECT().LastSwipe(ctx, 1).Return(last, nil)
+				mockSwiper.EXPECT().CountSwipeEvents(ctx, 1, entity.SwipeActionRewind, startOfDay).Return(2, nil)
+			},
+			expectedError: entity.ErrRewindLimitReached,
+		},
+		{
+			name: "cannot rewind a swipe that matched",
+			setupMocks: func() {
+				mockSwiper.EXPECT().LastSwipe(ctx, 1).Return(last, nil)
+				mockSwiper.EXPECT().CountSwipeEvents(ctx, 1, entity.SwipeActionRewind, startOfDay).Return(0, nil)
+				mockMatcher.EXPECT().FindMatchByPair(ctx, 1, 3).Return(entity.Match{ID: 5}, nil)
+			},
+			expectedError: entity.ErrSwipeLocked,
+		},
+		{
+			name: "error indexing rewind",
+			setupMocks: func() {
+				mockSwiper.EXPECT().LastSwipe(ctx, 1).Return(last, nil)
+				mockSwiper.EXPECT().CountSwipeEvents(ctx, 1, entity.SwipeActionRewind, startOfDay).Return(0, nil)
+				mockMatcher.EXPECT().FindMatchByPair(ctx, 1, 3).Return(entity.Match{}, entity.ErrMatchNotFound)
+				mockSwiper.EXPECT().DeleteSwipe(ctx, 7).Return(nil)
+				mockSwiper.EXPECT().RecordSwipeEvent(ctx, gomock.Any()).Return(nil)
+				mockIndexer.EXPECT().RemoveSwiped(ctx, 1, 3).Return(fmt.Errorf("es error"))
+			},
+			expectedError: fmt.Errorf("failed to index rewind: es error"),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.setupMocks()
+
+			swipe, err := service.Rewind(ctx, 1)
+
+			if tt.expectedError != nil {
+				assert.EqualError(t, err, tt.expectedError.Error())
+				return
+			}
+
+			assert.NoError(t, err)
+			assert.Equal(t, tt.expected, swipe)
+		})
+	}
+}
+
+func TestService_Quota(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockLimiter := mocks.NewMocklikeLimiter(ctrl)
+	service := NewService(
+		zap.NewNop().Sugar(), Config{LikesPerWindow: 50, LikeWindow: 12 * time.Hour}, nil, nil, nil, nil, nil, mockLimiter, nil,
+	)
+	now := time.Date(2024, 6, 30, 9, 0, 0, 0, time.UTC)
+	service.now = func() time.Time {
+		return now
+	}
+
+	ctx := context.Background()
+	rule := entity.QuotaRule{Limit: 50, Window: 12 * time.Hour}
+
+	tests := []struct {
+		name          string
+		setupMocks    func()
+		expected      entity.Quota
+		expectedError error
+	}{
+		{
+			name: "returns the configured quota",
+			setupMocks: func() {
+				mockLimiter.EXPECT().Quota(ctx, 1, rule, now).Return(entity.Quota{Limit: 50, Used: 3, Remaining: 47}, nil)
+			},
+			expected: entity.Quota{Limit: 50, Used: 3, Remaining: 47},
+		},
+		{
+			name: "limiter failure",
+			setupMocks: func() {
+				mockLimiter.EXPECT().Quota(ctx, 1, rule, now).Return(entity.Quota{}, fmt.Errorf("db error"))
+			},
+			expectedError: fmt.Errorf("failed to get like quota: db error"),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.setupMocks()
+
+			quota, err := service.Quota(ctx, 1)
+
+			if tt.expectedError != nil {
+				assert.EqualError(t, err, tt.expectedError.Error())
+				return
+			}
+
+			assert.NoError(t, err)
+			assert.Equal(t, tt.expected, quota)
+		})
+	}
+}
+
+func TestService_SwipeBatch(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockSwiper := mocks.NewMockswiper(ctrl)
+	mockIndexer := mocks.NewMockswipedIndexer(ctrl)
+	mockMatcher := mocks.NewMockmatcher(ctrl)
+	mockBlocks := mocks.NewMockblockChecker(ctrl)
+	mockNotifier := mocks.NewMocknotifier(ctrl)
+	mockLimiter := mocks.NewMocklikeLimiter(ctrl)
+	mockUnitOfWork := mocks.NewMockunitOfWork(ctrl)
+	service := NewService(
+		zap.NewNop().Sugar(), Config{},
+		mockSwiper, mockIndexer, mockMatcher, mockBlocks, mockNotifier, mockLimiter, mockUnitOfWork,
+	)
+
+	ctx := context.Background()
+	swipedAt := time.Date(2024, 7, 1, 8, 0, 0, 0, time.UTC)
+
+	mockUnitOfWork.EXPECT().Do(ctx, gomock.Any()).DoAndReturn(
+		func(ctx context.Context, fn func(ctx context.Context) error) error {
+			return fn(ctx)
+		}).AnyTimes()
+
+	passes := func(key string, target int) {
+		swipe := entity.Swipe{UserID: 1, TargetID: target, Preference: entity.PreferenceNo}
+
+		mockSwiper.EXPECT().FindSwipeKey(ctx, 1, key).Return(entity.SwipeKey{}, entity.ErrSwipeKeyNotFound)
+		mockBlocks.EXPECT().IsBlocked(ctx, 1, target).Return(false, nil)
+		mockSwiper.EXPECT().FindSwipe(ctx, 1, target).Return(entity.Swipe{}, entity.ErrSwipeNotFound)
+		mockSwiper.EXPECT().SaveSwipe(ctx, swipe).Return(nil)
+		mockSwiper.EXPECT().RecordSwipeEvent(ctx, gomock.Any()).Return(nil)
+		mockSwiper.EXPECT().SaveSwipeKey(ctx, entity.SwipeKey{
+			UserID:     1,
+			Key:        key,
+			TargetID:   target,
+			Preference: entity.PreferenceNo,
+		}).Return(nil)
+		mockIndexer.EXPECT().AddSwiped(ctx, 1, target).Return(nil)
+	}
+
+	t.Run("applies swipes in the order they were made", func(t *testing.T) {
+		later := entity.BatchSwipe{Key: "b", TargetID: 3, Preference: entity.PreferenceNo, SwipedAt: swipedAt.Add(time.Minute)}
+		earlier := entity.BatchSwipe{Key: "a", TargetID: 2, Preference: entity.PreferenceNo, SwipedAt: swipedAt}
+
+		gomock.InOrder(
+			mockSwiper.EXPECT().FindSwipeKey(ctx, 1, "a").Return(entity.SwipeKey{}, entity.ErrSwipeKeyNotFound),
+			mockSwiper.EXPECT().FindSwipeKey(ctx, 1, "b").Return(entity.SwipeKey{}, entity.ErrSwipeKeyNotFound),
+		)
+
+		for _, swipe := range []entity.BatchSwipe{earlier, later} {
+			mockBlocks.EXPECT().IsBlocked(ctx, 1, swipe.TargetID).Return(false, nil)
+			mockSwiper.EXPECT().FindSwipe(ctx, 1, swipe.TargetID).Return(entity.Swipe{}, entity.ErrSwipeNotFound)
+			mockSwiper.EXPECT().SaveSwipe(ctx, gomock.Any()).Return(nil)
+			mockSwiper.EXPECT().RecordSwipeEvent(ctx, gomock.Any()).Return(nil)
+			mockSwiper.EXPECT().SaveSwipeKey(ctx, gomock.Any()).Return(nil)
+			mockIndexer.EXPECT().AddSwiped(ctx, 1, swipe.TargetID).Return(nil)
+		}
+
+		results := service.SwipeBatch(ctx, 1, []entity.BatchSwipe{later, earlier})
+
+		assert.Equal(t, []BatchResult{{Swipe: later}, {Swipe: earlier}}, results)
+	})
+
+	t.Run("replays a key sent before", func(t *testing.T) {
+		swipe := entity.BatchSwipe{Key: "a", TargetID: 2, Preference: entity.PreferenceYes, SwipedAt: swipedAt}
+
+		mockSwiper.EXPECT().FindSwipeKey(ctx, 1, "a").Return(entity.SwipeKey{
+			UserID:     1,
+			Key:        "a",
+			TargetID:   2,
+			Preference: entity.PreferenceYes,
+			MatchID:    9,
+		}, nil)
+		// The swipe is indexed again as it is now, which may not be how the key
+		// left it.
+		mockSwiper.EXPECT().FindSwipe(ctx, 1, 2).Return(entity.Swipe{ID: 5, Preference: entity.PreferenceSuper}, nil)
+		mockIndexer.EXPECT().AddSwiped(ctx, 1, 2).Return(nil)
+		mockIndexer.EXPECT().AddSuperLike(ctx, 2, 1).Return(nil)
+
+		results := service.SwipeBatch(ctx, 1, []entity.BatchSwipe{swipe})
+
+		assert.Equal(t, []BatchResult{{
+			Swipe:    swipe,
+			Match:    MatchResponse{Matched: true, MatchID: 9},
+			Replayed: true,
+		}}, results)
+	})
+
+	t.Run("replaying a rewound swipe indexes nothing", func(t *testing.T) {
+		swipe := entity.BatchSwipe{Key: "a", TargetID: 2, Preference: entity.PreferenceNo, SwipedAt: swipedAt}
+
+		mockSwiper.EXPECT().FindSwipeKey(ctx, 1, "a").Return(entity.SwipeKey{
+			UserID:     1,
+			Key:        "a",
+			TargetID:   2,
+			Preference: entity.PreferenceNo,
+		}, nil)
+		mockSwiper.EXPECT().FindSwipe(ctx, 1, 2).Return(entity.Swipe{}, entity.ErrSwipeNotFound)
+
+		results := service.SwipeBatch(ctx, 1, []entity.BatchSwipe{swipe})
+
+		assert.Equal(t, []BatchResult{{Swipe: swipe, Replayed: true}}, results)
+	})
+
+	t.Run("replays retry indexing", func(t *testing.T) {
+		swipe := entity.BatchSwipe{Key: "a", TargetID: 2, Preference: entity.PreferenceYes, SwipedAt: swipedAt}
+
+		mockSwiper.EXPECT().FindSwipeKey(ctx, 1, "a").Return(entity.SwipeKey{
+			UserID:     1,
+			Key:        "a",
+			TargetID:   2,
+			Preference: entity.PreferenceYes,
+		}, nil)
+		mockSwiper.EXPECT().FindSwipe(ctx, 1, 2).Return(entity.Swipe{ID: 5, Preference: entity.PreferenceYes}, nil)
+		mockIndexer.EXPECT().AddSwiped(ctx, 1, 2).Return(fmt.Errorf("es error"))
+
+		results := service.SwipeBatch(ctx, 1, []entity.BatchSwipe{swipe})
+
+		assert.Len(t, results, 1)
+		assert.EqualError(t, results[0].Err, "failed to index swipe: es error")
+	})
+
+	t.Run("reports failures item by item", func(t *testing.T) {
+		reused := entity.BatchSwipe{Key: "a", TargetID: 2, Preference: entity.PreferenceYes, SwipedAt: swipedAt}
+		invalid := entity.BatchSwipe{Key: "b", TargetID: 0, Preference: entity.PreferenceNo, SwipedAt: swipedAt}
+		ok := entity.BatchSwipe{Key: "c", TargetID: 4, Preference: entity.PreferenceNo, SwipedAt: swipedAt}
+		yourself := entity.BatchSwipe{Key: "d", TargetID: 1, Preference: entity.PreferenceYes, SwipedAt: swipedAt}
+
+		mockSwiper.EXPECT().FindSwipeKey(ctx, 1, "a").Return(entity.SwipeKey{
+			UserID:     1,
+			Key:        "a",
+			TargetID:   3,
+			Preference: entity.PreferenceYes,
+		}, nil)
+		passes("c", 4)
+		mockSwiper.EXPECT().FindSwipeKey(ctx, 1, "d").Return(entity.SwipeKey{}, entity.ErrSwipeKeyNotFound)
+
+		results := service.SwipeBatch(ctx, 1, []entity.BatchSwipe{reused, invalid, ok, yourself})
+
+		assert.Len(t, results, 4)
+		assert.ErrorIs(t, results[0].Err, entity.ErrSwipeKeyReused)
+		assert.EqualError(t, results[1].Err, "target_id: cannot be blank.")
+		assert.Equal(t, BatchResult{Swipe: ok}, results[2])
+		assert.ErrorIs(t, results[3].Err, entity.ErrInvalidParam)
+	})
 }
@@ -2,11 +2,9 @@ package user
 
 import (
 	"context"
+	"errors"
 	"fmt"
-	"regexp"
-	"strings"
-
-	faker "github.com/bxcodec/faker/v3"
+	"time"
 
 	"github.com/colmmurphy91/muzz/internal/adapter/mysql/user/model"
 	"github.com/colmmurphy91/muzz/internal/entity"
@@ -14,80 +12,188 @@ import (
 
 //go:generate mockgen -source $GOFILE -destination mocks/mocks_${GOFILE} -package mocks
 
-type userCreator interface {
+type userStore interface {
 	CreateUser(ctx context.Context, user model.User) (model.User, error)
+	FindByID(ctx context.Context, userID int) (model.User, error)
+	UpdateProfile(ctx context.Context, user model.User) error
+	UpdateLocation(ctx context.Context, userID int, loc entity.Location, at time.Time) error
 }
 
 type userIndexer interface {
 	Index(ctx context.Context, user entity.User) error
+	UpdateProfile(ctx context.Context, user entity.User) error
+	UpdateLocation(ctx context.Context, userID int, loc entity.Location) error
 }
 
-type Manager struct {
-	userCreator userCreator
-	userIndexer userIndexer
+type passwordHasher interface {
+	Hash(password string) (string, error)
 }
 
-func NewManager(creator userCreator, indexer userIndexer) *Manager {
-	return &Manager{userCreator: creator, userIndexer: indexer}
+type unitOfWork interface {
+	Do(ctx context.Context, fn func(ctx context.Context) error) error
 }
 
-func (m *Manager) CreateUser(ctx context.Context) (entity.User, error) {
-	user := generateFakeUser()
+type Manager struct {
+	userStore      userStore
+	userIndexer    userIndexer
+	passwordHasher passwordHasher
+	unitOfWork     unitOfWork
+	now            func() time.Time
+}
 
-	dbUser, err := m.userCreator.CreateUser(ctx, user)
+func NewManager(store userStore, indexer userIndexer, hasher passwordHasher, unitOfWork unitOfWork) *Manager {
+	return &Manager{
+		userStore:      store,
+		userIndexer:    indexer,
+		passwordHasher: hasher,
+		unitOfWork:     unitOfWork,
+		now:            time.Now,
+	}
+}
+
+// CreateUser registers a user and makes them discoverable.
+func (m *Manager) CreateUser(ctx context.Context, registration entity.Registration) (model.User, error) {
+	passwordHash, err := m.passwordHasher.Hash(registration.Password)
 	if err != nil {
-		return entity.User{}, fmt.Errorf("failed to create user: %w", err)
+		return model.User{}, fmt.Errorf("failed to hash password: %w", err)
 	}
 
-	user.ID = dbUser.ID
+	location := registration.Location.Fuzzed()
+
+	user := model.User{
+		Email:       registration.Email,
+		Password:    passwordHash,
+		Name:        registration.Name,
+		Gender:      registration.Gender,
+		DateOfBirth: registration.DateOfBirth,
+		Age:         entity.AgeAt(registration.DateOfBirth, time.Now()),
+		Lat:         location.Lat,
+		Lon:         location.Lon,
+	}
 
-	newUser := entity.User{
-		ID:       dbUser.ID,
-		Email:    dbUser.Email,
-		Name:     dbUser.Name,
-		Password: dbUser.Password,
-		Gender:   dbUser.Gender,
-		Age:      dbUser.Age,
-		Location: entity.Location{
-			Lat: dbUser.Lat,
-			Lon: dbUser.Lon,
-		},
+	dbUser, err := m.userStore.CreateUser(ctx, user)
+	if err != nil {
+		return model.User{}, fmt.Errorf("failed to create user: %w", err)
 	}
 
-	indexErr := m.userIndexer.Index(ctx, newUser)
+	indexErr := m.userIndexer.Index(ctx, dbUser.Profile())
 	if indexErr != nil {
-		return entity.User{}, fmt.Errorf("failed to index: %w", indexErr)
+		return model.User{}, fmt.Errorf("failed to index: %w", indexErr)
+	}
+
+	return dbUser, nil
+}
+
+// Profile returns the user's own profile.
+func (m *Manager) Profile(ctx context.Context, userID int) (model.User, error) {
+	user, err := m.userStore.FindByID(ctx, userID)
+	if err != nil {
+		return model.User{}, fmt.Errorf("failed to find user: %w", err)
 	}
 
-	return newUser, nil
+	return user, nil
 }
 
-func generateFakeUser() model.User {
-	p, _ := faker.RandomInt(18, 70, 1)
+// UpdateProfile changes the details set in update and reindexes the user, so
+// discovery shows the change straight away. It fails with
+// entity.ErrVersionConflict when the profile has changed since update.Version
+// was read.
+func (m *Manager) UpdateProfile(ctx context.Context, userID int, update entity.ProfileUpdate) (model.User, error) {
+	user, err := m.userStore.FindByID(ctx, userID)
+	if err != nil {
+		return model.User{}, fmt.Errorf("failed to find user: %w", err)
+	}
+
+	if user.Version != update.Version {
+		return model.User{}, entity.ErrVersionConflict
+	}
+
+	if update.Name.Valid {
+		user.Name = update.Name.String
+	}
+
+	if update.Bio.Valid {
+		user.Bio = update.Bio.String
+	}
+
+	if update.Gender.Valid {
+		user.Gender = update.Gender.String
+	}
+
+	if update.DateOfBirth.Valid {
+		user.DateOfBirth = update.DateOfBirth.Time
+		user.Age = entity.AgeAt(user.DateOfBirth, time.Now())
+	}
+
+	// The index is updated last, so failing to update it rolls the edit back
+	// and it can be retried with the same version.
+	err = m.unitOfWork.Do(ctx, func(ctx context.Context) error {
+		if err := m.userStore.UpdateProfile(ctx, user); err != nil {
+			return fmt.Errorf("failed to update profile: %w", err)
+		}
 
-	name := faker.Name()
-	sanitizedName := sanitizeName(name)
-	email := fmt.Sprintf("%s@muzz.com", sanitizedName)
+		if err := m.userIndexer.UpdateProfile(ctx, user.Profile()); err != nil {
+			return fmt.Errorf("failed to index: %w", err)
+		}
 
-	return model.User{
-		Email:    email,
-		Password: "Password1",
-		Name:     name,
-		Gender:   faker.Gender(),
-		Age:      p[0],
-		Lon:      faker.Longitude(),
-		Lat:      faker.Latitude(),
+		return nil
+	})
+	if err != nil {
+		return model.User{}, err
 	}
+
+	user.Version++
+
+	return user, nil
 }
 
-func sanitizeName(name string) string {
-	// Convert the name to lowercase
-	name = strings.ToLower(name)
-	// Replace spaces with hyphens
-	name = strings.ReplaceAll(name, " ", "-")
-	// Remove invalid characters
-	re := regexp.MustCompile(`[^a-z0-9-_]`)
-	name = re.ReplaceAllString(name, "")
+// UpdateLocation moves the user to loc, snapped to the grid locations are stored
+// on, and returns where they were put. Staying in the same grid cell writes
+// nothing; moving to another one sooner than entity.LocationUpdateInterval
+// after the last move fails with entity.ErrLocationThrottled, along with an
+// entity.QuotaExceededError saying when it can move again.
+func (m *Manager) UpdateLocation(ctx context.Context, userID int, loc entity.Location) (entity.Location, error) {
+	user, err := m.userStore.FindByID(ctx, userID)
+	if err != nil {
+		return entity.Location{}, fmt.Errorf("failed to find user: %w", err)
+	}
+
+	location := loc.Fuzzed()
+	if location == (entity.Location{Lat: user.Lat, Lon: user.Lon}) {
+		return location, nil
+	}
+
+	now := m.now().UTC().Truncate(time.Second)
+
+	if user.LocationUpdatedAt.Valid {
+		if next := user.LocationUpdatedAt.Time.Add(entity.LocationUpdateInterval); now.Before(next) {
+			return entity.Location{}, fmt.Errorf("%w: %w", entity.ErrLocationThrottled, entity.QuotaExceededError{ResetAt: next})
+		}
+	}
+
+	err = m.unitOfWork.Do(ctx, func(ctx context.Context) error {
+		// The store checks the throttle again as it writes, for a move made since
+		// the user was read.
+		err := m.userStore.UpdateLocation(ctx, userID, location, now)
+		if errors.Is(err, entity.ErrLocationThrottled) {
+			next := now.Add(entity.LocationUpdateInterval)
+
+			return fmt.Errorf("%w: %w", entity.ErrLocationThrottled, entity.QuotaExceededError{ResetAt: next})
+		}
+
+		if err != nil {
+			return fmt.Errorf("failed to update location: %w", err)
+		}
+
+		if err := m.userIndexer.UpdateLocation(ctx, userID, location); err != nil {
+			return fmt.Errorf("failed to index: %w", err)
+		}
+
+		return nil
+	})
+	if err != nil {
+		return entity.Location{}, err
+	}
 
-	return name
+	return location, nil
 }
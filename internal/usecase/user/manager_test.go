@@ -4,8 +4,10 @@ import (
 	"context"
 	"errors"
 	"testing"
+	"time"
 
 	"github.com/golang/mock/gomock"
+	null "github.com/guregu/null/v5"
 	"github.com/stretchr/testify/assert"
 
 	"github.com/colmmurphy91/muzz/internal/adapter/mysql/user/model"
@@ -17,64 +19,110 @@ func TestManager_CreateUser(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
 
-	mockUserCreator := mocks.NewMockuserCreator(ctrl)
+	mockUserStore := mocks.NewMockuserStore(ctrl)
 	mockUserIndexer := mocks.NewMockuserIndexer(ctrl)
+	mockPasswordHasher := mocks.NewMockpasswordHasher(ctrl)
 
-	manager := NewManager(mockUserCreator, mockUserIndexer)
+	manager := NewManager(mockUserStore, mockUserIndexer, mockPasswordHasher, mocks.NewMockunitOfWork(ctrl))
+
+	registration := entity.Registration{
+		Email:       "test-email@muzz.com",
+		Password:    "password123",
+		Name:        "Test User",
+		Gender:      "male",
+		DateOfBirth: time.Now().AddDate(-30, 0, -1),
+		Location:    entity.Location{Lat: 51.5, Lon: -0.12},
+	}
 
 	tests := []struct {
 		name          string
 		setupMocks    func()
-		expectedUser  entity.User
+		expectedUser  model.User
 		expectedError error
 	}{
 		{
 			name: "successful creation and indexing",
 			setupMocks: func() {
-				mockUserCreator.EXPECT().
-					CreateUser(gomock.Any(), gomock.Any()).
+				mockPasswordHasher.EXPECT().
+					Hash(registration.Password).
+					Return("hashed-password", nil)
+
+				mockUserStore.EXPECT().
+					CreateUser(gomock.Any(), model.User{
+						Email:       registration.Email,
+						Password:    "hashed-password",
+						Name:        registration.Name,
+						Gender:      registration.Gender,
+						DateOfBirth: registration.DateOfBirth,
+						Age:         30,
+						Lat:         51.5,
+						Lon:         -0.12,
+					}).
 					Return(model.User{
 						ID:       1,
 						Email:    "test-email@muzz.com",
-						Password: "password123",
+						Password: "hashed-password",
 						Name:     "Test User",
 						Gender:   "Male",
 						Age:      30,
 					}, nil)
 
 				mockUserIndexer.EXPECT().
-					Index(gomock.Any(), gomock.Any()).
+					Index(gomock.Any(), entity.User{
+						ID:     1,
+						Name:   "Test User",
+						Gender: "Male",
+						Age:    30,
+					}).
 					Return(nil)
 			},
-			expectedUser: entity.User{
+			expectedUser: model.User{
 				ID:       1,
 				Email:    "test-email@muzz.com",
-				Password: "password123",
+				Password: "hashed-password",
 				Name:     "Test User",
 				Gender:   "Male",
 				Age:      30,
 			},
 			expectedError: nil,
 		},
+		{
+			name: "password hashing failure",
+			setupMocks: func() {
+				mockPasswordHasher.EXPECT().
+					Hash(registration.Password).
+					Return("", errors.New("hashing failed"))
+			},
+			expectedUser:  model.User{},
+			expectedError: errors.New("failed to hash password: hashing failed"),
+		},
 		{
 			name: "user creation failure",
 			setupMocks: func() {
-				mockUserCreator.EXPECT().
+				mockPasswordHasher.EXPECT().
+					Hash(registration.Password).
+					Return("hashed-password", nil)
+
+				mockUserStore.EXPECT().
 					CreateUser(gomock.Any(), gomock.Any()).
 					Return(model.User{}, errors.New("creation failed"))
 			},
-			expectedUser:  entity.User{},
+			expectedUser:  model.User{},
 			expectedError: errors.New("failed to create user: creation failed"),
 		},
 		{
 			name: "indexing failure",
 			setupMocks: func() {
-				mockUserCreator.EXPECT().
+				mockPasswordHasher.EXPECT().
+					Hash(registration.Password).
+					Return("hashed-password", nil)
+
+				mockUserStore.EXPECT().
 					CreateUser(gomock.Any(), gomock.Any()).
 					Return(model.User{
 						ID:       1,
 						Email:    "test-email@muzz.com",
-						Password: "password123",
+						Password: "hashed-password",
 						Name:     "Test User",
 						Gender:   "Male",
 						Age:      30,
@@ -84,7 +132,7 @@ func TestManager_CreateUser(t *testing.T) {
 					Index(gomock.Any(), gomock.Any()).
 					Return(errors.New("indexing failed"))
 			},
-			expectedUser:  entity.User{},
+			expectedUser:  model.User{},
 			expectedError: errors.New("failed to index: indexing failed"),
 		},
 	}
@@ -93,7 +141,7 @@ func TestManager_CreateUser(t *testing.T) {
 		t.Run(tt.name, func(t *testing.T) {
 			tt.setupMocks()
 
-			user, err := manager.CreateUser(context.Background())
+			user, err := manager.CreateUser(context.Background(), registration)
 
 			assert.Equal(t, tt.expectedUser, user)
 
@@ -105,3 +153,249 @@ func TestManager_CreateUser(t *testing.T) {
 		})
 	}
 }
+
+func TestManager_UpdateProfile(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockUserStore := mocks.NewMockuserStore(ctrl)
+	mockUserIndexer := mocks.NewMockuserIndexer(ctrl)
+	mockUnitOfWork := mocks.NewMockunitOfWork(ctrl)
+	manager := NewManager(mockUserStore, mockUserIndexer, mocks.NewMockpasswordHasher(ctrl), mockUnitOfWork)
+
+	ctx := context.Background()
+	dob := time.Now().AddDate(-25, 0, -1)
+	stored := model.User{
+		ID:      1,
+		Email:   "test-email@muzz.com",
+		Name:    "Test User",
+		Gender:  "male",
+		Age:     30,
+		Lat:     51.5,
+		Lon:     -0.12,
+		Version: 3,
+	}
+	updated := stored
+	updated.Bio = "Likes hiking"
+	updated.DateOfBirth = dob
+	updated.Age = 25
+
+	mockUnitOfWork.EXPECT().Do(ctx, gomock.Any()).DoAndReturn(
+		func(ctx context.Context, fn func(ctx context.Context) error) error {
+			return fn(ctx)
+		}).AnyTimes()
+
+	update := entity.ProfileUpdate{
+		Bio:         null.StringFrom("Likes hiking"),
+		DateOfBirth: null.TimeFrom(dob),
+		Version:     3,
+	}
+
+	tests := []struct {
+		name          string
+		update        entity.ProfileUpdate
+		setupMocks    func()
+		expected      model.User
+		expectedError error
+	}{
+		{
+			name:   "changes only what is set and reindexes",
+			update: update,
+			setupMocks: func() {
+				mockUserStore.EXPECT().FindByID(ctx, 1).Return(stored, nil)
+				mockUserStore.EXPECT().UpdateProfile(ctx, updated).Return(nil)
+				mockUserIndexer.EXPECT().UpdateProfile(ctx, updated.Profile()).Return(nil)
+			},
+			expected: func() model.User {
+				user := updated
+				user.Version = 4
+
+				return user
+			}(),
+		},
+		{
+			name:   "stale version",
+			update: entity.ProfileUpdate{Name: null.StringFrom("New"), Version: 2},
+			setupMocks: func() {
+				mockUserStore.EXPECT().FindByID(ctx, 1).Return(stored, nil)
+			},
+			expectedError: entity.ErrVersionConflict,
+		},
+		{
+			name:   "changed by someone else while updating",
+			update: update,
+			setupMocks: func() {
+				mockUserStore.EXPECT().FindByID(ctx, 1).Return(stored, nil)
+				mockUserStore.EXPECT().UpdateProfile(ctx, updated).Return(entity.ErrVersionConflict)
+			},
+			expectedError: errors.New("failed to update profile: profile was changed since it was read"),
+		},
+		{
+			name:   "indexing failure",
+			update: update,
+			setupMocks: func() {
+				mockUserStore.EXPECT().FindByID(ctx, 1).Return(stored, nil)
+				mockUserStore.EXPECT().UpdateProfile(ctx, updated).Return(nil)
+				mockUserIndexer.EXPECT().UpdateProfile(ctx, gomock.Any()).Return(errors.New("es error"))
+			},
+			expectedError: errors.New("failed to index: es error"),
+		},
+		{
+			name:   "user not found",
+			update: update,
+			setupMocks: func() {
+				mockUserStore.EXPECT().FindByID(ctx, 1).Return(model.User{}, entity.ErrUserNotFound)
+			},
+			expectedError: errors.New("failed to find user: user does not exists"),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.setupMocks()
+
+			user, err := manager.UpdateProfile(ctx, 1, tt.update)
+
+			if tt.expectedError != nil {
+				assert.EqualError(t, err, tt.expectedError.Error())
+				return
+			}
+
+			assert.NoError(t, err)
+			assert.Equal(t, tt.expected, user)
+		})
+	}
+}
+
+func TestManager_UpdateLocation(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockUserStore := mocks.NewMockuserStore(ctrl)
+	mockUserIndexer := mocks.NewMockuserIndexer(ctrl)
+	mockUnitOfWork := mocks.NewMockunitOfWork(ctrl)
+	manager := NewManager(mockUserStore, mockUserIndexer, mocks.NewMockpasswordHasher(ctrl), mockUnitOfWork)
+
+	now := time.Date(2024, 7, 8, 12, 0, 0, 0, time.UTC)
+	manager.now = func() time.Time { return now }
+
+	ctx := context.Background()
+	stored := model.User{
+		ID:                1,
+		Lat:               51.5,
+		Lon:               -0.12,
+		LocationUpdatedAt: null.TimeFrom(now.Add(-entity.LocationUpdateInterval)),
+	}
+	moved := entity.Location{Lat: 53.35, Lon: -6.26}
+
+	mockUnitOfWork.EXPECT().Do(ctx, gomock.Any()).DoAndReturn(
+		func(ctx context.Context, fn func(ctx context.Context) error) error {
+			return fn(ctx)
+		}).AnyTimes()
+
+	tests := []struct {
+		name          string
+		location      entity.Location
+		setupMocks    func()
+		expected      entity.Location
+		expectedError error
+	}{
+		{
+			name:     "stores the location snapped to the grid",
+			location: entity.Location{Lat: 53.3498, Lon: -6.2603},
+			setupMocks: func() {
+				mockUserStore.EXPECT().FindByID(ctx, 1).Return(stored, nil)
+				mockUserStore.EXPECT().UpdateLocation(ctx, 1, moved, now).Return(nil)
+				mockUserIndexer.EXPECT().UpdateLocation(ctx, 1, moved).Return(nil)
+			},
+			expected: moved,
+		},
+		{
+			name:     "first update",
+			location: moved,
+			setupMocks: func() {
+				user := stored
+				user.LocationUpdatedAt = null.Time{}
+
+				mockUserStore.EXPECT().FindByID(ctx, 1).Return(user, nil)
+				mockUserStore.EXPECT().UpdateLocation(ctx, 1, moved, now).Return(nil)
+				mockUserIndexer.EXPECT().UpdateLocation(ctx, 1, moved).Return(nil)
+			},
+			expected: moved,
+		},
+		{
+			name:     "same grid cell writes nothing",
+			location: entity.Location{Lat: 51.5012, Lon: -0.1191},
+			setupMocks: func() {
+				user := stored
+				user.LocationUpdatedAt = null.TimeFrom(now)
+
+				mockUserStore.EXPECT().FindByID(ctx, 1).Return(user, nil)
+			},
+			expected: entity.Location{Lat: 51.5, Lon: -0.12},
+		},
+		{
+			name:     "moved again too soon",
+			location: moved,
+			setupMocks: func() {
+				user := stored
+				user.LocationUpdatedAt = null.TimeFrom(now.Add(-time.Second))
+
+				mockUserStore.EXPECT().FindByID(ctx, 1).Return(user, nil)
+			},
+			expectedError: errors.New("location was updated too recently: quota exceeded until 2024-07-08T12:00:59Z"),
+		},
+		{
+			name:     "moved by another request meanwhile",
+			location: moved,
+			setupMocks: func() {
+				mockUserStore.EXPECT().FindByID(ctx, 1).Return(stored, nil)
+				mockUserStore.EXPECT().UpdateLocation(ctx, 1, moved, now).Return(entity.ErrLocationThrottled)
+			},
+			expectedError: errors.New("location was updated too recently: quota exceeded until 2024-07-08T12:01:00Z"),
+		},
+		{
+			name:     "store failure",
+			location: moved,
+			setupMocks: func() {
+				mockUserStore.EXPECT().FindByID(ctx, 1).Return(stored, nil)
+				mockUserStore.EXPECT().UpdateLocation(ctx, 1, moved, now).Return(errors.New("db error"))
+			},
+			expectedError: errors.New("failed to update location: db error"),
+		},
+		{
+			name:     "indexing failure",
+			location: moved,
+			setupMocks: func() {
+				mockUserStore.EXPECT().FindByID(ctx, 1).Return(stored, nil)
+				mockUserStore.EXPECT().UpdateLocation(ctx, 1, moved, now).Return(nil)
+				mockUserIndexer.EXPECT().UpdateLocation(ctx, 1, moved).Return(errors.New("es error"))
+			},
+			expectedError: errors.New("failed to index: es error"),
+		},
+		{
+			name:     "user not found",
+			location: moved,
+			setupMocks: func() {
+				mockUserStore.EXPECT().FindByID(ctx, 1).Return(model.User{}, entity.ErrUserNotFound)
+			},
+			expectedError: errors.New("failed to find user: user does not exists"),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.setupMocks()
+
+			location, err := manager.UpdateLocation(ctx, 1, tt.location)
+
+			if tt.expectedError != nil {
+				assert.EqualError(t, err, tt.expectedError.Error())
+				return
+			}
+
+			assert.NoError(t, err)
+			assert.Equal(t, tt.expected, location)
+		})
+	}
+}
@@ -7,37 +7,38 @@ package mocks
 import (
 	context "context"
 	reflect "reflect"
+	time "time"
 
 	model "github.com/colmmurphy91/muzz/internal/adapter/mysql/user/model"
 	entity "github.com/colmmurphy91/muzz/internal/entity"
 	gomock "github.com/golang/mock/gomock"
 )
 
-// MockuserCreator is a mock of userCreator interface.
-type MockuserCreator struct {
+// MockuserStore is a mock of userStore interface.
+type MockuserStore struct {
 	ctrl     *gomock.Controller
-	recorder *MockuserCreatorMockRecorder
+	recorder *MockuserStoreMockRecorder
 }
 
-// MockuserCreatorMockRecorder is the mock recorder for MockuserCreator.
-type MockuserCreatorMockRecorder struct {
-	mock *MockuserCreator
+// MockuserStoreMockRecorder is the mock recorder for MockuserStore.
+type MockuserStoreMockRecorder struct {
+	mock *MockuserStore
 }
 
-// NewMockuserCreator creates a new mock instance.
-func NewMockuserCreator(ctrl *gomock.Controller) *MockuserCreator {
-	mock := &MockuserCreator{ctrl: ctrl}
-	mock.recorder = &MockuserCreatorMockRecorder{mock}
+// NewMockuserStore creates a new mock instance.
+func NewMockuserStore(ctrl *gomock.Controller) *MockuserStore {
+	mock := &MockuserStore{ctrl: ctrl}
+	mock.recorder = &MockuserStoreMockRecorder{mock}
 	return mock
 }
 
 // EXPECT returns an object that allows the caller to indicate expected use.
-func (m *MockuserCreator) EXPECT() *MockuserCreatorMockRecorder {
+func (m *MockuserStore) EXPECT() *MockuserStoreMockRecorder {
 	return m.recorder
 }
 
 // CreateUser mocks base method.
-func (m *MockuserCreator) CreateUser(ctx context.Context, user model.User) (model.User, error) {
+func (m *MockuserStore) CreateUser(ctx context.Context, user model.User) (model.User, error) {
 	m.ctrl.T.Helper()
 	ret := m.ctrl.Call(m, "CreateUser", ctx, user)
 	ret0, _ := ret[0].(model.User)
@@ -46,9 +47,52 @@ func (m *MockuserCreator) CreateUser(ctx context.Context, user model.User) (mode
 }
 
 // CreateUser indicates an expected call of CreateUser.
-func (mr *MockuserCreatorMockRecorder) CreateUser(ctx, user interface{}) *gomock.Call {
+func (mr *MockuserStoreMockRecorder) CreateUser(ctx, user interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateUser", reflect.TypeOf((*MockuserCreator)(nil).CreateUser), ctx, user)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateUser", reflect.TypeOf((*MockuserStore)(nil).CreateUser), ctx, user)
+}
+
+// FindByID mocks base method.
+func (m *MockuserStore) FindByID(ctx context.Context, userID int) (model.User, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindByID", ctx, userID)
+	ret0, _ := ret[0].(model.User)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindByID indicates an expected call of FindByID.
+func (mr *MockuserStoreMockRecorder) FindByID(ctx, userID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindByID", reflect.TypeOf((*MockuserStore)(nil).FindByID), ctx, userID)
+}
+
+// UpdateLocation mocks base method.
+func (m *MockuserStore) UpdateLocation(ctx context.Context, userID int, loc entity.Location, at time.Time) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateLocation", ctx, userID, loc, at)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateLocation indicates an expected call of UpdateLocation.
+func (mr *MockuserStoreMockRecorder) UpdateLocation(ctx, userID, loc, at interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateLocation", reflect.TypeOf((*MockuserStore)(nil).UpdateLocation), ctx, userID, loc, at)
+}
+
+// UpdateProfile mocks base method.
+func (m *MockuserStore) UpdateProfile(ctx context.Context, user model.User) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateProfile", ctx, user)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateProfile indicates an expected call of UpdateProfile.
+func (mr *MockuserStoreMockRecorder) UpdateProfile(ctx, user interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateProfile", reflect.TypeOf((*MockuserStore)(nil).UpdateProfile), ctx, user)
 }
 
 // MockuserIndexer is a mock of userIndexer interface.
@@ -87,3 +131,106 @@ func (mr *MockuserIndexerMockRecorder) Index(ctx, user interface{}) *gomock.Call
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Index", reflect.TypeOf((*MockuserIndexer)(nil).Index), ctx, user)
 }
+
+// UpdateLocation mocks base method.
+func (m *MockuserIndexer) UpdateLocation(ctx context.Context, userID int, loc entity.Location) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateLocation", ctx, userID, loc)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateLocation indicates an expected call of UpdateLocation.
+func (mr *MockuserIndexerMockRecorder) UpdateLocation(ctx, userID, loc interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateLocation", reflect.TypeOf((*MockuserIndexer)(nil).UpdateLocation), ctx, userID, loc)
+}
+
+// UpdateProfile mocks base method.
+func (m *MockuserIndexer) UpdateProfile(ctx context.Context, user entity.User) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateProfile", ctx, user)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateProfile indicates an expected call of UpdateProfile.
+func (mr *MockuserIndexerMockRecorder) UpdateProfile(ctx, user interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateProfile", reflect.TypeOf((*MockuserIndexer)(nil).UpdateProfile), ctx, user)
+}
+
+// MockpasswordHasher is a mock of passwordHasher interface.
+type MockpasswordHasher struct {
+	ctrl     *gomock.Controller
+	recorder *MockpasswordHasherMockRecorder
+}
+
+// MockpasswordHasherMockRecorder is the mock recorder for MockpasswordHasher.
+type MockpasswordHasherMockRecorder struct {
+	mock *MockpasswordHasher
+}
+
+// NewMockpasswordHasher creates a new mock instance.
+func NewMockpasswordHasher(ctrl *gomock.Controller) *MockpasswordHasher {
+	mock := &MockpasswordHasher{ctrl: ctrl}
+	mock.recorder = &MockpasswordHasherMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockpasswordHasher) EXPECT() *MockpasswordHasherMockRecorder {
+	return m.recorder
+}
+
+// Hash mocks base method.
+func (m *MockpasswordHasher) Hash(password string) (string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Hash", password)
+	ret0, _ := ret[0].(string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Hash indicates an expected call of Hash.
+func (mr *MockpasswordHasherMockRecorder) Hash(password interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Hash", reflect.TypeOf((*MockpasswordHasher)(nil).Hash), password)
+}
+
+// MockunitOfWork is a mock of unitOfWork interface.
+type MockunitOfWork struct {
+	ctrl     *gomock.Controller
+	recorder *MockunitOfWorkMockRecorder
+}
+
+// MockunitOfWorkMockRecorder is the mock recorder for MockunitOfWork.
+type MockunitOfWorkMockRecorder struct {
+	mock *MockunitOfWork
+}
+
+// NewMockunitOfWork creates a new mock instance.
+func NewMockunitOfWork(ctrl *gomock.Controller) *MockunitOfWork {
+	mock := &MockunitOfWork{ctrl: ctrl}
+	mock.recorder = &MockunitOfWorkMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockunitOfWork) EXPECT() *MockunitOfWorkMockRecorder {
+	return m.recorder
+}
+
+// Do mocks base method.
+func (m *MockunitOfWork) Do(ctx context.Context, fn func(context.Context) error) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Do", ctx, fn)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Do indicates an expected call of Do.
+func (mr *MockunitOfWorkMockRecorder) Do(ctx, fn interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Do", reflect.TypeOf((*MockunitOfWork)(nil).Do), ctx, fn)
+}
@@ -0,0 +1,9 @@
+ALTER TABLE users
+    ADD COLUMN date_of_birth DATE NULL AFTER gender;
+
+UPDATE users
+SET date_of_birth = DATE_SUB(CURDATE(), INTERVAL age YEAR)
+WHERE date_of_birth IS NULL;
+
+ALTER TABLE users
+    MODIFY COLUMN date_of_birth DATE NOT NULL;
@@ -0,0 +1,24 @@
+CREATE TABLE refresh_tokens (
+                                id INT AUTO_INCREMENT PRIMARY KEY,
+                                family_id VARCHAR(36) NOT NULL,
+                                user_id INT NOT NULL,
+                                token_hash CHAR(64) NOT NULL,
+                                access_jti VARCHAR(36) NOT NULL,
+                                access_expires_at TIMESTAMP NOT NULL,
+                                expires_at TIMESTAMP NOT NULL,
+                                rotated_at TIMESTAMP NULL,
+                                revoked_at TIMESTAMP NULL,
+                                created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+                                UNIQUE KEY unique_token_hash (token_hash),
+                                KEY family_id_index (family_id),
+                                KEY user_id_index (user_id)
+);
+
+-- Access tokens that must be rejected before they expire. Rows can be removed
+-- once expires_at has passed.
+CREATE TABLE revoked_tokens (
+                                jti VARCHAR(36) PRIMARY KEY,
+                                expires_at TIMESTAMP NOT NULL,
+                                created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+                                KEY expires_at_index (expires_at)
+);
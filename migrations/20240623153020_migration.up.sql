@@ -0,0 +1,10 @@
+CREATE TABLE discovery_preferences (
+                                       user_id INT PRIMARY KEY,
+                                       min_age INT NULL,
+                                       max_age INT NULL,
+                                       genders VARCHAR(255) NOT NULL DEFAULT '',
+                                       max_distance_km DOUBLE NULL,
+                                       show_me BOOLEAN NOT NULL DEFAULT TRUE,
+                                       created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+                                       updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP
+);
@@ -0,0 +1,2 @@
+ALTER TABLE swipes
+    DROP INDEX target_preference_index;
@@ -0,0 +1,3 @@
+-- Counting the swipes someone has received reads swipes by target.
+ALTER TABLE swipes
+    ADD INDEX target_preference_index (target_id, preference);
@@ -0,0 +1,2 @@
+ALTER TABLE matches
+    DROP INDEX user2_id_index;
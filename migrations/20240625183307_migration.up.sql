@@ -0,0 +1,3 @@
+-- Matches are listed for either side of the pair.
+ALTER TABLE matches
+    ADD INDEX user2_id_index (user2_id);
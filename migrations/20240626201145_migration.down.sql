@@ -0,0 +1,3 @@
+ALTER TABLE matches
+    DROP COLUMN unmatched_at,
+    DROP COLUMN unmatched_by;
@@ -0,0 +1,3 @@
+ALTER TABLE matches
+    ADD COLUMN unmatched_at TIMESTAMP NULL,
+    ADD COLUMN unmatched_by INT NULL;
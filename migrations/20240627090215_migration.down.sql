@@ -0,0 +1,3 @@
+ALTER TABLE matches
+    DROP INDEX unique_pair,
+    ADD UNIQUE KEY unique_match (user1_id, user2_id, match_id);
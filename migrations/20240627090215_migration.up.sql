@@ -0,0 +1,19 @@
+-- Store every pair smaller id first, whoever swiped last.
+UPDATE matches m
+    JOIN (SELECT id, LEAST(user1_id, user2_id) AS low, GREATEST(user1_id, user2_id) AS high FROM matches) p
+    ON p.id = m.id
+SET m.user1_id = p.low,
+    m.user2_id = p.high;
+
+-- Concurrent swipes could match a pair twice; keep the first.
+DELETE m
+FROM matches m
+    JOIN matches kept
+    ON kept.user1_id = m.user1_id AND kept.user2_id = m.user2_id AND kept.id < m.id;
+
+UPDATE matches
+SET match_id = SHA2(CONCAT(user1_id, ':', user2_id), 256);
+
+ALTER TABLE matches
+    DROP INDEX unique_match,
+    ADD UNIQUE KEY unique_pair (user1_id, user2_id);
@@ -0,0 +1,5 @@
+DROP TABLE IF EXISTS swipe_history;
+
+ALTER TABLE swipes
+    DROP INDEX user_updated_at_index,
+    DROP COLUMN updated_at;
@@ -0,0 +1,18 @@
+ALTER TABLE swipes
+    ADD COLUMN updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP,
+    ADD INDEX user_updated_at_index (user_id, updated_at);
+
+UPDATE swipes
+SET updated_at = created_at;
+
+-- Every swipe, change of mind and rewind, kept after the swipe itself is
+-- changed or removed.
+CREATE TABLE swipe_history (
+                               id INT AUTO_INCREMENT PRIMARY KEY,
+                               user_id INT NOT NULL,
+                               target_id INT NOT NULL,
+                               preference ENUM('YES', 'NO') NOT NULL,
+                               action ENUM('SWIPE', 'RESWIPE', 'REWIND') NOT NULL,
+                               created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+                               KEY user_action_created_at_index (user_id, action, created_at)
+);
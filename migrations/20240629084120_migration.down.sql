@@ -0,0 +1,12 @@
+DROP TABLE IF EXISTS notifications;
+
+-- Super likes become plain likes.
+UPDATE swipes SET preference = 'YES' WHERE preference = 'SUPER';
+UPDATE swipe_history SET preference = 'YES' WHERE preference = 'SUPER';
+
+ALTER TABLE swipe_history
+    DROP INDEX user_preference_created_at_index,
+    MODIFY preference ENUM('YES', 'NO') NOT NULL;
+
+ALTER TABLE swipes
+    MODIFY preference ENUM('YES', 'NO') NOT NULL;
@@ -0,0 +1,16 @@
+ALTER TABLE swipes
+    MODIFY preference ENUM('YES', 'NO', 'SUPER') NOT NULL;
+
+ALTER TABLE swipe_history
+    MODIFY preference ENUM('YES', 'NO', 'SUPER') NOT NULL,
+    ADD INDEX user_preference_created_at_index (user_id, preference, created_at);
+
+-- Things a user has been told about, newest first.
+CREATE TABLE notifications (
+                               id INT AUTO_INCREMENT PRIMARY KEY,
+                               user_id INT NOT NULL,
+                               type VARCHAR(64) NOT NULL,
+                               actor_id INT NOT NULL,
+                               created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+                               KEY user_id_index (user_id, id)
+);
@@ -0,0 +1 @@
+DROP TABLE IF EXISTS like_quota_uses;
@@ -0,0 +1,7 @@
+-- When each user used one of their likes, kept for as long as the quota window.
+CREATE TABLE like_quota_uses (
+                                 id INT AUTO_INCREMENT PRIMARY KEY,
+                                 user_id INT NOT NULL,
+                                 used_at TIMESTAMP(3) NOT NULL,
+                                 KEY user_used_at_index (user_id, used_at)
+);
@@ -0,0 +1,11 @@
+-- The result of each batched swipe under the key its client chose, so resending
+-- a batch replays it instead of swiping again.
+CREATE TABLE swipe_keys (
+                            user_id INT NOT NULL,
+                            swipe_key VARCHAR(64) NOT NULL,
+                            target_id INT NOT NULL,
+                            preference ENUM('YES', 'NO', 'SUPER') NOT NULL,
+                            match_id INT NOT NULL DEFAULT 0,
+                            created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+                            PRIMARY KEY (user_id, swipe_key)
+);
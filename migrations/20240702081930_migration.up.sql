@@ -0,0 +1,10 @@
+-- Responses to requests sent with an Idempotency-Key, replayed when the key is
+-- sent again. status and body are null while the first request is in progress.
+CREATE TABLE idempotency_keys (
+                                  key_hash CHAR(64) PRIMARY KEY,
+                                  fingerprint CHAR(64) NOT NULL,
+                                  status INT NULL,
+                                  body MEDIUMBLOB NULL,
+                                  expires_at TIMESTAMP(3) NOT NULL,
+                                  KEY expires_at_index (expires_at)
+);
@@ -0,0 +1,2 @@
+ALTER TABLE notifications
+    DROP COLUMN match_id;
@@ -0,0 +1,2 @@
+ALTER TABLE notifications
+    ADD COLUMN match_id INT NOT NULL DEFAULT 0 AFTER actor_id;
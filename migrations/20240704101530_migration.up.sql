@@ -0,0 +1,24 @@
+-- The conversation of a match, started by its first message.
+CREATE TABLE conversations (
+                               match_id INT PRIMARY KEY,
+                               last_message_id INT NOT NULL DEFAULT 0,
+                               created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE TABLE messages (
+                          id INT AUTO_INCREMENT PRIMARY KEY,
+                          match_id INT NOT NULL,
+                          sender_id INT NOT NULL,
+                          body VARCHAR(2000) NOT NULL,
+                          created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+                          KEY match_id_index (match_id, id)
+);
+
+-- How far each of the pair has read their conversation.
+CREATE TABLE conversation_reads (
+                                    match_id INT NOT NULL,
+                                    user_id INT NOT NULL,
+                                    last_read_id INT NOT NULL,
+                                    read_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+                                    PRIMARY KEY (match_id, user_id)
+);
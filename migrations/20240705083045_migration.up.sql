@@ -0,0 +1,23 @@
+-- Who has blocked whom. A block keeps the pair apart both ways.
+CREATE TABLE blocks (
+                        blocker_id INT NOT NULL,
+                        blocked_id INT NOT NULL,
+                        created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+                        PRIMARY KEY (blocker_id, blocked_id),
+                        KEY blocked_id_index (blocked_id)
+);
+
+-- Reports about users, queued for moderators.
+CREATE TABLE reports (
+                         id INT AUTO_INCREMENT PRIMARY KEY,
+                         reporter_id INT NOT NULL,
+                         reported_id INT NOT NULL,
+                         reason ENUM('spam', 'harassment', 'inappropriate_content', 'fake_profile', 'underage', 'other') NOT NULL,
+                         details VARCHAR(1000) NOT NULL DEFAULT '',
+                         status ENUM('open', 'in_review', 'actioned', 'dismissed') NOT NULL DEFAULT 'open',
+                         reviewed_by INT NULL,
+                         created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+                         updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP,
+                         KEY status_index (status, id),
+                         KEY reported_id_index (reported_id)
+);
@@ -0,0 +1,6 @@
+DROP TABLE IF EXISTS audit_log;
+
+ALTER TABLE users
+    DROP COLUMN shadow_banned,
+    DROP COLUMN status,
+    DROP COLUMN role;
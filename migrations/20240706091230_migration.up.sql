@@ -0,0 +1,21 @@
+-- What a user may do, and whether moderators have taken them out of the app.
+-- A shadow banned user can still use the app but is never discovered.
+ALTER TABLE users
+    ADD COLUMN role ENUM('user', 'moderator', 'admin') NOT NULL DEFAULT 'user',
+    ADD COLUMN status ENUM('active', 'suspended', 'banned') NOT NULL DEFAULT 'active',
+    ADD COLUMN shadow_banned BOOLEAN NOT NULL DEFAULT FALSE;
+
+-- Every action taken through the admin API. What target_id refers to depends
+-- on the action: a user, a report or a match. detail is what it was changed
+-- to, where the action alone does not say.
+CREATE TABLE audit_log (
+                           id INT AUTO_INCREMENT PRIMARY KEY,
+                           actor_id INT NOT NULL,
+                           action VARCHAR(64) NOT NULL,
+                           target_id INT NOT NULL,
+                           detail VARCHAR(64) NOT NULL DEFAULT '',
+                           reason VARCHAR(1000) NOT NULL DEFAULT '',
+                           created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+                           KEY actor_id_index (actor_id, id),
+                           KEY action_target_index (action, target_id)
+);
@@ -0,0 +1,5 @@
+-- version is bumped by every profile edit, so an edit made from a stale read
+-- is rejected instead of silently undoing another.
+ALTER TABLE users
+    ADD COLUMN bio VARCHAR(500) NOT NULL DEFAULT '' AFTER name,
+    ADD COLUMN version INT NOT NULL DEFAULT 1;
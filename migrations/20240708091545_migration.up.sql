@@ -0,0 +1,7 @@
+-- When the user last moved to a new grid cell, so location updates can be
+-- throttled.
+ALTER TABLE users
+    ADD COLUMN location_updated_at TIMESTAMP NULL;
+
+-- Locations are stored snapped to a grid of a hundredth of a degree.
+UPDATE users SET lat = ROUND(lat, 2), lon = ROUND(lon, 2);